@@ -0,0 +1,184 @@
+// Copyright 2024 Stacklok, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriftPolicy controls what the operator does when it detects that a live
+// Deployment/Service no longer matches the desired state rendered from
+// MCPServerSpec.
+type DriftPolicy string
+
+const (
+	// DriftPolicyAlert only updates Status.Drift when drift is detected; it does
+	// not touch the live objects.
+	DriftPolicyAlert DriftPolicy = "Alert"
+	// DriftPolicyAutoHeal re-applies the expected manifest when drift is detected.
+	DriftPolicyAutoHeal DriftPolicy = "AutoHeal"
+)
+
+// DriftSyncStatus reports whether the live objects owned by an MCPServer match
+// the desired state derived from its Spec.
+type DriftSyncStatus string
+
+const (
+	// DriftStatusInSync indicates no drift was detected on the last check.
+	DriftStatusInSync DriftSyncStatus = "InSync"
+	// DriftStatusOutOfSync indicates the live Deployment or Service has diverged
+	// from the desired state.
+	DriftStatusOutOfSync DriftSyncStatus = "OutOfSync"
+	// DriftStatusUnknown indicates drift has not been checked yet.
+	DriftStatusUnknown DriftSyncStatus = ""
+)
+
+// MCPServerSpec defines the desired state of an MCPServer.
+type MCPServerSpec struct {
+	// Image is the container image for the MCP server.
+	Image string `json:"image"`
+
+	// Port is the port the MCP server listens on.
+	Port int32 `json:"port"`
+
+	// Env sets environment variables in the MCP server container.
+	// +optional
+	Env []EnvVar `json:"env,omitempty"`
+
+	// Replicas is the desired number of replicas for the proxy Deployment.
+	// +optional
+	// +kubebuilder:default=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Transport is the MCP transport used by the server (e.g. "stdio" or "sse").
+	// +optional
+	Transport string `json:"transport,omitempty"`
+
+	// DependsOn lists the names of other MCPServer resources in the same
+	// namespace that must be Ready before this one is started.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// ResourceOverrides allows overriding labels/annotations on resources
+	// created for this MCPServer.
+	// +optional
+	ResourceOverrides *ResourceOverrides `json:"resourceOverrides,omitempty"`
+
+	// DriftPolicy controls what the operator does when the live Deployment or
+	// Service diverges from the desired state. Defaults to Alert.
+	// +optional
+	// +kubebuilder:validation:Enum=Alert;AutoHeal
+	// +kubebuilder:default=Alert
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
+
+	// DriftCheckInterval is how often the drift detector compares live state
+	// against the desired state, independent of the main reconcile trigger.
+	// +optional
+	// +kubebuilder:default="5m"
+	DriftCheckInterval metav1.Duration `json:"driftCheckInterval,omitempty"`
+}
+
+// EnvVar represents an environment variable set on the MCP server container.
+type EnvVar struct {
+	// Name is the environment variable name.
+	Name string `json:"name"`
+	// Value is the environment variable value.
+	Value string `json:"value"`
+}
+
+// ResourceOverrides allows overriding metadata on the resources the operator
+// creates for an MCPServer.
+type ResourceOverrides struct {
+	// ProxyDeployment overrides metadata on the proxy Deployment.
+	// +optional
+	ProxyDeployment *ResourceMetadataOverrides `json:"proxyDeployment,omitempty"`
+
+	// ProxyService overrides metadata on the proxy Service.
+	// +optional
+	ProxyService *ResourceMetadataOverrides `json:"proxyService,omitempty"`
+}
+
+// ResourceMetadataOverrides carries additional labels/annotations to merge
+// onto a created resource. Default labels/annotations always take precedence
+// over these overrides.
+type ResourceMetadataOverrides struct {
+	// Labels are additional labels to merge onto the resource.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are additional annotations to merge onto the resource.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DriftStatus reports the result of the most recent drift check for an
+// MCPServer's owned resources.
+type DriftStatus struct {
+	// Status is InSync or OutOfSync.
+	// +optional
+	Status DriftSyncStatus `json:"status,omitempty"`
+
+	// DriftedPaths lists the diffed field paths found on the last check, e.g.
+	// "deployment.spec.template.spec.containers[0].image".
+	// +optional
+	DriftedPaths []string `json:"driftedPaths,omitempty"`
+
+	// LastCheckedTime is when the drift detector last compared live state
+	// against the desired state.
+	// +optional
+	LastCheckedTime metav1.Time `json:"lastCheckedTime,omitempty"`
+}
+
+// MCPServerStatus defines the observed state of an MCPServer.
+type MCPServerStatus struct {
+	// Conditions represent the latest available observations of the
+	// MCPServer's state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// URL is the resolved endpoint of the MCP server, once available.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Drift reports the result of the most recent drift check.
+	// +optional
+	Drift *DriftStatus `json:"drift,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced
+
+// MCPServer is the Schema for the mcpservers API.
+type MCPServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MCPServerSpec   `json:"spec,omitempty"`
+	Status MCPServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MCPServerList contains a list of MCPServer.
+type MCPServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MCPServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MCPServer{}, &MCPServerList{})
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 Stacklok, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package controllers contains the reconciliation logic for the toolhive
+// operator's custom resources.
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+)
+
+// MCPServerReconciler reconciles an MCPServer object, owning a proxy
+// Deployment and Service that run the ToolHive proxy in front of the MCP
+// server container.
+type MCPServerReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Reconcile brings the live Deployment/Service for an MCPServer in line with
+// its Spec.
+func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var mcpServer mcpv1alpha1.MCPServer
+	if err := r.Get(ctx, req.NamespacedName, &mcpServer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	deployment := r.deploymentForMCPServer(&mcpServer)
+	if err := controllerutil.SetControllerReference(&mcpServer, deployment, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner reference on deployment: %w", err)
+	}
+	if err := r.reconcileDeployment(ctx, deployment); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile deployment: %w", err)
+	}
+
+	service := r.serviceForMCPServer(&mcpServer)
+	if err := controllerutil.SetControllerReference(&mcpServer, service, r.Scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to set owner reference on service: %w", err)
+	}
+	if err := r.reconcileService(ctx, service); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile service: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *MCPServerReconciler) reconcileDeployment(ctx context.Context, desired *appsv1.Deployment) error {
+	var existing appsv1.Deployment
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err != nil {
+		return r.Create(ctx, desired)
+	}
+	desired.ResourceVersion = existing.ResourceVersion
+	return r.Update(ctx, desired)
+}
+
+func (r *MCPServerReconciler) reconcileService(ctx context.Context, desired *corev1.Service) error {
+	var existing corev1.Service
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, &existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err != nil {
+		return r.Create(ctx, desired)
+	}
+	desired.ResourceVersion = existing.ResourceVersion
+	desired.Spec.ClusterIP = existing.Spec.ClusterIP
+	return r.Update(ctx, desired)
+}
+
+// defaultLabels returns the labels the operator always sets on resources it
+// owns for the given MCPServer, regardless of ResourceOverrides.
+func defaultLabels(m *mcpv1alpha1.MCPServer) map[string]string {
+	return map[string]string{
+		"app":                        "mcpserver",
+		"app.kubernetes.io/name":     "mcpserver",
+		"app.kubernetes.io/instance": m.Name,
+		"toolhive":                   "true",
+		"toolhive-name":              m.Name,
+	}
+}
+
+// deploymentForMCPServer renders the desired proxy Deployment for an
+// MCPServer.
+func (*MCPServerReconciler) deploymentForMCPServer(m *mcpv1alpha1.MCPServer) *appsv1.Deployment {
+	labels := defaultLabels(m)
+	annotations := map[string]string{}
+	if overrides := m.Spec.ResourceOverrides; overrides != nil && overrides.ProxyDeployment != nil {
+		labels = mergeStringMaps(labels, overrides.ProxyDeployment.Labels)
+		annotations = mergeStringMaps(annotations, overrides.ProxyDeployment.Annotations)
+	}
+
+	replicas := int32(1)
+	if m.Spec.Replicas != nil {
+		replicas = *m.Spec.Replicas
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        m.Name,
+			Namespace:   m.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"toolhive-name": m.Name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "mcpserver",
+							Image: m.Spec.Image,
+							Ports: []corev1.ContainerPort{
+								{ContainerPort: m.Spec.Port},
+							},
+							Env: envVarsForMCPServer(m),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// serviceForMCPServer renders the desired proxy Service for an MCPServer.
+func (*MCPServerReconciler) serviceForMCPServer(m *mcpv1alpha1.MCPServer) *corev1.Service {
+	labels := defaultLabels(m)
+	annotations := map[string]string{}
+	if overrides := m.Spec.ResourceOverrides; overrides != nil && overrides.ProxyService != nil {
+		labels = mergeStringMaps(labels, overrides.ProxyService.Labels)
+		annotations = mergeStringMaps(annotations, overrides.ProxyService.Annotations)
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        m.Name,
+			Namespace:   m.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"toolhive-name": m.Name},
+			Ports: []corev1.ServicePort{
+				{Port: m.Spec.Port, TargetPort: intOrStringFromPort(m.Spec.Port)},
+			},
+		},
+	}
+}
+
+func intOrStringFromPort(port int32) intstr.IntOrString {
+	return intstr.FromInt(int(port))
+}
+
+func envVarsForMCPServer(m *mcpv1alpha1.MCPServer) []corev1.EnvVar {
+	if len(m.Spec.Env) == 0 {
+		return nil
+	}
+	env := make([]corev1.EnvVar, 0, len(m.Spec.Env))
+	for _, e := range m.Spec.Env {
+		env = append(env, corev1.EnvVar{Name: e.Name, Value: e.Value})
+	}
+	return env
+}
+
+// mergeStringMaps merges overrideMap into defaultMap, with keys in
+// defaultMap always taking precedence over the same key in overrideMap.
+func mergeStringMaps(defaultMap, overrideMap map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaultMap)+len(overrideMap))
+	for k, v := range overrideMap {
+		merged[k] = v
+	}
+	for k, v := range defaultMap {
+		merged[k] = v
+	}
+	return merged
+}
+
+// SetupWithManager wires the reconciler into the controller-runtime manager.
+func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mcpv1alpha1.MCPServer{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Complete(r)
+}
@@ -0,0 +1,240 @@
+// Copyright 2024 Stacklok, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	mcpv1alpha1 "github.com/stacklok/toolhive/cmd/thv-operator/api/v1alpha1"
+)
+
+// defaultDriftCheckInterval is used when an MCPServer does not set
+// Spec.DriftCheckInterval.
+const defaultDriftCheckInterval = 5 * time.Minute
+
+// MCPServerDriftReconciler periodically compares the live Deployment and
+// Service owned by an MCPServer against the state that would be rendered
+// from its Spec, independent of the main MCPServerReconciler's watch-driven
+// reconcile loop. It records the result on Status.Drift and, depending on
+// Spec.DriftPolicy, either just alerts or re-applies the expected manifest.
+type MCPServerDriftReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// Reconcile runs one drift check for the named MCPServer and schedules the
+// next one via RequeueAfter, so the interval is decoupled from any watch
+// events on the MCPServer, its Deployment, or its Service.
+func (r *MCPServerDriftReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var mcpServer mcpv1alpha1.MCPServer
+	if err := r.Get(ctx, req.NamespacedName, &mcpServer); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	interval := mcpServer.Spec.DriftCheckInterval.Duration
+	if interval <= 0 {
+		interval = defaultDriftCheckInterval
+	}
+
+	driftedPaths, err := r.checkDrift(ctx, &mcpServer)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check drift for MCPServer %s: %w", req.NamespacedName, err)
+	}
+
+	status := mcpv1alpha1.DriftStatusInSync
+	if len(driftedPaths) > 0 {
+		status = mcpv1alpha1.DriftStatusOutOfSync
+		r.Recorder.Eventf(&mcpServer, corev1.EventTypeWarning, "DriftDetected",
+			"live state diverged from spec at: %v", driftedPaths)
+
+		if mcpServer.Spec.DriftPolicy == mcpv1alpha1.DriftPolicyAutoHeal {
+			if err := r.heal(ctx, &mcpServer); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to heal drift for MCPServer %s: %w", req.NamespacedName, err)
+			}
+			r.Recorder.Event(&mcpServer, corev1.EventTypeNormal, "DriftHealed", "re-applied expected manifest")
+			status = mcpv1alpha1.DriftStatusInSync
+			driftedPaths = nil
+		}
+	}
+
+	mcpServer.Status.Drift = &mcpv1alpha1.DriftStatus{
+		Status:          status,
+		DriftedPaths:    driftedPaths,
+		LastCheckedTime: metav1.Now(),
+	}
+	if err := r.Status().Update(ctx, &mcpServer); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update drift status for MCPServer %s: %w", req.NamespacedName, err)
+	}
+
+	logger.V(1).Info("drift check complete", "status", status, "driftedPaths", driftedPaths)
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// checkDrift renders the expected Deployment/Service for mcpServer and
+// three-way diffs them against the live objects, ignoring fields the cluster
+// itself is expected to mutate (status, resourceVersion, clusterIP, default
+// tokens, defaulted probes).
+func (r *MCPServerDriftReconciler) checkDrift(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) ([]string, error) {
+	renderer := &MCPServerReconciler{Client: r.Client, Scheme: r.Scheme}
+
+	var drifted []string
+
+	expectedDeployment := renderer.deploymentForMCPServer(mcpServer)
+	var liveDeployment appsv1.Deployment
+	err := r.Get(ctx, types.NamespacedName{Name: expectedDeployment.Name, Namespace: expectedDeployment.Namespace}, &liveDeployment)
+	switch {
+	case err != nil && client.IgnoreNotFound(err) != nil:
+		return nil, err
+	case err != nil:
+		drifted = append(drifted, "deployment: missing")
+	default:
+		for _, path := range diffDeployment(expectedDeployment, &liveDeployment) {
+			drifted = append(drifted, "deployment."+path)
+		}
+	}
+
+	expectedService := renderer.serviceForMCPServer(mcpServer)
+	var liveService corev1.Service
+	err = r.Get(ctx, types.NamespacedName{Name: expectedService.Name, Namespace: expectedService.Namespace}, &liveService)
+	switch {
+	case err != nil && client.IgnoreNotFound(err) != nil:
+		return nil, err
+	case err != nil:
+		drifted = append(drifted, "service: missing")
+	default:
+		for _, path := range diffService(expectedService, &liveService) {
+			drifted = append(drifted, "service."+path)
+		}
+	}
+
+	return drifted, nil
+}
+
+// heal re-applies the expected Deployment and Service for mcpServer.
+func (r *MCPServerDriftReconciler) heal(ctx context.Context, mcpServer *mcpv1alpha1.MCPServer) error {
+	renderer := &MCPServerReconciler{Client: r.Client, Scheme: r.Scheme}
+
+	deployment := renderer.deploymentForMCPServer(mcpServer)
+	if err := controllerutil.SetControllerReference(mcpServer, deployment, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on deployment: %w", err)
+	}
+	if err := renderer.reconcileDeployment(ctx, deployment); err != nil {
+		return fmt.Errorf("failed to re-apply deployment: %w", err)
+	}
+
+	service := renderer.serviceForMCPServer(mcpServer)
+	if err := controllerutil.SetControllerReference(mcpServer, service, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on service: %w", err)
+	}
+	if err := renderer.reconcileService(ctx, service); err != nil {
+		return fmt.Errorf("failed to re-apply service: %w", err)
+	}
+
+	return nil
+}
+
+// diffDeployment compares the fields an operator actually manages, skipping
+// anything the apiserver/scheduler/kubelet mutate on its own.
+func diffDeployment(expected, live *appsv1.Deployment) []string {
+	var paths []string
+	if expected.Spec.Replicas != nil && live.Spec.Replicas != nil && *expected.Spec.Replicas != *live.Spec.Replicas {
+		paths = append(paths, "spec.replicas")
+	}
+	if len(expected.Spec.Template.Spec.Containers) > 0 && len(live.Spec.Template.Spec.Containers) > 0 {
+		if expected.Spec.Template.Spec.Containers[0].Image != live.Spec.Template.Spec.Containers[0].Image {
+			paths = append(paths, "spec.template.spec.containers[0].image")
+		}
+		if !envEqual(expected.Spec.Template.Spec.Containers[0].Env, live.Spec.Template.Spec.Containers[0].Env) {
+			paths = append(paths, "spec.template.spec.containers[0].env")
+		}
+	}
+	for k, v := range expected.Labels {
+		if live.Labels[k] != v {
+			paths = append(paths, "metadata.labels["+k+"]")
+		}
+	}
+	for k, v := range expected.Annotations {
+		if live.Annotations[k] != v {
+			paths = append(paths, "metadata.annotations["+k+"]")
+		}
+	}
+	return paths
+}
+
+// diffService compares the fields an operator actually manages on a Service,
+// ignoring ClusterIP, resourceVersion, and anything else the apiserver
+// defaults or allocates on create.
+func diffService(expected, live *corev1.Service) []string {
+	var paths []string
+	if len(expected.Spec.Ports) != len(live.Spec.Ports) {
+		paths = append(paths, "spec.ports")
+	} else {
+		for i := range expected.Spec.Ports {
+			if expected.Spec.Ports[i].Port != live.Spec.Ports[i].Port ||
+				expected.Spec.Ports[i].TargetPort != live.Spec.Ports[i].TargetPort {
+				paths = append(paths, fmt.Sprintf("spec.ports[%d]", i))
+			}
+		}
+	}
+	for k, v := range expected.Labels {
+		if live.Labels[k] != v {
+			paths = append(paths, "metadata.labels["+k+"]")
+		}
+	}
+	for k, v := range expected.Annotations {
+		if live.Annotations[k] != v {
+			paths = append(paths, "metadata.annotations["+k+"]")
+		}
+	}
+	return paths
+}
+
+func envEqual(a, b []corev1.EnvVar) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name || a[i].Value != b[i].Value {
+			return false
+		}
+	}
+	return true
+}
+
+// SetupWithManager wires the drift reconciler into the controller-runtime
+// manager. It does not set up any watches of its own beyond the MCPServer
+// itself, relying on Reconcile's RequeueAfter to drive the periodic check.
+func (r *MCPServerDriftReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("mcpserver-drift-detector")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&mcpv1alpha1.MCPServer{}).
+		Complete(r)
+}
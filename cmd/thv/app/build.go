@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/StacklokLabs/toolhive/pkg/container"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+var (
+	buildDockerfile string
+	buildTags       []string
+	buildArgs       []string
+	buildTarget     string
+	buildPlatform   string
+	buildPull       bool
+	buildNoCache    bool
+	buildLabels     []string
+	buildCacheFrom  []string
+	buildCacheTo    []string
+)
+
+func newBuildCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "build [context-dir]",
+		Short: "Build an MCP server image from source",
+		Long: `Build an MCP server image from a local Dockerfile, so contributors can
+iterate on an MCP server without pushing to a registry. Run this before "thv run"
+to pick up the freshly built image.`,
+		Args: cobra.ExactArgs(1),
+		Run:  buildCmdFunc,
+	}
+
+	cmd.Flags().StringVar(&buildDockerfile, "dockerfile", "", "Path to the Dockerfile within the context directory (default \"Dockerfile\")")
+	cmd.Flags().StringArrayVarP(&buildTags, "tag", "t", nil, "Tag to apply to the built image (can be repeated)")
+	cmd.Flags().StringArrayVar(&buildArgs, "build-arg", nil, "Build-time variable in the form KEY=VALUE (can be repeated)")
+	cmd.Flags().StringVar(&buildTarget, "target", "", "Build stage to build, for multi-stage Dockerfiles")
+	cmd.Flags().StringVar(&buildPlatform, "platform", "", "Target platform to build for, e.g. linux/amd64")
+	cmd.Flags().BoolVar(&buildPull, "pull", false, "Always attempt to pull a newer version of the base image")
+	cmd.Flags().BoolVar(&buildNoCache, "no-cache", false, "Do not use the build cache")
+	cmd.Flags().StringArrayVar(&buildLabels, "label", nil, "Label to apply to the built image in the form KEY=VALUE (can be repeated)")
+	cmd.Flags().StringArrayVar(&buildCacheFrom, "cache-from", nil, "External cache source, Buildx-style (can be repeated)")
+	cmd.Flags().StringArrayVar(&buildCacheTo, "cache-to", nil, "Buildx-style cache export destination (can be repeated)")
+
+	return cmd
+}
+
+func buildCmdFunc(_ *cobra.Command, args []string) {
+	contextDir := args[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rt, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		logger.Log.Errorf("failed to create container runtime: %v", err)
+		return
+	}
+
+	opts := container.BuildOptions{
+		ContextDir: contextDir,
+		Dockerfile: buildDockerfile,
+		Tags:       buildTags,
+		BuildArgs:  parseKeyValuePairs(buildArgs),
+		Target:     buildTarget,
+		Platform:   buildPlatform,
+		Pull:       buildPull,
+		NoCache:    buildNoCache,
+		Labels:     parseKeyValuePairs(buildLabels),
+		CacheFrom:  buildCacheFrom,
+		CacheTo:    buildCacheTo,
+	}
+
+	imageID, logs, err := rt.BuildImage(ctx, opts)
+	if err != nil {
+		logger.Log.Errorf("failed to build image: %v", err)
+		return
+	}
+	defer logs.Close()
+
+	if _, err := io.Copy(os.Stdout, logs); err != nil {
+		logger.Log.Errorf("failed to read build output: %v", err)
+		return
+	}
+
+	logger.Log.Infof("Built image %s", imageID)
+}
+
+// parseKeyValuePairs turns a list of "KEY=VALUE" strings (as collected from
+// a repeated --build-arg/--label flag) into a map, skipping entries that
+// don't contain an "=".
+func parseKeyValuePairs(pairs []string) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			logger.Log.Warnf("ignoring malformed key=value pair %q", pair)
+			continue
+		}
+		result[key] = value
+	}
+	return result
+}
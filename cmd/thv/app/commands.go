@@ -51,6 +51,10 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(logsCommand())
 	rootCmd.AddCommand(newSecretCommand())
+	rootCmd.AddCommand(newMirrorCommand())
+	rootCmd.AddCommand(newStatsCommand())
+	rootCmd.AddCommand(newBuildCommand())
+	rootCmd.AddCommand(newKubeCommand())
 
 	// Skip update check for completion command
 	if !IsCompletionCommand(os.Args) {
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/StacklokLabs/toolhive/pkg/container"
+	"github.com/StacklokLabs/toolhive/pkg/labels"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// kubeGenerator is implemented by container runtimes that can render a
+// running workload as a Kubernetes manifest, so this command can stay
+// written against the generic container.Runtime interface and only reach
+// for the capability on runtimes that actually have it.
+type kubeGenerator interface {
+	GenerateKube(ctx context.Context, workloadID string) ([]byte, error)
+}
+
+var kubeOutputPath string
+
+func newKubeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "kube [container-name]",
+		Short: "Generate a Kubernetes manifest for an MCP server",
+		Long: `Generate a Kubernetes Pod manifest (and a NetworkPolicy, if the server has an
+outbound permission profile) describing a running MCP server started with
+'thv run'. Container mounts are written as volumeMounts only; the manifest
+has no volumes section defining them.`,
+		Args: cobra.ExactArgs(1),
+		RunE: kubeCmdFunc,
+	}
+
+	cmd.Flags().StringVarP(&kubeOutputPath, "output", "o", "", "Write the manifest to a file instead of stdout")
+
+	return cmd
+}
+
+func kubeCmdFunc(_ *cobra.Command, args []string) error {
+	containerName := args[0]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runtime, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create container runtime: %v", err)
+	}
+
+	generator, ok := runtime.(kubeGenerator)
+	if !ok {
+		return fmt.Errorf("the current container runtime does not support generating Kubernetes manifests")
+	}
+
+	containers, err := runtime.ListContainers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %v", err)
+	}
+
+	var containerID string
+	for _, c := range containers {
+		if !labels.IsToolHiveContainer(c.Labels) {
+			continue
+		}
+
+		name := labels.GetContainerName(c.Labels)
+		if name == "" {
+			name = c.Name
+		}
+
+		if name == containerName || strings.HasPrefix(c.ID, containerName) {
+			containerID = c.ID
+			break
+		}
+	}
+	if containerID == "" {
+		return fmt.Errorf("container %s not found", containerName)
+	}
+
+	manifest, err := generator.GenerateKube(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to generate Kubernetes manifest: %v", err)
+	}
+
+	if kubeOutputPath == "" {
+		_, err = os.Stdout.Write(manifest)
+		return err
+	}
+
+	if err := os.WriteFile(kubeOutputPath, manifest, 0644); err != nil { //nolint:gosec // manifest is not sensitive
+		return fmt.Errorf("failed to write manifest to %s: %v", kubeOutputPath, err)
+	}
+	logger.Log.Infof("Kubernetes manifest for %s written to %s", containerName, kubeOutputPath)
+	return nil
+}
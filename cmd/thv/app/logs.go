@@ -2,7 +2,10 @@ package app
 
 import (
 	"context"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -11,67 +14,102 @@ import (
 	"github.com/StacklokLabs/toolhive/pkg/logger"
 )
 
+var (
+	logsFollow     bool
+	logsTail       int
+	logsSince      string
+	logsTimestamps bool
+)
+
 func newLogsCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "logs [container-name]",
 		Short: "Output the logs of an MCP server",
 		Long:  `Output the logs of an MCP server managed by Vibe Tool.`,
 		Args:  cobra.ExactArgs(1),
-		Run: func(_ *cobra.Command, args []string) {
-			// Get container name
-			containerName := args[0]
-
-			// Create context
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
-			// Create container runtime
-			runtime, err := container.NewFactory().Create(ctx)
-			if err != nil {
-				logger.Log.Errorf("failed to create container runtime: %v", err)
-				return
-			}
-
-			// List containers to find the one with the given name
-			containers, err := runtime.ListContainers(ctx)
-			if err != nil {
-				logger.Log.Errorf("failed to list containers: %v", err)
-				return
-			}
-
-			// Find the container with the given name
-			var containerID string
-			for _, c := range containers {
-				// Check if the container is managed by Vibe Tool
-				if !labels.IsToolHiveContainer(c.Labels) {
-					continue
-				}
-
-				// Check if the container name matches
-				name := labels.GetContainerName(c.Labels)
-				if name == "" {
-					name = c.Name // Fallback to container name
-				}
-
-				// Check if the name matches (exact match or prefix match)
-				if name == containerName || strings.HasPrefix(c.ID, containerName) {
-					containerID = c.ID
-					break
-				}
-			}
-
-			if containerID == "" {
-				logger.Log.Infof("container %s not found", containerName)
-				return
-			}
-
-			logs, err := runtime.ContainerLogs(ctx, containerID)
-			if err != nil {
-				logger.Log.Errorf("failed to get container logs: %v", err)
-				return
-			}
-			logger.Log.Infof(logs)
-
-		},
+		Run:   logsCmdFunc,
+	}
+
+	cmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Stream logs continuously instead of showing a one-shot dump")
+	cmd.Flags().IntVar(&logsTail, "tail", 0, "Only show the last N lines of existing logs (0 shows all)")
+	cmd.Flags().StringVar(&logsSince, "since", "", "Only show logs newer than a relative duration, e.g. 10m, 1h30m")
+	cmd.Flags().BoolVar(&logsTimestamps, "timestamps", false, "Show a timestamp alongside each log line")
+
+	return cmd
+}
+
+func logsCmdFunc(_ *cobra.Command, args []string) {
+	// Get container name
+	containerName := args[0]
+
+	// Create context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create container runtime
+	runtime, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		logger.Log.Errorf("failed to create container runtime: %v", err)
+		return
+	}
+
+	// List containers to find the one with the given name
+	containers, err := runtime.ListContainers(ctx)
+	if err != nil {
+		logger.Log.Errorf("failed to list containers: %v", err)
+		return
+	}
+
+	// Find the container with the given name
+	var containerID string
+	for _, c := range containers {
+		// Check if the container is managed by Vibe Tool
+		if !labels.IsToolHiveContainer(c.Labels) {
+			continue
+		}
+
+		// Check if the container name matches
+		name := labels.GetContainerName(c.Labels)
+		if name == "" {
+			name = c.Name // Fallback to container name
+		}
+
+		// Check if the name matches (exact match or prefix match)
+		if name == containerName || strings.HasPrefix(c.ID, containerName) {
+			containerID = c.ID
+			break
+		}
+	}
+
+	if containerID == "" {
+		logger.Log.Infof("container %s not found", containerName)
+		return
+	}
+
+	opts := container.LogOptions{
+		Follow:     logsFollow,
+		Tail:       logsTail,
+		Timestamps: logsTimestamps,
+	}
+	if logsSince != "" {
+		d, err := time.ParseDuration(logsSince)
+		if err != nil {
+			logger.Log.Errorf("invalid --since duration %q: %v", logsSince, err)
+			return
+		}
+		opts.Since = time.Now().Add(-d)
+	}
+
+	logs, err := runtime.StreamContainerLogs(ctx, containerID, opts)
+	if err != nil {
+		logger.Log.Errorf("failed to get container logs: %v", err)
+		return
+	}
+	defer logs.Close()
+
+	// Copy until the stream ends: a one-shot dump stops at EOF, a followed
+	// stream stops when ctx is canceled or the container stops logging.
+	if _, err := io.Copy(os.Stdout, logs); err != nil && err != io.EOF {
+		logger.Log.Errorf("error reading container logs: %v", err)
 	}
 }
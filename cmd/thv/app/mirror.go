@@ -0,0 +1,137 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/mirror"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+func newMirrorCommand() *cobra.Command {
+	mirrorCmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Manage the embedded image mirror",
+		Long: `Manage ToolHive's embedded OCI image mirror, a read-through cache used to
+resolve MCP server images in air-gapped environments and across multiple
+local container runtimes without re-pulling.`,
+	}
+
+	mirrorCmd.AddCommand(newMirrorStatusCommand())
+	mirrorCmd.AddCommand(newMirrorPruneCommand())
+	mirrorCmd.AddCommand(newMirrorImportCommand())
+	mirrorCmd.AddCommand(newMirrorExportCommand())
+
+	return mirrorCmd
+}
+
+func newMirrorStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the embedded image mirror's cache status",
+		RunE: func(*cobra.Command, []string) error {
+			m, err := newConfiguredMirror()
+			if err != nil {
+				return err
+			}
+
+			status, err := m.Status()
+			if err != nil {
+				return fmt.Errorf("failed to get mirror status: %v", err)
+			}
+
+			logger.Log.Infof("Enabled:      %t", status.Enabled)
+			logger.Log.Infof("Listen addr:  %s", status.ListenAddr)
+			logger.Log.Infof("Cached images: %d", status.Images)
+			logger.Log.Infof("Cached blobs:  %d", status.Blobs)
+			logger.Log.Infof("Cache size:    %d bytes", status.SizeBytes)
+
+			return nil
+		},
+	}
+}
+
+func newMirrorPruneCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Remove blobs not referenced by any cached image",
+		RunE: func(*cobra.Command, []string) error {
+			m, err := newConfiguredMirror()
+			if err != nil {
+				return err
+			}
+
+			result, err := m.Prune()
+			if err != nil {
+				return fmt.Errorf("failed to prune mirror cache: %v", err)
+			}
+
+			logger.Log.Infof("Removed %d blob(s), reclaimed %d bytes", result.RemovedBlobs, result.ReclaimedBytes)
+			return nil
+		},
+	}
+}
+
+func newMirrorImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import [tarball]",
+		Short: "Load a tarball of images into the mirror for air-gapped bootstrap",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			m, err := newConfiguredMirror()
+			if err != nil {
+				return err
+			}
+
+			if err := m.Import(args[0]); err != nil {
+				return fmt.Errorf("failed to import %s: %v", args[0], err)
+			}
+
+			logger.Log.Infof("Imported %s into the mirror cache", args[0])
+			return nil
+		},
+	}
+}
+
+func newMirrorExportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export [tarball] [image-ref...]",
+		Short: "Write a tarball of the named images for transfer to an air-gapped environment",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			m, err := newConfiguredMirror()
+			if err != nil {
+				return err
+			}
+
+			tarPath, refs := args[0], args[1:]
+			if err := m.Export(tarPath, refs); err != nil {
+				return fmt.Errorf("failed to export to %s: %v", tarPath, err)
+			}
+
+			logger.Log.Infof("Exported %d image(s) to %s", len(refs), tarPath)
+			return nil
+		},
+	}
+}
+
+// newConfiguredMirror builds a mirror.Mirror backed by the default ToolHive
+// data dir. It does not start the HTTP listener; the mirror subcommands only
+// need to read and write the local cache.
+func newConfiguredMirror() (*mirror.Mirror, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine home directory: %v", err)
+	}
+
+	m, err := mirror.New(mirror.Config{
+		DataDir: mirror.DefaultDataDir(filepath.Join(home, ".toolhive")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize mirror: %v", err)
+	}
+	return m, nil
+}
@@ -16,27 +16,61 @@ import (
 )
 
 var rmCmd = &cobra.Command{
-	Use:   "rm [container-name]",
-	Short: "Remove an MCP server",
-	Long:  `Remove an MCP server managed by ToolHive.`,
-	Args:  cobra.ExactArgs(1),
-	RunE:  rmCmdFunc,
+	Use:   "rm [container-name...]",
+	Short: "Remove one or more MCP servers",
+	Long: `Remove one or more MCP servers managed by ToolHive.
+
+Targets can be given as one or more container names, or selected with
+--all, --label key=value, or --prune (every ToolHive-managed container
+that is not currently running).`,
+	Args: cobra.ArbitraryArgs,
+	RunE: rmCmdFunc,
 }
 
 var (
-	rmForce bool
+	rmForce   bool
+	rmCascade bool
+	rmAll     bool
+	rmLabel   string
+	rmPrune   bool
+	rmDryRun  bool
 )
 
 func init() {
 	rmCmd.Flags().BoolVarP(&rmForce, "force", "f", false, "Force removal of a running container")
+	rmCmd.Flags().BoolVar(
+		&rmCascade,
+		"cascade",
+		false,
+		"Also remove any MCP servers that declare this one in their DependsOn, in reverse dependency order",
+	)
+	rmCmd.Flags().BoolVar(&rmAll, "all", false, "Remove all ToolHive-managed containers")
+	rmCmd.Flags().StringVar(
+		&rmLabel,
+		"label",
+		"",
+		"Only remove ToolHive-managed containers matching a label selector (format: key=value)",
+	)
+	rmCmd.Flags().BoolVar(
+		&rmPrune,
+		"prune",
+		false,
+		"Remove every ToolHive-managed container that is not running",
+	)
+	rmCmd.Flags().BoolVar(
+		&rmDryRun,
+		"dry-run",
+		false,
+		"Print what would be removed, and which client configurations would be touched, without removing anything",
+	)
 }
 
 //nolint:gocyclo // This function is complex but manageable
 func rmCmdFunc(_ *cobra.Command, args []string) error {
-	// Get container name
-	containerName := args[0]
+	if err := validateRmTargetSelection(args); err != nil {
+		return err
+	}
 
-	// Create context
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -46,33 +80,209 @@ func rmCmdFunc(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create container runtime: %v", err)
 	}
 
-	// List containers to find the one with the given name
+	// List containers so targets and dependents can be resolved from labels
 	containers, err := runtime.ListContainers(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to list containers: %v", err)
 	}
 
-	// Find the container with the given name
+	targets, errs := resolveRmTargets(containers, args)
+	if len(targets) == 0 {
+		return joinErrors(errs)
+	}
+
+	// Expand each target to include its cascaded dependents (if any), in an
+	// order where dependents come before the container they depend on.
+	visited := make(map[string]bool)
+	var order []string
+	for _, target := range targets {
+		if dependents := findDependents(containers, target); len(dependents) > 0 && !rmCascade {
+			errs = append(errs, fmt.Errorf(
+				"cannot remove %s: %s still depend(s) on it; use --cascade to remove them too",
+				target, strings.Join(dependents, ", "),
+			))
+			continue
+		}
+		appendRemovalOrder(containers, target, visited, &order)
+	}
+
+	if rmDryRun {
+		printDryRun(order)
+		errs = append(errs, resolveTouchedClientConfigs()...)
+		return joinErrors(errs)
+	}
+
+	var removed []string
+	for _, name := range order {
+		if err := removeOne(ctx, runtime, containers, name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		removed = append(removed, name)
+	}
+
+	if shouldRemoveClientConfig() && len(removed) > 0 {
+		if err := removeClientConfigurations(removed); err != nil {
+			logger.Log.Warnf("Warning: Failed to remove client configurations: %v", err)
+		}
+	}
+
+	return joinErrors(errs)
+}
+
+// validateRmTargetSelection ensures exactly one target-selection mechanism is
+// used: explicit names, --all, --label, or --prune.
+func validateRmTargetSelection(args []string) error {
+	selectors := 0
+	if rmAll {
+		selectors++
+	}
+	if rmLabel != "" {
+		selectors++
+	}
+	if rmPrune {
+		selectors++
+	}
+	if selectors > 1 {
+		return fmt.Errorf("only one of --all, --label, or --prune may be specified")
+	}
+	if selectors == 0 && len(args) == 0 {
+		return fmt.Errorf("specify at least one container name, or use --all, --label, or --prune")
+	}
+	if selectors == 1 && len(args) > 0 {
+		return fmt.Errorf("container names cannot be combined with --all, --label, or --prune")
+	}
+	return nil
+}
+
+// resolveRmTargets returns the base names of the containers to remove,
+// according to the selection mode, along with one error per name that
+// couldn't be resolved (lookups never abort the rest of the batch).
+func resolveRmTargets(containers []container.ContainerInfo, args []string) ([]string, []error) {
+	switch {
+	case rmAll:
+		return toolHiveBaseNames(containers, func(container.ContainerInfo) bool { return true }), nil
+	case rmPrune:
+		return toolHiveBaseNames(containers, func(c container.ContainerInfo) bool {
+			return !strings.Contains(strings.ToLower(c.State), "running")
+		}), nil
+	case rmLabel != "":
+		key, value, ok := strings.Cut(rmLabel, "=")
+		if !ok {
+			return nil, []error{fmt.Errorf("invalid --label selector %q: expected key=value", rmLabel)}
+		}
+		return toolHiveBaseNames(containers, func(c container.ContainerInfo) bool {
+			return c.Labels[key] == value
+		}), nil
+	default:
+		var targets []string
+		var errs []error
+		for _, name := range args {
+			baseName := resolveContainerBaseName(containers, name)
+			if baseName == "" {
+				errs = append(errs, fmt.Errorf("container %s not found", name))
+				continue
+			}
+			targets = append(targets, baseName)
+		}
+		return targets, errs
+	}
+}
+
+// toolHiveBaseNames returns the base names of ToolHive-managed containers
+// matching the given predicate.
+func toolHiveBaseNames(containers []container.ContainerInfo, match func(container.ContainerInfo) bool) []string {
+	var names []string
+	for _, c := range containers {
+		if !labels.IsToolHiveContainer(c.Labels) || !match(c) {
+			continue
+		}
+		name := labels.GetContainerBaseName(c.Labels)
+		if name == "" {
+			name = labels.GetContainerName(c.Labels)
+		}
+		if name == "" {
+			name = c.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// appendRemovalOrder walks baseName and its transitive dependents, appending
+// to order in an order where dependents are removed before the containers
+// they depend on. visited is shared across every requested target so a
+// dependent reachable from more than one target is only listed once.
+func appendRemovalOrder(containers []container.ContainerInfo, baseName string, visited map[string]bool, order *[]string) {
+	if visited[baseName] {
+		return
+	}
+	visited[baseName] = true
+
+	for _, dependent := range findDependents(containers, baseName) {
+		appendRemovalOrder(containers, dependent, visited, order)
+	}
+	*order = append(*order, baseName)
+}
+
+// findDependents returns the base names of containers whose
+// toolhive-depends-on label includes baseName.
+func findDependents(containers []container.ContainerInfo, baseName string) []string {
+	var dependents []string
+	for _, c := range containers {
+		if !labels.IsToolHiveContainer(c.Labels) {
+			continue
+		}
+		for _, dep := range labels.GetDependsOn(c.Labels) {
+			if dep == baseName {
+				dependents = append(dependents, labels.GetContainerBaseName(c.Labels))
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// resolveContainerBaseName finds the base name of the ToolHive container
+// matching containerName (exact name or ID prefix match).
+func resolveContainerBaseName(containers []container.ContainerInfo, containerName string) string {
+	for _, c := range containers {
+		if !labels.IsToolHiveContainer(c.Labels) {
+			continue
+		}
+
+		name := labels.GetContainerName(c.Labels)
+		if name == "" {
+			name = c.Name // Fallback to container name
+		}
+
+		if name == containerName || strings.HasPrefix(c.ID, containerName) {
+			baseName := labels.GetContainerBaseName(c.Labels)
+			if baseName == "" {
+				baseName = name
+			}
+			return baseName
+		}
+	}
+	return ""
+}
+
+func removeOne(ctx context.Context, runtime container.Runtime, containers []container.ContainerInfo, containerName string) error {
 	var containerID string
 	var isRunning bool
-	var containerLabels map[string]string
 	for _, c := range containers {
-		// Check if the container is managed by ToolHive
 		if !labels.IsToolHiveContainer(c.Labels) {
 			continue
 		}
 
-		// Check if the container name matches
 		name := labels.GetContainerName(c.Labels)
 		if name == "" {
-			name = c.Name // Fallback to container name
+			name = c.Name
 		}
 
-		// Check if the name matches (exact match or prefix match)
 		if name == containerName || strings.HasPrefix(c.ID, containerName) {
 			containerID = c.ID
 			isRunning = strings.Contains(strings.ToLower(c.State), "running")
-			containerLabels = c.Labels
 			break
 		}
 	}
@@ -89,30 +299,17 @@ func rmCmdFunc(_ *cobra.Command, args []string) error {
 	// Remove the container
 	logger.Log.Infof("Removing container %s...", containerName)
 	if err := runtime.RemoveContainer(ctx, containerID); err != nil {
-		return fmt.Errorf("failed to remove container: %v", err)
+		return fmt.Errorf("failed to remove container %s: %v", containerName, err)
 	}
 
-	// Get the base name from the container labels
-	baseName := labels.GetContainerBaseName(containerLabels)
-	if baseName != "" {
-		// Delete the saved state if it exists
-		if err := runner.DeleteSavedConfig(ctx, baseName); err != nil {
-			logger.Log.Warnf("Warning: Failed to delete saved state: %v", err)
-		} else {
-			logger.Log.Infof("Saved state for %s removed", baseName)
-		}
+	// Delete the saved state if it exists
+	if err := runner.DeleteSavedConfig(ctx, containerName); err != nil {
+		logger.Log.Warnf("Warning: Failed to delete saved state: %v", err)
+	} else {
+		logger.Log.Infof("Saved state for %s removed", containerName)
 	}
 
 	logger.Log.Infof("Container %s removed", containerName)
-
-	if shouldRemoveClientConfig() {
-		if err := removeClientConfigurations(containerName); err != nil {
-			logger.Log.Warnf("Warning: Failed to remove client configurations: %v", err)
-		} else {
-			logger.Log.Infof("Client configurations for %s removed", containerName)
-		}
-	}
-
 	return nil
 }
 
@@ -121,29 +318,62 @@ func shouldRemoveClientConfig() bool {
 	return len(c.Clients.RegisteredClients) > 0 || c.Clients.AutoDiscovery
 }
 
-// updateClientConfigurations updates client configuration files with the MCP server URL
-func removeClientConfigurations(containerName string) error {
-	// Find client configuration files
-	configs, err := client.FindClientConfigs()
+// removeClientConfigurations removes every name in names from each client
+// configuration file via Manager.RemoveMany, which makes one
+// ConfigUpdater.Remove call per name and file; the names are not combined
+// into a single update of a file.
+func removeClientConfigurations(names []string) error {
+	mgr, err := client.NewManager(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to find client configurations: %w", err)
+		return fmt.Errorf("failed to create client manager: %w", err)
 	}
 
-	if len(configs) == 0 {
-		logger.Log.Infof("No client configuration files found")
-		return nil
+	if err := mgr.RemoveMany(context.Background(), names); err != nil {
+		return fmt.Errorf("failed to remove MCP servers from client configurations: %w", err)
 	}
 
-	for _, c := range configs {
-		logger.Log.Infof("Removing MCP server from client configuration: %s", c.Path)
+	logger.Log.Infof("Client configurations for %s removed", strings.Join(names, ", "))
+	return nil
+}
 
-		if err := c.ConfigUpdater.Remove(containerName); err != nil {
-			logger.Log.Warnf("Warning: Failed to remove MCP server from client configurationn %s: %v", c.Path, err)
-			continue
-		}
+// resolveTouchedClientConfigs logs which client configuration files --dry-run
+// would have touched. It returns an error only when the client configurations
+// cannot be found, so that failure is collected alongside the others.
+func resolveTouchedClientConfigs() []error {
+	if !shouldRemoveClientConfig() {
+		return nil
+	}
+	configs, err := client.FindClientConfigs()
+	if err != nil {
+		return []error{fmt.Errorf("failed to find client configurations: %w", err)}
+	}
+	for _, c := range configs {
+		logger.Log.Infof("Would update client configuration: %s", c.Path)
+	}
+	return nil
+}
 
-		logger.Log.Infof("Successfully removed MCP server from client configuration: %s", c.Path)
+func printDryRun(order []string) {
+	if len(order) == 0 {
+		logger.Log.Infof("No containers would be removed")
+		return
 	}
+	logger.Log.Infof("Would remove %d container(s):", len(order))
+	for _, name := range order {
+		logger.Log.Infof("  - %s", name)
+	}
+}
 
-	return nil
+// joinErrors aggregates per-target failures into a single error, preserving
+// all of them so a caller can see everything that went wrong in one batch
+// rather than just the first failure.
+func joinErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("%d of the requested removals failed:\n%s", len(errs), strings.Join(msgs, "\n"))
 }
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/StacklokLabs/toolhive/pkg/container"
+	"github.com/StacklokLabs/toolhive/pkg/labels"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+var statsFollow bool
+
+func newStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [container-name]",
+		Short: "Show resource usage statistics of an MCP server",
+		Long:  `Show resource usage statistics (CPU, memory, network, block I/O) of an MCP server managed by Vibe Tool.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   statsCmdFunc,
+	}
+
+	cmd.Flags().BoolVarP(&statsFollow, "follow", "f", false, "Stream stats continuously instead of showing a single sample")
+
+	return cmd
+}
+
+func statsCmdFunc(_ *cobra.Command, args []string) {
+	// Get container name
+	containerName := args[0]
+
+	// Create context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create container runtime
+	rt, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		logger.Log.Errorf("failed to create container runtime: %v", err)
+		return
+	}
+
+	// List containers to find the one with the given name
+	containers, err := rt.ListContainers(ctx)
+	if err != nil {
+		logger.Log.Errorf("failed to list containers: %v", err)
+		return
+	}
+
+	// Find the container with the given name
+	var containerID string
+	for _, c := range containers {
+		// Check if the container is managed by Vibe Tool
+		if !labels.IsToolHiveContainer(c.Labels) {
+			continue
+		}
+
+		// Check if the container name matches
+		name := labels.GetContainerName(c.Labels)
+		if name == "" {
+			name = c.Name // Fallback to container name
+		}
+
+		// Check if the name matches (exact match or prefix match)
+		if name == containerName || strings.HasPrefix(c.ID, containerName) {
+			containerID = c.ID
+			break
+		}
+	}
+
+	if containerID == "" {
+		logger.Log.Infof("container %s not found", containerName)
+		return
+	}
+
+	samples, err := rt.ContainerStats(ctx, containerID, statsFollow)
+	if err != nil {
+		logger.Log.Errorf("failed to get container stats: %v", err)
+		return
+	}
+
+	for sample := range samples {
+		fmt.Printf(
+			"CPU: %.2f%%  Mem: %d / %d bytes  Net I/O: %d / %d bytes  Block I/O: %d / %d bytes\n",
+			sample.CPUPercent,
+			sample.MemoryUsageBytes, sample.MemoryLimitBytes,
+			sample.NetworkRxBytes, sample.NetworkTxBytes,
+			sample.BlockReadBytes, sample.BlockWriteBytes,
+		)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// CreateConfigLabel is the container label RunMCPServer attaches pointing
+// back at createConfigPath, so a workload's saved create config can be
+// found even from a fresh checkout whose XDG_STATE_HOME differs from the
+// one it was originally run under.
+const CreateConfigLabel = "io.toolhive.create-config"
+
+// createConfigFileName is the file saveCreateConfig writes under each
+// workload's own state directory.
+const createConfigFileName = "create-config.json"
+
+// stateDir returns the root of a workload's own state directory, e.g.
+// "~/.local/state/toolhive/<name>", falling back to $HOME and finally a
+// temp directory when neither XDG_STATE_HOME nor HOME is set.
+func stateDir(name string) string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "toolhive", name)
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".local", "state", "toolhive", name)
+	}
+	return filepath.Join(os.TempDir(), "toolhive", name)
+}
+
+// createConfigPath returns the path saveCreateConfig/loadCreateConfig read
+// and write a workload's create config under.
+func createConfigPath(name string) string {
+	return filepath.Join(stateDir(name), createConfigFileName)
+}
+
+// saveCreateConfig persists options as name's reproducible create config --
+// borrowed from podman's own create-config artifact -- so "vt replay" can
+// reconstruct an identical invocation without the caller having to remember
+// the exact flag set they originally ran it with.
+func saveCreateConfig(name string, options RunOptions) error {
+	dir := stateDir(name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(options, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize create config: %w", err)
+	}
+
+	if err := os.WriteFile(createConfigPath(name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write create config: %w", err)
+	}
+
+	return nil
+}
+
+// loadCreateConfig reads back the create config saveCreateConfig wrote for
+// name.
+func loadCreateConfig(name string) (*RunOptions, error) {
+	data, err := os.ReadFile(createConfigPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no create config found for %q (it may predate this feature)", name)
+		}
+		return nil, fmt.Errorf("failed to read create config: %w", err)
+	}
+
+	var options RunOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return nil, fmt.Errorf("failed to parse create config: %w", err)
+	}
+
+	return &options, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/stacklok/vibetool/pkg/container"
+	"github.com/stacklok/vibetool/pkg/manifest"
+)
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy -f manifest.yaml",
+	Short: "Run a group of MCP servers from a manifest",
+	Long: `Run every MCP server declared in a Kubernetes-style YAML manifest as one
+invocation, rather than scripting repeated "run" calls. Each server entry is
+deployed with "run"'s own defaults and reuses the manifest's shared
+permissionProfiles/configMaps by name where it asks to. Every server started
+this way is labeled with the manifest's group name so "vt ls"/"vt stop" can
+operate on the whole set together.`,
+	Args: cobra.NoArgs,
+	RunE: deployCmdFunc,
+}
+
+var deployManifestPath string
+
+func init() {
+	deployCmd.Flags().StringVarP(&deployManifestPath, "file", "f", "", "Path to the deployment manifest (required)")
+	if err := deployCmd.MarkFlagRequired("file"); err != nil {
+		panic(err)
+	}
+}
+
+func deployCmdFunc(cmd *cobra.Command, _ []string) error {
+	debugMode, _ := cmd.Flags().GetBool("debug")
+
+	m, err := manifest.Load(deployManifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to load manifest: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deployed := make([]string, 0, len(m.Servers))
+	for i := range m.Servers {
+		server := &m.Servers[i]
+
+		options, err := buildDeployOptions(m, server, debugMode)
+		if err != nil {
+			return fmt.Errorf("server %q: failed to build run options: %v", server.Name, err)
+		}
+
+		logDebug(debugMode, "Deploying server %q (group %q)", server.Name, m.Group)
+		if err := deployManifestServer(ctx, cmd, server.Image, *options); err != nil {
+			return fmt.Errorf("server %q: %v (already-deployed servers in this group were left running: %v)",
+				server.Name, err, deployed)
+		}
+		deployed = append(deployed, server.Name)
+	}
+
+	fmt.Printf("Deployed %d server(s) in group %q\n", len(deployed), m.Group)
+	return nil
+}
+
+// buildDeployOptions translates one manifest server entry into the same
+// RunOptions "run" itself builds, resolving its permissionProfile/
+// configMaps references against the manifest and passing its secrets
+// references through unchanged.
+func buildDeployOptions(m *manifest.Manifest, server *manifest.Server, debugMode bool) (*RunOptions, error) {
+	transport := server.Transport
+	if transport == "" {
+		transport = "stdio"
+	}
+
+	options := &RunOptions{
+		Name:            server.Name,
+		Transport:       transport,
+		TargetPort:      server.TargetPort,
+		EnvVars:         m.ResolveEnv(server),
+		Secrets:         server.Secrets,
+		AuthzConfigPath: server.AuthzConfigPath,
+		GroupName:       m.Group,
+	}
+	if server.OIDC != nil {
+		options.OIDCIssuer = server.OIDC.Issuer
+		options.OIDCAudience = server.OIDC.Audience
+		options.OIDCJwksURL = server.OIDC.JwksURL
+		options.OIDCClientID = server.OIDC.ClientID
+	}
+
+	switch {
+	case server.PermissionProfile == "":
+		options.PermissionProfile = "stdio"
+	case m.PermissionProfiles[server.PermissionProfile] != nil:
+		path, err := createPermissionProfileFile(server.Name, m.PermissionProfiles[server.PermissionProfile], debugMode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to materialize permission profile %q: %w", server.PermissionProfile, err)
+		}
+		options.PermissionProfile = path
+	default:
+		// validate() already confirmed this resolves to an existing file.
+		options.PermissionProfile = server.PermissionProfile
+	}
+
+	return options, nil
+}
+
+// deployManifestServer runs a single manifest server entry the same way
+// runCmdFunc does for a direct image, skipping the registry lookup since a
+// manifest entry always names its image explicitly.
+func deployManifestServer(ctx context.Context, cmd *cobra.Command, image string, options RunOptions) error {
+	options.Image = image
+
+	runtime, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create container runtime: %v", err)
+	}
+
+	imageExists, err := runtime.ImageExists(ctx, options.Image)
+	if err != nil {
+		return fmt.Errorf("failed to check if image exists: %v", err)
+	}
+	if !imageExists {
+		fmt.Printf("Image %s not found locally, pulling...\n", options.Image)
+		if err := runtime.PullImage(ctx, options.Image, nil); err != nil {
+			return fmt.Errorf("failed to pull image: %v", err)
+		}
+	}
+
+	return RunMCPServer(ctx, cmd, options)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect NAME",
+	Short: "Show details about a workload",
+	Args:  cobra.ExactArgs(1),
+	RunE:  inspectCmdFunc,
+}
+
+var inspectConfig bool
+
+func init() {
+	inspectCmd.Flags().BoolVar(&inspectConfig, "config", false, "Print the workload's saved create config as JSON")
+}
+
+func inspectCmdFunc(_ *cobra.Command, args []string) error {
+	name := args[0]
+
+	if !inspectConfig {
+		return fmt.Errorf("inspect currently only supports --config; pass it to print %q's saved create config", name)
+	}
+
+	options, err := loadCreateConfig(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(options, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format create config: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay NAME",
+	Short: "Re-run a workload from its saved create config",
+	Long: `Reads the create config "vt run" saved for NAME under its state directory and
+re-runs it with identical options. Useful for bringing a workload back after
+a crash or host reboot without having to remember the exact flags it was
+originally started with.`,
+	Args: cobra.ExactArgs(1),
+	RunE: replayCmdFunc,
+}
+
+func replayCmdFunc(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	options, err := loadCreateConfig(name)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fmt.Printf("Replaying %q from its saved create config (image: %s)\n", name, options.Image)
+	return RunMCPServer(ctx, cmd, *options)
+}
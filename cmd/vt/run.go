@@ -9,9 +9,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	regauth "github.com/stacklok/vibetool/pkg/auth/registry"
 	"github.com/stacklok/vibetool/pkg/container"
 	"github.com/stacklok/vibetool/pkg/permissions"
 	"github.com/stacklok/vibetool/pkg/registry"
+	"github.com/stacklok/vibetool/pkg/runlabel"
+	"github.com/stacklok/vibetool/pkg/trust"
 )
 
 var runCmd = &cobra.Command{
@@ -27,17 +30,24 @@ The container will be started with minimal permissions and the specified transpo
 }
 
 var (
-	runTransport         string
-	runName              string
-	runPort              int
-	runTargetPort        int
-	runPermissionProfile string
-	runEnv               []string
-	runNoClientConfig    bool
-	runForeground        bool
-	runVolumes           []string
-	runSecrets           []string
-	runAuthzConfig       string
+	runTransport          string
+	runName               string
+	runPort               int
+	runTargetPort         int
+	runPermissionProfile  string
+	runEnv                []string
+	runNoClientConfig     bool
+	runForeground         bool
+	runVolumes            []string
+	runSecrets            []string
+	runAuthzConfig        string
+	runSignaturePolicy    string
+	runSignaturePolicyDir string
+	runPlatform           string
+	runAuthFile           string
+	runCreds              string
+	runSeccompProfile     string
+	runApparmorProfile    string
 )
 
 func init() {
@@ -84,6 +94,48 @@ func init() {
 		"",
 		"Path to the authorization configuration file",
 	)
+	runCmd.Flags().StringVar(
+		&runSignaturePolicy,
+		"signature-policy",
+		"",
+		"Path to a trust policy file the image must satisfy before it is run",
+	)
+	runCmd.Flags().StringVar(
+		&runSignaturePolicyDir,
+		"signature-policy-dir",
+		"",
+		"Directory of trust policy fragments (one scope set per file) layered on top of --signature-policy",
+	)
+	runCmd.Flags().StringVar(
+		&runPlatform,
+		"platform",
+		"",
+		"Platform variant to pull from a multi-arch image, e.g. linux/amd64 (default: host platform)",
+	)
+	runCmd.Flags().StringVar(
+		&runAuthFile,
+		"authfile",
+		"",
+		"Path to a registry authfile (default: $XDG_RUNTIME_DIR/containers/auth.json or ~/.docker/config.json)",
+	)
+	runCmd.Flags().StringVar(
+		&runCreds,
+		"creds",
+		"",
+		"One-off registry credentials for this pull (format: user:password), overriding --authfile",
+	)
+	runCmd.Flags().StringVar(
+		&runSeccompProfile,
+		"seccomp-profile",
+		"",
+		"Seccomp profile to apply (runtime/default, unconfined, localhost/<name>, or inline OCI seccomp JSON)",
+	)
+	runCmd.Flags().StringVar(
+		&runApparmorProfile,
+		"apparmor-profile",
+		"",
+		"AppArmor profile to apply (runtime/default, unconfined, or a named profile)",
+	)
 
 	// Add OIDC validation flags
 	AddOIDCFlags(runCmd)
@@ -126,6 +178,22 @@ func runCmdFunc(cmd *cobra.Command, args []string) error {
 		Volumes:           runVolumes,
 		Secrets:           runSecrets,
 		AuthzConfigPath:   runAuthzConfig,
+		SignaturePolicy:   runSignaturePolicy,
+		SeccompProfile:    runSeccompProfile,
+		AppArmorProfile:   runApparmorProfile,
+	}
+
+	// Load the trust policy, if one was configured, before resolving the
+	// server so a registry entry that isn't covered by any scope is
+	// rejected up front rather than after the image has already been
+	// pulled.
+	var verifier *trust.Verifier
+	if runSignaturePolicy != "" || runSignaturePolicyDir != "" {
+		policy, err := loadSignaturePolicy(runSignaturePolicy, runSignaturePolicyDir)
+		if err != nil {
+			return fmt.Errorf("failed to load trust policy: %v", err)
+		}
+		verifier = trust.NewVerifier(policy)
 	}
 
 	// Try to find the server in the registry
@@ -137,13 +205,29 @@ func runCmdFunc(cmd *cobra.Command, args []string) error {
 		logDebug(debugMode, "Found server '%s' in registry", serverOrImage)
 
 		// Apply registry settings to options
-		applyRegistrySettings(cmd, serverOrImage, server, &options, debugMode)
+		if err := applyRegistrySettings(cmd, serverOrImage, server, &options, debugMode, verifier); err != nil {
+			return err
+		}
 	} else {
 		// Server not found in registry, treat as direct image
 		logDebug(debugMode, "Server '%s' not found in registry, treating as Docker image", serverOrImage)
 		options.Image = serverOrImage
 	}
 
+	// Verify the image's signature against the trust policy, if one was
+	// configured, before the image is pulled or run. A registry entry can
+	// narrow the policy's own scope requirement by pinning an expected
+	// signer via server.Signer/server.Identity.
+	if verifier != nil {
+		var signerReq *trust.SignerRequirement
+		if server != nil && (server.Signer != "" || server.Identity != "") {
+			signerReq = &trust.SignerRequirement{Identity: server.Signer, Issuer: server.Identity}
+		}
+		if err := verifier.VerifyImage(ctx, options.Image, signerReq); err != nil {
+			return fmt.Errorf("image trust verification failed: %v", err)
+		}
+	}
+
 	// Create container runtime
 	runtime, err := container.NewFactory().Create(ctx)
 	if err != nil {
@@ -156,19 +240,119 @@ func runCmdFunc(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to check if image exists: %v", err)
 	}
 	if !imageExists {
+		pullOpts, err := buildPullImageOptions(options.Image, runPlatform, runAuthFile, runCreds)
+		if err != nil {
+			return fmt.Errorf("failed to resolve registry credentials: %v", err)
+		}
 		fmt.Printf("Image %s not found locally, pulling...\n", options.Image)
-		if err := runtime.PullImage(ctx, options.Image); err != nil {
+		if err := runtime.PullImage(ctx, options.Image, pullOpts); err != nil {
 			return fmt.Errorf("failed to pull image: %v", err)
 		}
 		fmt.Printf("Successfully pulled image: %s\n", options.Image)
 	}
 
+	// An image not found in the registry may still declare its own
+	// defaults via io.toolhive.* labels; a registry entry always wins, so
+	// labels are only consulted as a fallback for a plain image reference.
+	if server == nil {
+		if err := applyRunLabels(ctx, runtime, &options, cmd, debugMode); err != nil {
+			return fmt.Errorf("failed to apply image run labels: %v", err)
+		}
+	}
+
+	// Persist the fully-resolved options as a reproducible create config
+	// now that the registry merge, env prompts, permission profile
+	// materialization, OIDC, and authz settings are all settled -- "vt
+	// replay" and "vt inspect --config" read this back later. A failure
+	// here shouldn't block the run itself, just the ability to replay it.
+	// options.Name is only known here if it came from --name or a registry
+	// hit; an unnamed direct image run gets its name auto-generated further
+	// downstream in RunMCPServer, too late for this to key off of.
+	if options.Name != "" {
+		if err := saveCreateConfig(options.Name, options); err != nil {
+			fmt.Printf("Warning: failed to persist create config: %v\n", err)
+		}
+	}
+
 	// Run the MCP server
 	return RunMCPServer(ctx, cmd, options)
 }
 
-// applyRegistrySettings applies settings from a registry server to the run options
-func applyRegistrySettings(cmd *cobra.Command, serverName string, server *registry.Server, options *RunOptions, debugMode bool) {
+// imageLabelInspector is the subset of the container runtime applyRunLabels
+// needs, so it doesn't have to name whichever concrete runtime type
+// container.NewFactory().Create returns.
+type imageLabelInspector interface {
+	GetImageLabels(ctx context.Context, image string) (map[string]string, error)
+}
+
+// applyRunLabels inspects image's io.toolhive.* labels and merges them into
+// options, with the same "CLI flags win" precedence applyRegistrySettings
+// gives a registry entry -- this lets an image author ship a runnable MCP
+// server without a PR to the curated registry, the way `podman container
+// runlabel` reads LABEL RUN from image metadata.
+func applyRunLabels(
+	ctx context.Context, rt imageLabelInspector, options *RunOptions, cmd *cobra.Command, debugMode bool,
+) error {
+	labels, err := rt.GetImageLabels(ctx, options.Image)
+	if err != nil {
+		return fmt.Errorf("failed to inspect image labels: %w", err)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+
+	providedEnv := map[string]bool{}
+	for _, kv := range options.EnvVars {
+		if name, _, ok := strings.Cut(kv, "="); ok {
+			providedEnv[name] = true
+		}
+	}
+
+	target := &runlabel.Target{
+		Transport:         options.Transport,
+		TargetPort:        options.TargetPort,
+		PermissionProfile: options.PermissionProfile,
+		EnvVars:           options.EnvVars,
+	}
+	set := runlabel.FlagsSet{
+		Transport:         cmd.Flags().Changed("transport"),
+		TargetPort:        cmd.Flags().Changed("target-port"),
+		PermissionProfile: cmd.Flags().Changed("permission-profile"),
+	}
+
+	missing, err := runlabel.Apply(labels, target, set, providedEnv)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		names := make([]string, len(missing))
+		for i, m := range missing {
+			names[i] = m.Name
+		}
+		return fmt.Errorf("image declares required environment variable(s) %s (pass with -e); %s",
+			strings.Join(names, ", "), missing[0].Description)
+	}
+
+	logDebug(debugMode, "Applied io.toolhive.* labels from image %s", options.Image)
+	options.Transport = target.Transport
+	options.TargetPort = target.TargetPort
+	options.PermissionProfile = target.PermissionProfile
+	options.EnvVars = target.EnvVars
+	return nil
+}
+
+// applyRegistrySettings applies settings from a registry server to the run
+// options. If verifier is non-nil, it fails fast when no trust policy scope
+// covers server.Image, before doing any of the other work below (permission
+// profile materialization in particular) that a later rejection in
+// runCmdFunc's VerifyImage call would make moot anyway.
+func applyRegistrySettings(
+	cmd *cobra.Command, serverName string, server *registry.Server, options *RunOptions, debugMode bool, verifier *trust.Verifier,
+) error {
+	if verifier != nil && !verifier.Covers(server.Image) {
+		return fmt.Errorf("no trust policy scope covers registry entry %q's image %q", serverName, server.Image)
+	}
+
 	// Use the image from the registry
 	options.Image = server.Image
 
@@ -191,6 +375,8 @@ func applyRegistrySettings(cmd *cobra.Command, serverName string, server *regist
 
 	// Create a temporary file for the permission profile if not explicitly provided
 	if !cmd.Flags().Changed("permission-profile") {
+		applySeccompAndApparmorOverrides(server.Permissions, server.RecommendedSeccompProfile, options)
+
 		permProfilePath, err := createPermissionProfileFile(serverName, server.Permissions, debugMode)
 		if err != nil {
 			// Just log the error and continue with the default permission profile
@@ -199,6 +385,75 @@ func applyRegistrySettings(cmd *cobra.Command, serverName string, server *regist
 			options.PermissionProfile = permProfilePath
 		}
 	}
+
+	return nil
+}
+
+// applySeccompAndApparmorOverrides applies --seccomp-profile/--apparmor-profile
+// onto profile in place, before it's serialized to a permission profile
+// file: an explicit CLI flag always wins; absent that, a registry entry's
+// own RecommendedSeccompProfile is used for Seccomp when the profile does not
+// already set one. AppArmor has no
+// registry-recommended equivalent, so it's only ever set by the flag.
+func applySeccompAndApparmorOverrides(profile *permissions.Profile, recommendedSeccomp string, options *RunOptions) {
+	if profile == nil {
+		return
+	}
+	switch {
+	case options.SeccompProfile != "":
+		profile.Seccomp = options.SeccompProfile
+	case recommendedSeccomp != "" && profile.Seccomp == "":
+		profile.Seccomp = recommendedSeccomp
+	}
+	if options.AppArmorProfile != "" {
+		profile.AppArmor = options.AppArmorProfile
+	}
+}
+
+// loadSignaturePolicy loads the trust policy from policyPath and/or
+// policyDir, merging the directory's fragments on top of the base file so
+// an operator can override a single scope without editing the base policy.
+func loadSignaturePolicy(policyPath, policyDir string) (*trust.Policy, error) {
+	policy := &trust.Policy{Scopes: map[string]trust.Scope{}}
+
+	if policyPath != "" {
+		base, err := trust.Load(policyPath)
+		if err != nil {
+			return nil, err
+		}
+		policy = base
+	}
+
+	if policyDir != "" {
+		overrides, err := trust.LoadDir(policyDir)
+		if err != nil {
+			return nil, err
+		}
+		policy = policy.Merge(overrides)
+	}
+
+	return policy, nil
+}
+
+// buildPullImageOptions resolves --platform/--authfile/--creds into the
+// container.PullImageOptions for a single image pull, returning nil if
+// neither a platform nor any credentials apply so PullImage keeps behaving
+// like an ordinary anonymous, host-platform pull.
+func buildPullImageOptions(image, platform, authFile, oneOffCreds string) (*container.PullImageOptions, error) {
+	creds, found, err := regauth.Resolve(authFile, regauth.HostFromImageRef(image), oneOffCreds)
+	if err != nil {
+		return nil, err
+	}
+	if platform == "" && !found {
+		return nil, nil
+	}
+
+	opts := &container.PullImageOptions{Platform: platform}
+	if found {
+		opts.Username = creds.Username
+		opts.Password = creds.Password
+	}
+	return opts, nil
 }
 
 // processEnvironmentVariables processes environment variables from the registry
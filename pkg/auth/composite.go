@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// defaultIntrospectionCacheSize bounds the CompositeValidator's result cache
+// so a flood of distinct tokens can't grow it unbounded.
+const defaultIntrospectionCacheSize = 1024
+
+// CompositeValidator validates a token locally as a JWT first (fast path via
+// JWTValidator's jwk.Cache) and falls back to IntrospectionValidator when the
+// token isn't a locally-verifiable JWT (no "kid", unsupported algorithm, bad
+// signature, etc.), caching either outcome briefly so repeated requests with
+// the same token don't hammer the IdP.
+type CompositeValidator struct {
+	jwtValidator  *JWTValidator
+	introspection *IntrospectionValidator
+	cache         *validationCache
+}
+
+// NewCompositeValidator creates a CompositeValidator that tries jwtValidator
+// before falling back to introspection, caching results for cacheTTL. A
+// cacheTTL of 0 disables caching.
+func NewCompositeValidator(jwtValidator *JWTValidator, introspection *IntrospectionValidator, cacheTTL time.Duration) *CompositeValidator {
+	return &CompositeValidator{
+		jwtValidator:  jwtValidator,
+		introspection: introspection,
+		cache:         newValidationCache(defaultIntrospectionCacheSize, cacheTTL),
+	}
+}
+
+// NewCompositeValidatorFromConfig builds the JWTValidator and
+// IntrospectionValidator for a CompositeValidator from a single
+// JWTValidatorConfig, using its IntrospectionURL, ClientID, ClientSecret,
+// Audience, and CacheTTL fields. This is the easiest way to wire opaque-token
+// fallback into the existing Middleware chain: swap whatever builds your
+// JWTValidator today for a call to this function and pass the result's
+// Middleware to your router instead.
+func NewCompositeValidatorFromConfig(ctx context.Context, config JWTValidatorConfig) (*CompositeValidator, error) {
+	jwtValidator, err := NewJWTValidator(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	introspection, err := NewIntrospectionValidator(IntrospectionValidatorConfig{
+		IntrospectionURL: config.IntrospectionURL,
+		ClientID:         config.ClientID,
+		ClientSecret:     config.ClientSecret,
+		Audience:         config.Audience,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCompositeValidator(jwtValidator, introspection, config.CacheTTL), nil
+}
+
+// ValidateToken validates tokenString as a local JWT first, falling back to
+// introspection if that fails, and caches whichever outcome succeeds.
+func (v *CompositeValidator) ValidateToken(ctx context.Context, tokenString string) (jwt.MapClaims, error) {
+	if claims, ok := v.cache.get(tokenString); ok {
+		return claims, nil
+	}
+
+	claims, err := v.jwtValidator.ValidateToken(ctx, tokenString)
+	if err != nil {
+		claims, err = v.introspection.ValidateToken(ctx, tokenString)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v.cache.put(tokenString, claims)
+	return claims, nil
+}
+
+// Middleware creates an HTTP middleware that validates tokens via ValidateToken.
+func (v *CompositeValidator) Middleware(next http.Handler) http.Handler {
+	return tokenMiddleware(next, v.ValidateToken)
+}
+
+// validationCache is a small in-process LRU cache, keyed by a SHA-256 hash
+// of the token string (so raw tokens are never retained in memory), used to
+// avoid re-validating or re-introspecting the same token on every request.
+type validationCache struct {
+	mutex    sync.Mutex
+	ttl      time.Duration
+	maxItems int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type validationCacheEntry struct {
+	key       string
+	claims    jwt.MapClaims
+	expiresAt time.Time
+}
+
+func newValidationCache(maxItems int, ttl time.Duration) *validationCache {
+	return &validationCache{
+		ttl:      ttl,
+		maxItems: maxItems,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *validationCache) get(tokenString string) (jwt.MapClaims, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	key := hashToken(tokenString)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*validationCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.claims, true
+}
+
+func (c *validationCache) put(tokenString string, claims jwt.MapClaims) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	key := hashToken(tokenString)
+	expiresAt := c.expiryFor(claims)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*validationCacheEntry).claims = claims
+		elem.Value.(*validationCacheEntry).expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	entry := &validationCacheEntry{key: key, claims: claims, expiresAt: expiresAt}
+	elem := c.order.PushFront(entry)
+	c.items[key] = elem
+
+	if c.order.Len() > c.maxItems {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*validationCacheEntry).key)
+		}
+	}
+}
+
+// expiryFor returns whichever is sooner: c.ttl from now, or claims' own exp.
+// Without this clamp, a cacheTTL configured longer than a token's remaining
+// lifetime (e.g. a 5m cache against a 60s-lived token) would keep serving
+// that token as valid out of the cache for up to cacheTTL after it actually
+// expired.
+func (c *validationCache) expiryFor(claims jwt.MapClaims) time.Time {
+	ttlExpiry := time.Now().Add(c.ttl)
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return ttlExpiry
+	}
+	if exp.Time.Before(ttlExpiry) {
+		return exp.Time
+	}
+	return ttlExpiry
+}
+
+func hashToken(tokenString string) string {
+	sum := sha256.Sum256([]byte(tokenString))
+	return hex.EncodeToString(sum[:])
+}
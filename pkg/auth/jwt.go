@@ -3,6 +3,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,15 +16,36 @@ import (
 
 // Common errors
 var (
-	ErrNoToken           = errors.New("no token provided")
-	ErrInvalidToken      = errors.New("invalid token")
-	ErrTokenExpired      = errors.New("token expired")
-	ErrInvalidIssuer     = errors.New("invalid issuer")
-	ErrInvalidAudience   = errors.New("invalid audience")
-	ErrMissingJWKSURL    = errors.New("missing JWKS URL")
-	ErrFailedToFetchJWKS = errors.New("failed to fetch JWKS")
+	ErrNoToken              = errors.New("no token provided")
+	ErrInvalidToken         = errors.New("invalid token")
+	ErrTokenExpired         = errors.New("token expired")
+	ErrInvalidIssuer        = errors.New("invalid issuer")
+	ErrInvalidAudience      = errors.New("invalid audience")
+	ErrMissingJWKSURL       = errors.New("missing JWKS URL")
+	ErrFailedToFetchJWKS    = errors.New("failed to fetch JWKS")
+	ErrUnexpectedSigningAlg = errors.New("unexpected signing algorithm")
 )
 
+// defaultAllowedAlgorithms is used when JWTValidatorConfig.AllowedAlgorithms
+// is empty, covering the RSA, ECDSA, and EdDSA families supported by
+// getKeyFromJWKS. Operators should pin AllowedAlgorithms to whatever their
+// IdP actually issues to close off algorithm-confusion attacks.
+var defaultAllowedAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// oidcDiscoveryDocument is the subset of an OIDC provider's
+// /.well-known/openid-configuration response that NewJWTValidatorFromIssuer
+// needs to configure a JWTValidator automatically.
+type oidcDiscoveryDocument struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+}
+
 // JWTValidator validates JWT tokens.
 type JWTValidator struct {
 	// OIDC configuration
@@ -33,6 +55,10 @@ type JWTValidator struct {
 	clientID   string
 	jwksClient *jwk.Cache
 
+	// allowedAlgorithms restricts which JWT "alg" values getKeyFromJWKS will
+	// accept, closing the "alg=none" and algorithm-confusion classes of attack.
+	allowedAlgorithms []string
+
 	// No need for additional caching as jwk.Cache handles it
 }
 
@@ -49,6 +75,27 @@ type JWTValidatorConfig struct {
 
 	// ClientID is the OIDC client ID
 	ClientID string
+
+	// AllowedAlgorithms restricts which JWT "alg" values are accepted.
+	// Defaults to every RSA/ECDSA/EdDSA algorithm getKeyFromJWKS supports if
+	// left empty; operators should pin this to whatever their IdP actually
+	// issues.
+	AllowedAlgorithms []string
+
+	// IntrospectionURL, if set, is the OAuth 2.0 token introspection endpoint
+	// (RFC 7662) used to validate opaque access tokens that can't be checked
+	// locally. Only consumed by NewCompositeValidatorFromConfig.
+	IntrospectionURL string
+
+	// ClientSecret is the client secret used for basic auth against
+	// IntrospectionURL, paired with ClientID. Only consumed by
+	// NewCompositeValidatorFromConfig.
+	ClientSecret string
+
+	// CacheTTL is how long NewCompositeValidatorFromConfig's CompositeValidator
+	// caches a validation outcome for a given token, keyed by the token's
+	// hash, to avoid hammering the IdP. Zero disables caching.
+	CacheTTL time.Duration
 }
 
 // NewJWTValidator creates a new JWT validator.
@@ -66,20 +113,81 @@ func NewJWTValidator(ctx context.Context, config JWTValidatorConfig) (*JWTValida
 		return nil, fmt.Errorf("failed to register JWKS URL: %w", err)
 	}
 
+	allowedAlgorithms := config.AllowedAlgorithms
+	if len(allowedAlgorithms) == 0 {
+		allowedAlgorithms = defaultAllowedAlgorithms
+	}
+
 	return &JWTValidator{
-		issuer:     config.Issuer,
-		audience:   config.Audience,
-		jwksURL:    config.JWKSURL,
-		clientID:   config.ClientID,
-		jwksClient: cache,
+		issuer:            config.Issuer,
+		audience:          config.Audience,
+		jwksURL:           config.JWKSURL,
+		clientID:          config.ClientID,
+		jwksClient:        cache,
+		allowedAlgorithms: allowedAlgorithms,
 	}, nil
 }
 
+// NewJWTValidatorFromIssuer creates a new JWT validator configured from
+// issuer's OIDC discovery document (`{issuer}/.well-known/openid-configuration`),
+// automatically picking up the provider's jwks_uri, canonical issuer, and
+// supported signing algorithms instead of requiring them to be set by hand.
+func NewJWTValidatorFromIssuer(ctx context.Context, issuer, audience, clientID string) (*JWTValidator, error) {
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OIDC discovery request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc oidcDiscoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+
+	if doc.JWKSURI == "" {
+		return nil, ErrMissingJWKSURL
+	}
+
+	config := JWTValidatorConfig{
+		Issuer:            doc.Issuer,
+		Audience:          audience,
+		JWKSURL:           doc.JWKSURI,
+		ClientID:          clientID,
+		AllowedAlgorithms: doc.IDTokenSigningAlgValuesSupported,
+	}
+	if config.Issuer == "" {
+		config.Issuer = issuer
+	}
+
+	return NewJWTValidator(ctx, config)
+}
+
 // getKeyFromJWKS gets the key from the JWKS.
 func (v *JWTValidator) getKeyFromJWKS(ctx context.Context, token *jwt.Token) (interface{}, error) {
-	// Validate the signing method
-	if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	// Validate the signing method against the configured allow-list. This
+	// covers RSA, ECDSA, and EdDSA (whichever algorithms the allow-list
+	// permits) instead of hard-coding RSA, and rejects "alg=none" and
+	// algorithm-confusion attempts outright.
+	alg := token.Method.Alg()
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+		// supported families
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedSigningAlg, alg)
+	}
+	if !v.isAlgorithmAllowed(alg) {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedSigningAlg, alg)
 	}
 
 	// Get the key ID from the token header
@@ -109,6 +217,16 @@ func (v *JWTValidator) getKeyFromJWKS(ctx context.Context, token *jwt.Token) (in
 	return rawKey, nil
 }
 
+// isAlgorithmAllowed reports whether alg is in v.allowedAlgorithms.
+func (v *JWTValidator) isAlgorithmAllowed(alg string) bool {
+	for _, allowed := range v.allowedAlgorithms {
+		if allowed == alg {
+			return true
+		}
+	}
+	return false
+}
+
 // validateClaims validates the claims in the token.
 func (v *JWTValidator) validateClaims(claims jwt.MapClaims) error {
 	// Validate the issuer if provided
@@ -183,25 +301,38 @@ type ClaimsContextKey struct{}
 
 // Middleware creates an HTTP middleware that validates JWT tokens.
 func (v *JWTValidator) Middleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get the token from the Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
-			return
-		}
+	return tokenMiddleware(next, v.ValidateToken)
+}
+
+// bearerToken extracts the token from a request's "Authorization: Bearer
+// ..." header, shared by every Validator's Middleware implementation in this
+// package.
+func bearerToken(r *http.Request) (string, error) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", fmt.Errorf("authorization header required")
+	}
+
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return "", fmt.Errorf("invalid authorization header format")
+	}
 
-		// Check if the Authorization header has the Bearer prefix
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			http.Error(w, "Invalid Authorization header format", http.StatusUnauthorized)
+	return strings.TrimPrefix(authHeader, "Bearer "), nil
+}
+
+// tokenMiddleware builds the Authorization-header-to-claims-context
+// middleware shared by every Validator in this package, parameterized on
+// validate so JWTValidator, IntrospectionValidator, and CompositeValidator
+// can each plug in their own token-validation strategy.
+func tokenMiddleware(next http.Handler, validate func(ctx context.Context, tokenString string) (jwt.MapClaims, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenString, err := bearerToken(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		// Extract the token
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-		// Validate the token
-		claims, err := v.ValidateToken(r.Context(), tokenString)
+		claims, err := validate(r.Context(), tokenString)
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Invalid token: %v", err), http.StatusUnauthorized)
 			return
@@ -0,0 +1,120 @@
+// Package registry resolves per-registry pull credentials the way
+// containers/image and the Docker CLI do: a credential-helper-aware
+// authfile (an authfile proper, or a Docker config.json), optionally
+// overridden by a one-off "user:password" pair for a single pull.
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Credentials are the username/password resolved for a single registry
+// host.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// authFile is the subset of Docker's config.json / containers/image's
+// auth.json toolhive reads.
+type authFile struct {
+	Auths map[string]struct {
+		Auth string `json:"auth"`
+	} `json:"auths"`
+	CredHelpers map[string]string `json:"credHelpers,omitempty"`
+}
+
+// DefaultPath returns the authfile toolhive reads when --authfile isn't
+// given: $XDG_RUNTIME_DIR/containers/auth.json if set, falling back to
+// ~/.docker/config.json, matching skopeo/podman's own default search order.
+func DefaultPath() string {
+	if xdgRuntimeDir := os.Getenv("XDG_RUNTIME_DIR"); xdgRuntimeDir != "" {
+		candidate := filepath.Join(xdgRuntimeDir, "containers", "auth.json")
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".docker", "config.json")
+	}
+	return ""
+}
+
+// Resolve returns the credentials to use for registryHost: oneOff
+// ("user:password"), if given, always wins; otherwise the authfile at path
+// is consulted (DefaultPath() if path is empty), matching registryHost
+// against its "auths" entries. ok is false if no credentials were found
+// anywhere, which isn't an error -- plenty of pulls are anonymous.
+func Resolve(path, registryHost, oneOff string) (creds Credentials, ok bool, err error) {
+	if oneOff != "" {
+		user, password, found := strings.Cut(oneOff, ":")
+		if !found {
+			return Credentials{}, false, fmt.Errorf("invalid --creds value %q: expected \"user:password\"", oneOff)
+		}
+		return Credentials{Username: user, Password: password}, true, nil
+	}
+
+	if path == "" {
+		path = DefaultPath()
+	}
+	if path == "" {
+		return Credentials{}, false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Credentials{}, false, nil
+	}
+	if err != nil {
+		return Credentials{}, false, fmt.Errorf("failed to read authfile %s: %w", path, err)
+	}
+
+	var parsed authFile
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return Credentials{}, false, fmt.Errorf("failed to parse authfile %s: %w", path, err)
+	}
+
+	if helper, hasHelper := parsed.CredHelpers[registryHost]; hasHelper {
+		return Credentials{}, false, fmt.Errorf(
+			"registry %s is configured to use credential helper %q, which toolhive does not yet invoke", registryHost, helper,
+		)
+	}
+
+	entry, found := parsed.Auths[registryHost]
+	if !found {
+		return Credentials{}, false, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return Credentials{}, false, fmt.Errorf("failed to decode auth entry for %s: %w", registryHost, err)
+	}
+
+	user, password, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return Credentials{}, false, fmt.Errorf("malformed auth entry for %s", registryHost)
+	}
+	return Credentials{Username: user, Password: password}, true, nil
+}
+
+// HostFromImageRef extracts the registry host portion of an image
+// reference, e.g. "ghcr.io" from "ghcr.io/acme/server:latest", defaulting
+// to Docker Hub's canonical host for an unqualified reference.
+func HostFromImageRef(imageRef string) string {
+	firstSegment, rest, hasSlash := strings.Cut(imageRef, "/")
+	if !hasSlash {
+		return "docker.io"
+	}
+	if !strings.ContainsAny(firstSegment, ".:") && firstSegment != "localhost" {
+		// No dot/port/localhost in the first path segment means it isn't a
+		// registry host at all (e.g. "library/ubuntu" on Docker Hub).
+		return "docker.io"
+	}
+	_ = rest
+	return firstSegment
+}
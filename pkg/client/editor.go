@@ -1,13 +1,202 @@
 package client
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // ConfigEditor defines the interface for types which can edit MCP client config files.
 type ConfigEditor interface {
 	AddServer(config *ConfigFile, serverName, url string) error
 }
 
+// EditorDescriptor declaratively describes one MCP client's config file
+// format, so a single generic ConfigEditor can drive it instead of every
+// client needing its own hand-written "descend through JSON and apply a
+// diff" implementation.
+type EditorDescriptor struct {
+	// ServersPath is a "/"-separated JSON pointer to the object that holds
+	// server entries keyed by server name, e.g. "mcpServers" or
+	// "mcp/servers". Missing intermediate objects are created as needed.
+	ServersPath string
+	// EntryTemplate is the shape given to a brand-new server entry, e.g.
+	// {"url": "", "type": "sse"}. UpdateField is filled in with the
+	// server's URL on top of this template.
+	EntryTemplate map[string]any
+	// UpdateField is the key within a server entry that AddServer writes
+	// the URL to, whether the entry already existed or was just created
+	// from EntryTemplate.
+	UpdateField string
+	// PreserveFields lists entry keys, besides UpdateField, that must
+	// survive an update: if one is already present it's left untouched,
+	// and if it's missing it's seeded from EntryTemplate. They're never
+	// clobbered by a later call.
+	PreserveFields []string
+	// JSONC marks that this client's config file may contain comments
+	// (VSCode- and Cursor-style settings), so AddServer must edit the raw
+	// text in place rather than round-tripping through encoding/json,
+	// which would silently drop them.
+	JSONC bool
+}
+
+var (
+	configEditorsMu sync.RWMutex
+	configEditors   = map[string]EditorDescriptor{}
+)
+
+// RegisterConfigEditor registers the declarative config editor for an MCP
+// client under name, so downstream users can support a new client's config
+// format without forking this package. Calling it again with a name that's
+// already registered overwrites the existing descriptor.
+func RegisterConfigEditor(name string, desc EditorDescriptor) {
+	configEditorsMu.Lock()
+	defer configEditorsMu.Unlock()
+	configEditors[name] = desc
+}
+
+// ConfigEditorFor returns the ConfigEditor registered for name, and false
+// if no editor has been registered under that name.
+func ConfigEditorFor(name string) (ConfigEditor, bool) {
+	configEditorsMu.RLock()
+	desc, ok := configEditors[name]
+	configEditorsMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return NewGenericConfigEditor(desc), true
+}
+
+func init() {
+	RegisterConfigEditor("claude-desktop", EditorDescriptor{
+		ServersPath:   "mcpServers",
+		EntryTemplate: map[string]any{"url": ""},
+		UpdateField:   "url",
+	})
+	RegisterConfigEditor("vscode", EditorDescriptor{
+		ServersPath:    "mcp/servers",
+		EntryTemplate:  map[string]any{"url": "", "type": "sse"},
+		UpdateField:    "url",
+		PreserveFields: []string{"type"},
+		JSONC:          true,
+	})
+	RegisterConfigEditor("cursor", EditorDescriptor{
+		ServersPath:   "mcpServers",
+		EntryTemplate: map[string]any{"url": ""},
+		UpdateField:   "url",
+		JSONC:         true,
+	})
+	RegisterConfigEditor("zed", EditorDescriptor{
+		ServersPath:    "context_servers",
+		EntryTemplate:  map[string]any{"url": "", "type": "sse"},
+		UpdateField:    "url",
+		PreserveFields: []string{"type"},
+	})
+	RegisterConfigEditor("continue", EditorDescriptor{
+		ServersPath:   "mcpServers",
+		EntryTemplate: map[string]any{"url": ""},
+		UpdateField:   "url",
+	})
+	RegisterConfigEditor("windsurf", EditorDescriptor{
+		ServersPath:   "mcpServers",
+		EntryTemplate: map[string]any{"url": ""},
+		UpdateField:   "url",
+	})
+}
+
+// GenericConfigEditor is a ConfigEditor driven entirely by an
+// EditorDescriptor, replacing the one-struct-per-client editors below for
+// any client whose config format fits the declarative schema.
+type GenericConfigEditor struct {
+	desc EditorDescriptor
+}
+
+// NewGenericConfigEditor creates a ConfigEditor for the given descriptor.
+func NewGenericConfigEditor(desc EditorDescriptor) *GenericConfigEditor {
+	return &GenericConfigEditor{desc: desc}
+}
+
+// AddServer inserts or updates a server in the MCP client config file.
+func (e *GenericConfigEditor) AddServer(config *ConfigFile, serverName, url string) error {
+	if e.desc.JSONC {
+		return setJSONCField(&config.Raw, e.desc.ServersPath, serverName, func(existing map[string]any, isNew bool) map[string]any {
+			return e.buildEntry(existing, isNew, url)
+		})
+	}
+
+	servers, err := descendToObject(config.Contents, e.desc.ServersPath)
+	if err != nil {
+		return err
+	}
+
+	existing, _ := servers[serverName].(map[string]any)
+	servers[serverName] = e.buildEntry(existing, existing == nil, url)
+	return nil
+}
+
+// buildEntry computes the entry to store for serverName: a fresh copy of
+// EntryTemplate if existing is nil, otherwise existing with UpdateField set
+// to url and every PreserveFields key backfilled from EntryTemplate if
+// missing.
+func (e *GenericConfigEditor) buildEntry(existing map[string]any, isNew bool, url string) map[string]any {
+	entry := existing
+	if isNew || entry == nil {
+		entry = make(map[string]any, len(e.desc.EntryTemplate))
+		for k, v := range e.desc.EntryTemplate {
+			entry[k] = v
+		}
+	}
+
+	entry[e.desc.UpdateField] = url
+
+	for _, field := range e.desc.PreserveFields {
+		if _, ok := entry[field]; !ok {
+			if def, ok := e.desc.EntryTemplate[field]; ok {
+				entry[field] = def
+			}
+		}
+	}
+
+	return entry
+}
+
+// descendToObject walks path (a "/"-separated sequence of object keys) from
+// root, creating missing intermediate map[string]any nodes as needed, and
+// returns the map at the end of the path.
+func descendToObject(root map[string]any, path string) (map[string]any, error) {
+	node := root
+	for _, seg := range splitPath(path) {
+		child, ok := node[seg]
+		if !ok {
+			child = make(map[string]any)
+			node[seg] = child
+		}
+		childMap, ok := child.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("config path %q: %q is not an object", path, seg)
+		}
+		node = childMap
+	}
+	return node, nil
+}
+
+// splitPath splits a "/"-separated EditorDescriptor.ServersPath into its
+// individual object keys.
+func splitPath(path string) []string {
+	var segs []string
+	for _, seg := range strings.Split(path, "/") {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+	return segs
+}
+
 // StandardConfigEditor edits the MCP client config format used by most clients.
+//
+// Deprecated: new clients should register an EditorDescriptor with
+// RegisterConfigEditor and use GenericConfigEditor instead; this type is
+// kept only because it predates that generalization.
 type StandardConfigEditor struct{}
 
 // AddServer inserts or updates a server in the MCP client config file.
@@ -53,11 +242,14 @@ func (*StandardConfigEditor) AddServer(config *ConfigFile, serverName, url strin
 }
 
 // VSCodeConfigEditor edits the MCP client config format used by VSCode.
+//
+// Deprecated: new clients should register an EditorDescriptor with
+// RegisterConfigEditor and use GenericConfigEditor instead; this type is
+// kept only because it predates that generalization.
 type VSCodeConfigEditor struct{}
 
 // AddServer inserts or updates a server in the MCP client config file.
 func (*VSCodeConfigEditor) AddServer(config *ConfigFile, serverName, url string) error {
-	// TODO: This pattern of "descend through JSON and apply a diff" can be generalized.
 	// Get mcp object
 	mcp, ok := config.Contents["mcp"]
 	if !ok {
@@ -0,0 +1,323 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// setJSONCField inserts or updates key within the object at path (a
+// "/"-separated sequence of object keys, same convention as
+// EditorDescriptor.ServersPath) inside *raw, which may be JSON-with-comments
+// (`//` and `/* */` comments, as used by VSCode- and Cursor-style settings
+// files). Unlike unmarshalling the whole document and re-marshalling it,
+// this only rewrites the byte range of the touched entry, so comments and
+// formatting everywhere else in the file survive untouched.
+//
+// mutate receives the entry's existing fields (nil if key is new) and
+// returns the fields to store.
+func setJSONCField(raw *[]byte, path string, key string, mutate func(existing map[string]any, isNew bool) map[string]any) error {
+	objStart, objEnd, err := ensureObjectPath(raw, splitPath(path))
+	if err != nil {
+		return err
+	}
+
+	clean := stripJSONCComments(*raw)
+	valStart, valEnd, insertAt, hasFields, found, err := objectFieldRange(clean, objStart, objEnd, key)
+	if err != nil {
+		return err
+	}
+
+	var existing map[string]any
+	if found {
+		_ = json.Unmarshal(clean[valStart:valEnd], &existing)
+	}
+	updated := mutate(existing, !found)
+
+	encoded, err := json.Marshal(updated)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q: %w", key, err)
+	}
+
+	if found {
+		*raw = splice(*raw, valStart, valEnd, encoded)
+		return nil
+	}
+
+	*raw = insertField(*raw, objStart, insertAt, hasFields, fmt.Sprintf("%q: %s", key, encoded))
+	return nil
+}
+
+// insertField splices `"key": value` (newFieldText) into the object
+// starting at objStart, just before its closing brace at insertAt, indented
+// two spaces deeper than the line the object opens on (see detectIndent) and
+// with a leading comma if hasFields says it already has at least one.
+func insertField(raw []byte, objStart, insertAt int, hasFields bool, newFieldText string) []byte {
+	trimEnd := insertAt
+	for trimEnd > objStart && isWS(raw[trimEnd-1]) {
+		trimEnd--
+	}
+
+	indent := detectIndent(raw, objStart)
+	var entry []byte
+	if hasFields {
+		entry = append(entry, ',')
+	}
+	entry = append(entry, '\n')
+	entry = append(entry, indent...)
+	entry = append(entry, newFieldText...)
+	return splice(raw, trimEnd, trimEnd, entry)
+}
+
+// ensureObjectPath walks path from the root object of *raw, creating any
+// missing intermediate object as `{}` and splicing it into *raw, and
+// returns the byte range of the object at the end of path.
+func ensureObjectPath(raw *[]byte, path []string) (objStart, objEnd int, err error) {
+	clean := stripJSONCComments(*raw)
+	objStart = skipWS(clean, 0)
+	if objStart >= len(clean) || clean[objStart] != '{' {
+		return 0, 0, fmt.Errorf("expected a top-level JSON object")
+	}
+	objEnd, err = scanValue(clean, objStart)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, seg := range path {
+		clean = stripJSONCComments(*raw)
+		valStart, valEnd, insertAt, hasFields, found, ferr := objectFieldRange(clean, objStart, objEnd, seg)
+		if ferr != nil {
+			return 0, 0, ferr
+		}
+
+		if !found {
+			before := len(*raw)
+			*raw = insertField(*raw, objStart, insertAt, hasFields, fmt.Sprintf("%q: {}", seg))
+			objEnd += len(*raw) - before
+
+			clean = stripJSONCComments(*raw)
+			valStart, valEnd, _, _, found, ferr = objectFieldRange(clean, objStart, objEnd, seg)
+			if ferr != nil || !found {
+				return 0, 0, fmt.Errorf("failed to create config path segment %q", seg)
+			}
+		}
+
+		if clean[valStart] != '{' {
+			return 0, 0, fmt.Errorf("config path segment %q is not an object", seg)
+		}
+		objStart, objEnd = valStart, valEnd
+	}
+
+	return objStart, objEnd, nil
+}
+
+// objectFieldRange scans the object occupying data[objStart:objEnd] (data
+// must have comments already blanked out by stripJSONCComments, so its
+// offsets line up byte-for-byte with the original) for key, returning the
+// byte range of its value. If key isn't present, found is false and
+// insertAt is the offset just before the object's closing brace, suitable
+// for splicing in a new field; hasFields reports whether the object already
+// has at least one field (so callers know whether a leading comma is
+// needed).
+func objectFieldRange(data []byte, objStart, objEnd int, key string) (valStart, valEnd, insertAt int, hasFields, found bool, err error) {
+	i := skipWS(data, objStart+1)
+	for i < objEnd-1 {
+		if data[i] == '}' {
+			break
+		}
+		if data[i] != '"' {
+			return 0, 0, 0, false, false, fmt.Errorf("expected object key at offset %d", i)
+		}
+
+		keyEnd, kerr := scanValue(data, i)
+		if kerr != nil {
+			return 0, 0, 0, false, false, kerr
+		}
+		var k string
+		if uerr := json.Unmarshal(data[i:keyEnd], &k); uerr != nil {
+			return 0, 0, 0, false, false, fmt.Errorf("invalid object key at offset %d: %w", i, uerr)
+		}
+
+		colon := skipWS(data, keyEnd)
+		if colon >= objEnd || data[colon] != ':' {
+			return 0, 0, 0, false, false, fmt.Errorf("expected ':' after key %q", k)
+		}
+
+		vStart := skipWS(data, colon+1)
+		vEnd, verr := scanValue(data, vStart)
+		if verr != nil {
+			return 0, 0, 0, false, false, verr
+		}
+
+		hasFields = true
+		if k == key {
+			found = true
+			valStart, valEnd = vStart, vEnd
+		}
+
+		i = skipWS(data, vEnd)
+		if i < objEnd && data[i] == ',' {
+			i = skipWS(data, i+1)
+		}
+	}
+
+	return valStart, valEnd, objEnd - 1, hasFields, found, nil
+}
+
+// scanValue returns the index one past the JSON value beginning at data[i],
+// which must be the first non-whitespace byte of that value.
+func scanValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, fmt.Errorf("unexpected end of input")
+	}
+
+	switch data[i] {
+	case '"':
+		j := i + 1
+		for j < len(data) {
+			switch data[j] {
+			case '\\':
+				j += 2
+				continue
+			case '"':
+				return j + 1, nil
+			}
+			j++
+		}
+		return 0, fmt.Errorf("unterminated string starting at offset %d", i)
+
+	case '{', '[':
+		open, closeCh := data[i], byte('}')
+		if open == '[' {
+			closeCh = ']'
+		}
+		depth := 0
+		j := i
+		for j < len(data) {
+			switch data[j] {
+			case '"':
+				end, err := scanValue(data, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end
+				continue
+			case open:
+				depth++
+			case closeCh:
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+			j++
+		}
+		return 0, fmt.Errorf("unterminated %q starting at offset %d", string(open), i)
+
+	default:
+		j := i
+		for j < len(data) {
+			switch data[j] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return j, nil
+			}
+			j++
+		}
+		return j, nil
+	}
+}
+
+// skipWS returns the index of the first byte at or after i that isn't
+// whitespace (comments must already be blanked to spaces).
+func skipWS(data []byte, i int) int {
+	for i < len(data) && isWS(data[i]) {
+		i++
+	}
+	return i
+}
+
+// isWS reports whether b is JSON whitespace.
+func isWS(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\r':
+		return true
+	}
+	return false
+}
+
+// detectIndent returns the leading whitespace of the line containing
+// objStart, plus two extra spaces for one nesting level, for use as the
+// indentation of a newly inserted field.
+func detectIndent(data []byte, objStart int) string {
+	lineStart := objStart
+	for lineStart > 0 && data[lineStart-1] != '\n' {
+		lineStart--
+	}
+
+	indentEnd := lineStart
+	for indentEnd < len(data) && (data[indentEnd] == ' ' || data[indentEnd] == '\t') {
+		indentEnd++
+	}
+
+	return string(data[lineStart:indentEnd]) + "  "
+}
+
+// splice returns data with the byte range [start:end) replaced by with.
+func splice(data []byte, start, end int, with []byte) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(with))
+	out = append(out, data[:start]...)
+	out = append(out, with...)
+	out = append(out, data[end:]...)
+	return out
+}
+
+// stripJSONCComments returns data with `//` line comments and `/* */` block
+// comments replaced by spaces (newlines inside block comments are kept so
+// line numbers don't shift), so the result has exactly the same length and
+// byte offsets as data but can be fed to a strict JSON scanner. String
+// contents are left untouched even if they contain "//" or "/*".
+func stripJSONCComments(data []byte) []byte {
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		switch {
+		case inString:
+			if out[i] == '\\' {
+				i++
+				continue
+			}
+			if out[i] == '"' {
+				inString = false
+			}
+
+		case out[i] == '"':
+			inString = true
+
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '/':
+			for i < len(out) && out[i] != '\n' {
+				out[i] = ' '
+				i++
+			}
+
+		case out[i] == '/' && i+1 < len(out) && out[i+1] == '*':
+			out[i], out[i+1] = ' ', ' '
+			i += 2
+			for i < len(out) && !(out[i] == '*' && i+1 < len(out) && out[i+1] == '/') {
+				if out[i] != '\n' {
+					out[i] = ' '
+				}
+				i++
+			}
+			if i < len(out) {
+				out[i] = ' '
+			}
+			if i+1 < len(out) {
+				out[i+1] = ' '
+			}
+			i++
+		}
+	}
+
+	return out
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/stacklok/toolhive/pkg/config"
 	ct "github.com/stacklok/toolhive/pkg/container"
@@ -25,6 +26,10 @@ type Manager interface {
 	RegisterClient(ctx context.Context, client Client) error
 	// UnregisterClient unregisters a client from ToolHive.
 	UnregisterClient(ctx context.Context, client Client) error
+	// RemoveMany removes the named MCP servers from every registered client's
+	// configuration. Each name is removed from a file by its own
+	// ConfigUpdater.Remove call; the names are not combined into one update.
+	RemoveMany(ctx context.Context, names []string) error
 }
 
 type defaultManager struct {
@@ -224,3 +229,71 @@ func (m *defaultManager) removeMCPsFromClient(ctx context.Context, clientType MC
 
 	return nil
 }
+
+// RemoveMany removes each name in names from every registered client's
+// configuration, and from auto-discovered ones when auto-discovery is on.
+// Each client configuration file is looked up once, then gets one
+// ConfigUpdater.Remove call per name; the names are not combined into a
+// single update of the file.
+func (m *defaultManager) RemoveMany(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	appConfig := config.GetConfig()
+
+	var errs []string
+	for _, clientName := range appConfig.Clients.RegisteredClients {
+		clientConfig, err := FindClientConfig(MCPClient(clientName))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to find client configuration for %s: %v", clientName, err))
+			continue
+		}
+
+		for _, name := range names {
+			if err := clientConfig.ConfigUpdater.Remove(name); err != nil {
+				errs = append(errs, fmt.Sprintf(
+					"failed to remove MCP server %s from client configuration %s: %v", name, clientConfig.Path, err,
+				))
+				continue
+			}
+			logger.Infof("Removed MCP server %s from client %s\n", name, clientName)
+		}
+	}
+
+	if err := m.removeManyFromDiscoveredConfigs(ctx, names); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d client configuration update(s) failed:\n%s", len(errs), strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// removeManyFromDiscoveredConfigs removes names from every auto-discovered
+// client configuration file (as opposed to explicitly registered clients),
+// with one ConfigUpdater.Remove call per name and file. Per-name failures are
+// logged, not returned.
+func (*defaultManager) removeManyFromDiscoveredConfigs(_ context.Context, names []string) error {
+	if !config.GetConfig().Clients.AutoDiscovery {
+		return nil
+	}
+
+	configs, err := FindClientConfigs()
+	if err != nil {
+		return fmt.Errorf("failed to find client configurations: %w", err)
+	}
+
+	for _, c := range configs {
+		for _, name := range names {
+			if err := c.ConfigUpdater.Remove(name); err != nil {
+				logger.Warnf("Warning: Failed to remove MCP server %s from client configuration %s: %v", name, c.Path, err)
+				continue
+			}
+			logger.Infof("Removed MCP server %s from client configuration %s\n", name, c.Path)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ package container
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -18,6 +20,7 @@ import (
 	dockerimage "github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
+	dockerregistry "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
 
@@ -521,21 +524,70 @@ func (c *Client) ImageExists(ctx context.Context, imageName string) (bool, error
 	return len(images) > 0, nil
 }
 
-// PullImage pulls an image from a registry
-func (c *Client) PullImage(ctx context.Context, imageName string) error {
+// PullImage pulls an image from a registry, optionally selecting a
+// platform variant and/or authenticating per opts.
+func (c *Client) PullImage(ctx context.Context, imageName string, opts *PullImageOptions) error {
 	fmt.Printf("Pulling image: %s\n", imageName)
 
+	pullOpts := dockerimage.PullOptions{}
+	if opts != nil {
+		pullOpts.Platform = opts.Platform
+		if opts.Username != "" || opts.Password != "" {
+			encoded, err := json.Marshal(dockerregistry.AuthConfig{
+				Username: opts.Username,
+				Password: opts.Password,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode registry credentials: %w", err)
+			}
+			pullOpts.RegistryAuth = base64.URLEncoding.EncodeToString(encoded)
+		}
+	}
+
 	// Pull the image
-	reader, err := c.client.ImagePull(ctx, imageName, dockerimage.PullOptions{})
+	reader, err := c.client.ImagePull(ctx, imageName, pullOpts)
 	if err != nil {
 		return NewContainerError(err, "", fmt.Sprintf("failed to pull image: %v", err))
 	}
 	defer reader.Close()
 
-	// Read the output to ensure the pull completes
-	_, err = io.Copy(os.Stdout, reader)
-	if err != nil {
-		return NewContainerError(err, "", fmt.Sprintf("failed to read pull output: %v", err))
+	// Parse and stream the pull output so a large image's progress is
+	// visible instead of a silent multi-minute wait.
+	if err := parsePullOutput(reader, os.Stdout); err != nil {
+		return NewContainerError(err, "", fmt.Sprintf("failed to process pull output: %v", err))
+	}
+
+	return nil
+}
+
+// parsePullOutput parses the Docker image pull output and formats it in a
+// more readable way, one progress line per layer instead of a wall of raw
+// JSON.
+func parsePullOutput(reader io.Reader, writer io.Writer) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		var pullStatus struct {
+			Status         string          `json:"status"`
+			ID             string          `json:"id,omitempty"`
+			ProgressDetail json.RawMessage `json:"progressDetail,omitempty"`
+			Progress       string          `json:"progress,omitempty"`
+		}
+
+		if err := decoder.Decode(&pullStatus); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("failed to decode pull output: %w", err)
+		}
+
+		switch {
+		case pullStatus.Progress != "":
+			fmt.Fprintf(writer, "%s: %s %s\n", pullStatus.Status, pullStatus.ID, pullStatus.Progress)
+		case pullStatus.ID != "":
+			fmt.Fprintf(writer, "%s: %s\n", pullStatus.Status, pullStatus.ID)
+		default:
+			fmt.Fprintf(writer, "%s\n", pullStatus.Status)
+		}
 	}
 
 	return nil
@@ -631,6 +683,12 @@ func (c *Client) getPermissionConfigFromProfile(profile *permissions.Profile, tr
 		config.NetworkMode = "bridge"
 	}
 
+	secOpts, err := securityOptsFromProfile(profile.Seccomp, profile.AppArmor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve security options: %w", err)
+	}
+	config.SecurityOpt = append(config.SecurityOpt, secOpts...)
+
 	// Validate transport type
 	if transportType != "sse" && transportType != "stdio" {
 		return nil, fmt.Errorf("unsupported transport type: %s", transportType)
@@ -638,3 +696,53 @@ func (c *Client) getPermissionConfigFromProfile(profile *permissions.Profile, tr
 
 	return config, nil
 }
+
+// seccompProfileDirEnvVar overrides the directory a "localhost/<name>"
+// seccomp profile is resolved against.
+const seccompProfileDirEnvVar = "TOOLHIVE_SECCOMP_PROFILE_DIR"
+
+// defaultSeccompProfileDir mirrors Podman's own default search location for
+// named seccomp profiles.
+const defaultSeccompProfileDir = "/etc/containers/seccomp.d"
+
+// securityOptsFromProfile translates a permission profile's Seccomp and
+// AppArmor settings into Docker-style "--security-opt" values. Seccomp may
+// be "runtime/default" (or empty, its default) to leave the runtime's own
+// default confinement in place, "unconfined" to disable it, a
+// "localhost/<name>" reference resolved against seccompProfileDirEnvVar
+// (defaultSeccompProfileDir if unset), or an inline OCI seccomp JSON
+// document. AppArmor may be "runtime/default" (or empty), "unconfined", or
+// the name of an already-loaded profile.
+func securityOptsFromProfile(seccomp, apparmor string) ([]string, error) {
+	var opts []string
+
+	switch {
+	case seccomp == "" || seccomp == "runtime/default":
+		// Leave the runtime's own default seccomp confinement in place.
+	case seccomp == "unconfined":
+		opts = append(opts, "seccomp=unconfined")
+	case strings.HasPrefix(seccomp, "localhost/"):
+		dir := os.Getenv(seccompProfileDirEnvVar)
+		if dir == "" {
+			dir = defaultSeccompProfileDir
+		}
+		opts = append(opts, "seccomp="+filepath.Join(dir, strings.TrimPrefix(seccomp, "localhost/")))
+	case json.Valid([]byte(seccomp)):
+		opts = append(opts, "seccomp="+seccomp)
+	default:
+		return nil, fmt.Errorf(
+			"invalid seccomp profile %q: expected runtime/default, unconfined, localhost/<name>, or inline OCI seccomp JSON", seccomp,
+		)
+	}
+
+	switch {
+	case apparmor == "" || apparmor == "runtime/default":
+		// Leave the runtime's own default AppArmor confinement in place.
+	case apparmor == "unconfined":
+		opts = append(opts, "apparmor=unconfined")
+	default:
+		opts = append(opts, "apparmor="+apparmor)
+	}
+
+	return opts, nil
+}
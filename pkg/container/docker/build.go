@@ -0,0 +1,349 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types/build"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// containerdSnapshotterDriver is the DriverStatus key the daemon's /info
+// endpoint reports when the containerd image store is enabled, which
+// BuildImage requires for a Platforms build producing a multi-arch image
+// index -- the classic graphdriver storage can only ever hold one
+// platform's layers for a given image reference.
+const containerdSnapshotterDriver = "driver-type"
+
+// BuildImage builds an image from opts, using BuildKit instead of the
+// legacy builder whenever the daemon advertises it (so multi-stage builds,
+// cache mounts, and --secret work; there is no option for SSH forwarding),
+// and either a local directory
+// tarred up as the build context or a remote context (a git URL, an HTTPS
+// tarball URL, or an OCI artifact reference) the daemon resolves itself. It
+// blocks until the build completes, returning the built image's ID and its
+// progress log as the plain text parseBuildOutput renders from the daemon's
+// JSON messages.
+func (c *Client) BuildImage(ctx context.Context, opts runtime.BuildOptions) (string, io.ReadCloser, error) {
+	logger.Infof("Building image %s from %s", strings.Join(opts.Tags, ","), buildContextDescription(opts))
+
+	useBuildKit, err := c.buildKitAvailable(ctx)
+	if err != nil {
+		return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to query daemon info: %v", err))
+	}
+
+	if len(opts.Platforms) > 1 {
+		if !useBuildKit {
+			return "", nil, fmt.Errorf("building for multiple platforms %v requires BuildKit", opts.Platforms)
+		}
+		hasContainerdStore, err := c.hasContainerdImageStore(ctx)
+		if err != nil {
+			return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to query daemon info: %v", err))
+		}
+		if !hasContainerdStore {
+			return "", nil, fmt.Errorf("building for multiple platforms %v requires the containerd image store", opts.Platforms)
+		}
+	}
+	if len(opts.Secrets) > 0 && !useBuildKit {
+		return "", nil, fmt.Errorf("build secrets %v require BuildKit", opts.Secrets)
+	}
+
+	dockerfile := opts.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	buildOptions := build.ImageBuildOptions{
+		Tags:       opts.Tags,
+		Dockerfile: dockerfile,
+		BuildArgs:  toDockerBuildArgs(opts.BuildArgs),
+		Target:     opts.Target,
+		Platform:   buildPlatformArg(opts),
+		PullParent: opts.Pull,
+		NoCache:    opts.NoCache,
+		Labels:     opts.Labels,
+		CacheFrom:  opts.CacheFrom,
+		Remove:     true,
+	}
+	if useBuildKit {
+		buildOptions.Version = build.BuilderBuildKit
+		buildOptions.SessionID = buildKitSessionID(opts)
+	}
+	if len(opts.CacheTo) > 0 {
+		if !useBuildKit {
+			logger.Warnf("BuildImage: CacheTo is not supported by the classic Docker build API; ignoring %v", opts.CacheTo)
+		} else {
+			buildOptions.Outputs = cacheToOutputs(opts.CacheTo)
+		}
+	}
+	if len(opts.Secrets) > 0 {
+		buildOptions.SecurityOpt = append(buildOptions.SecurityOpt, secretSecurityOpts(opts.Secrets)...)
+	}
+
+	var body io.Reader
+	if opts.RemoteContext != "" {
+		// The daemon resolves a git URL, HTTPS tarball, or OCI artifact
+		// reference itself; no local tar to stream.
+		buildOptions.RemoteContext = opts.RemoteContext
+	} else {
+		tarFile, err := os.CreateTemp("", "docker-build-context-*.tar")
+		if err != nil {
+			return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to create temporary tar file: %v", err))
+		}
+		defer os.Remove(tarFile.Name())
+		defer tarFile.Close()
+
+		if err := createTarFromDir(opts.ContextDir, tarFile); err != nil {
+			return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to create tar archive: %v", err))
+		}
+		if _, err := tarFile.Seek(0, 0); err != nil {
+			return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to reset tar file pointer: %v", err))
+		}
+		body = tarFile
+	}
+
+	response, err := c.client.ImageBuild(ctx, body, buildOptions)
+	if err != nil {
+		return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to build image: %v", err))
+	}
+	defer response.Body.Close()
+
+	logBuf := &bytes.Buffer{}
+	imageID, err := parseBuildOutput(response.Body, logBuf)
+	if err != nil {
+		return "", nil, NewContainerError(err, "", fmt.Sprintf("failed to process build output: %v", err))
+	}
+
+	return imageID, io.NopCloser(logBuf), nil
+}
+
+// buildContextDescription renders opts' build context for the BuildImage
+// startup log line.
+func buildContextDescription(opts runtime.BuildOptions) string {
+	if opts.RemoteContext != "" {
+		return fmt.Sprintf("remote context %s", opts.RemoteContext)
+	}
+	return fmt.Sprintf("context directory %s", opts.ContextDir)
+}
+
+// buildPlatformArg renders opts.Platforms as the comma-separated platform
+// list BuildKit's multi-platform build accepts, falling back to the
+// single-platform Platform field when Platforms is empty.
+func buildPlatformArg(opts runtime.BuildOptions) string {
+	if len(opts.Platforms) > 0 {
+		return strings.Join(opts.Platforms, ",")
+	}
+	return opts.Platform
+}
+
+// secretSecurityOpts renders BuildOptions.Secrets ("id=mysecret,src=/path")
+// as the SecurityOpt entries the Docker SDK plumbs through to BuildKit's
+// --secret handling.
+func secretSecurityOpts(secrets []string) []string {
+	opts := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		opts = append(opts, "secret="+s)
+	}
+	return opts
+}
+
+// cacheToOutputs renders BuildOptions.CacheTo as BuildKit exporter output
+// specs, the form ImageBuildOptions.Outputs expects once Version is set to
+// BuilderBuildKit.
+func cacheToOutputs(cacheTo []string) []build.ImageBuildOutput {
+	outputs := make([]build.ImageBuildOutput, 0, len(cacheTo))
+	for _, dest := range cacheTo {
+		outputs = append(outputs, build.ImageBuildOutput{
+			Type:  "registry",
+			Attrs: map[string]string{"ref": dest, "mode": "max"},
+		})
+	}
+	return outputs
+}
+
+// buildKitSessionID derives a stable BuildKit session ID from opts' tags so
+// repeated builds of the same image reuse the same session-scoped cache
+// mounts instead of each getting a fresh, empty one.
+func buildKitSessionID(opts runtime.BuildOptions) string {
+	if len(opts.Tags) == 0 {
+		return ""
+	}
+	return "toolhive-" + strings.ReplaceAll(opts.Tags[0], "/", "-")
+}
+
+// buildKitAvailable reports whether the daemon speaks the BuildKit builder
+// protocol (Docker Desktop and dockerd >= 23 default to this; older
+// daemons, and most Podman compat endpoints, only speak the legacy
+// builder).
+func (c *Client) buildKitAvailable(ctx context.Context) (bool, error) {
+	info, err := c.client.Info(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get daemon info: %w", err)
+	}
+	return info.BuilderVersion == string(build.BuilderBuildKit), nil
+}
+
+// hasContainerdImageStore reports whether the daemon stores images through
+// containerd's snapshotter rather than the classic graphdriver, which a
+// Platforms build needs to hold more than one platform's layers under a
+// single image reference.
+func (c *Client) hasContainerdImageStore(ctx context.Context) (bool, error) {
+	info, err := c.client.Info(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get daemon info: %w", err)
+	}
+	for _, status := range info.DriverStatus {
+		if len(status) == 2 && status[0] == containerdSnapshotterDriver && status[1] == "io.containerd.snapshotter.v1" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// toDockerBuildArgs converts BuildOptions.BuildArgs into the
+// map[string]*string shape the Docker SDK's ImageBuildOptions.BuildArgs expects.
+func toDockerBuildArgs(buildArgs map[string]string) map[string]*string {
+	if len(buildArgs) == 0 {
+		return nil
+	}
+
+	args := make(map[string]*string, len(buildArgs))
+	for k, v := range buildArgs {
+		value := v
+		args[k] = &value
+	}
+	return args
+}
+
+// createTarFromDir creates a tar archive from a directory
+func createTarFromDir(srcDir string, writer io.Writer) error {
+	// Create a new tar writer
+	tw := tar.NewWriter(writer)
+	defer tw.Close()
+
+	// Walk through the directory and add files to the tar archive
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Get the relative path
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+
+		// Skip the root directory
+		if relPath == "." {
+			return nil
+		}
+
+		// Create a tar header
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return fmt.Errorf("failed to create tar header: %w", err)
+		}
+
+		// Set the name to the relative path
+		header.Name = relPath
+
+		// Write the header
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write tar header: %w", err)
+		}
+
+		// If it's a regular file, write the contents
+		if !info.IsDir() {
+			// #nosec G304 - This is safe because we're only opening files within the specified context directory
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("failed to open file: %w", err)
+			}
+			defer file.Close()
+
+			if _, err := io.Copy(tw, file); err != nil {
+				return fmt.Errorf("failed to copy file contents: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// buildKitVertex is one step of BuildKit's build step graph, reported on
+// its own JSON lines (distinct from the classic "stream"/"error" messages)
+// when building with Version: build.BuilderBuildKit.
+type buildKitVertex struct {
+	Digest    string `json:"digest"`
+	Name      string `json:"name"`
+	Cached    bool   `json:"cached,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Completed bool   `json:"completed,omitempty"`
+}
+
+// parseBuildOutput parses the Docker image build output -- classic
+// "stream"/"error" messages, the "aux" message BuildKit reports the
+// resulting image ID in, and BuildKit's "vertexes" step-graph progress
+// messages -- and formats it in a more readable way.
+func parseBuildOutput(reader io.Reader, writer io.Writer) (string, error) {
+	var imageID string
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var buildOutput struct {
+			Stream string `json:"stream,omitempty"`
+			Error  string `json:"error,omitempty"`
+			Aux    *struct {
+				ID string `json:"ID"`
+			} `json:"aux,omitempty"`
+			Vertexes []buildKitVertex `json:"vertexes,omitempty"`
+		}
+
+		if err := decoder.Decode(&buildOutput); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", fmt.Errorf("failed to decode build output: %w", err)
+		}
+
+		// Check for errors
+		if buildOutput.Error != "" {
+			return "", fmt.Errorf("build error: %s", buildOutput.Error)
+		}
+
+		// Print the stream output
+		if buildOutput.Stream != "" {
+			fmt.Fprint(writer, buildOutput.Stream)
+		}
+
+		for _, v := range buildOutput.Vertexes {
+			if v.Error != "" {
+				return "", fmt.Errorf("build step %q failed: %s", v.Name, v.Error)
+			}
+			switch {
+			case v.Cached:
+				fmt.Fprintf(writer, "[build] CACHED %s\n", v.Name)
+			case v.Completed:
+				fmt.Fprintf(writer, "[build] DONE %s\n", v.Name)
+			default:
+				fmt.Fprintf(writer, "[build] %s\n", v.Name)
+			}
+		}
+
+		// BuildKit reports the resulting image ID via the "aux" message
+		if buildOutput.Aux != nil && buildOutput.Aux.ID != "" {
+			imageID = buildOutput.Aux.ID
+		}
+	}
+
+	return imageID, nil
+}
@@ -0,0 +1,86 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/container-orchestrated-devices/container-device-interface/pkg/cdi"
+	ocispecs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// cdiSpecDirs are the standard locations CDI spec files are read from; see
+// https://github.com/container-orchestrated-devices/container-device-interface.
+var cdiSpecDirs = []string{"/etc/cdi", "/var/run/cdi"}
+
+// resolvedCDIDevices is what resolveCDIDevices extracts from a CDI spec for
+// a set of requested device names -- the pieces a Docker HostConfig/Config
+// can actually express.
+type resolvedCDIDevices struct {
+	// Devices are the host device nodes to bind into the container.
+	Devices []container.DeviceMapping
+	// Env are the "KEY=value" environment variables the CDI spec requires
+	// (e.g. NVIDIA_VISIBLE_DEVICES).
+	Env []string
+}
+
+// resolveCDIDevices resolves deviceNames (e.g. "nvidia.com/gpu=all",
+// "vendor.com/fuse=default") against the CDI spec files under cdiSpecDirs,
+// returning the device nodes and environment variables they inject.
+//
+// If the CDI registry can't be loaded at all (no spec files, no CDI on this
+// host), this returns a zero value rather than an error so permission
+// profiles that don't request devices keep working unchanged. An unknown
+// device name, once the registry has loaded successfully, is a hard error.
+func resolveCDIDevices(deviceNames []string) (resolvedCDIDevices, error) {
+	if len(deviceNames) == 0 {
+		return resolvedCDIDevices{}, nil
+	}
+
+	registry := cdi.GetRegistry(cdi.WithSpecDirs(cdiSpecDirs...))
+	if err := registry.Refresh(); err != nil {
+		logger.Warnf("CDI unavailable, devices %v will not be injected: %v", deviceNames, err)
+		return resolvedCDIDevices{}, nil
+	}
+
+	spec := &ocispecs.Spec{
+		Process: &ocispecs.Process{},
+		Linux:   &ocispecs.Linux{},
+	}
+
+	unresolved, err := registry.InjectDevices(spec, deviceNames...)
+	if err != nil {
+		return resolvedCDIDevices{}, fmt.Errorf("failed to resolve CDI devices %v: %w", deviceNames, err)
+	}
+	if len(unresolved) > 0 {
+		return resolvedCDIDevices{}, fmt.Errorf("unknown CDI device kind(s): %s", strings.Join(unresolved, ", "))
+	}
+
+	result := resolvedCDIDevices{}
+	if spec.Process != nil {
+		result.Env = spec.Process.Env
+	}
+	if spec.Linux != nil {
+		for _, dev := range spec.Linux.Devices {
+			result.Devices = append(result.Devices, container.DeviceMapping{
+				PathOnHost:        dev.Path,
+				PathInContainer:   dev.Path,
+				CgroupPermissions: "rwm",
+			})
+		}
+	}
+
+	if spec.Hooks != nil && (len(spec.Hooks.Prestart) > 0 || len(spec.Hooks.CreateRuntime) > 0 ||
+		len(spec.Hooks.StartContainer) > 0 || len(spec.Hooks.Poststart) > 0) {
+		// Docker's HostConfig/Config have no way to carry OCI lifecycle hooks
+		// through, so a CDI device whose spec depends on one (uncommon for
+		// GPU/FUSE specs, but allowed by the spec format) won't be fully
+		// applied here.
+		logger.Warnf("CDI device(s) %v declare OCI hooks, which this runtime cannot run; they will be skipped", deviceNames)
+	}
+
+	return result, nil
+}
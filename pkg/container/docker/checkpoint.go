@@ -0,0 +1,378 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/checkpoint"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	dockerimage "github.com/docker/docker/api/types/image"
+
+	"github.com/stacklok/toolhive/pkg/container/docker/netdriver"
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// checkpointManifestFile is the name of the JSON sidecar a checkpoint
+// tarball carries alongside its CRIU image directory, recording enough
+// about the workload at checkpoint time for RestoreWorkload to refuse a
+// restore against an incompatible workload rather than handing CRIU a
+// checkpoint it can't safely apply.
+const checkpointManifestFile = "manifest.json"
+
+// checkpointImageDir is the suffix of the local staging directory
+// ("<name>.criu") that CheckpointCreate writes the CRIU image files (memory
+// pages, open file descriptor state, network state) into before they are
+// archived; inside the tarball those files sit under "<name>/".
+const checkpointImageDir = "criu"
+
+// checkpointManifest is the JSON sidecar written alongside a checkpoint's
+// CRIU image directory, letting RestoreWorkload verify compatibility before
+// it spends time recreating a workload's sidecars.
+type checkpointManifest struct {
+	// ImageDigest is the resolved digest of the image the workload was
+	// running when it was checkpointed.
+	ImageDigest string `json:"imageDigest"`
+	// Env is the workload's environment at checkpoint time.
+	Env map[string]string `json:"env"`
+	// Mounts are the workload's mount sources and targets, rendered as
+	// "source:target", for operator inspection rather than restore logic.
+	Mounts []string `json:"mounts"`
+	// PermissionProfileHash is a caller-supplied hash of the permission
+	// profile the workload was deployed with, so a restore against a
+	// different profile is rejected rather than silently applied.
+	PermissionProfileHash string `json:"permissionProfileHash"`
+	// CreatedAt is when the checkpoint was taken.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// checkpointStateDir is the root directory per-workload checkpoint
+// tarballs are written under.
+func checkpointStateDir(workloadName string) string {
+	return filepath.Join(toolhiveStateDir("checkpoints"), workloadName)
+}
+
+// CheckpointWorkload freezes workloadID's running state -- memory, open file
+// descriptors, and network state -- into a CRIU checkpoint, via the Docker
+// experimental checkpoint API where the daemon supports it. The checkpoint
+// is packed into a tarball under
+// "${XDG_STATE_HOME}/toolhive/checkpoints/<workload>/<name>.tar" alongside a
+// manifest capturing the workload's image digest, env, mounts, and
+// permission-profile hash, and the tarball path is returned as the
+// checkpoint's reference for a later RestoreWorkload call.
+func (c *Client) CheckpointWorkload(
+	ctx context.Context, workloadID string, opts runtime.CheckpointOptions,
+) (string, error) {
+	inspect, err := c.client.ContainerInspect(ctx, workloadID)
+	if err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to inspect workload: %v", err))
+	}
+
+	digest, err := c.resolveImageDigest(ctx, inspect.Config.Image)
+	if err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to resolve image digest: %v", err))
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = fmt.Sprintf("checkpoint-%d", time.Now().Unix())
+	}
+
+	root := checkpointStateDir(strings.TrimPrefix(inspect.Name, "/"))
+	imageDir := filepath.Join(root, name+"."+checkpointImageDir)
+	tarPath := filepath.Join(root, name+".tar")
+
+	if err := os.MkdirAll(imageDir, 0o750); err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to create checkpoint directory %q: %v", imageDir, err))
+	}
+	defer os.RemoveAll(imageDir)
+
+	if err := c.client.CheckpointCreate(ctx, workloadID, checkpoint.CreateOptions{
+		CheckpointID:  name,
+		CheckpointDir: imageDir,
+		Exit:          opts.StopAfter,
+	}); err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to checkpoint workload: %v", err))
+	}
+
+	manifest := checkpointManifest{
+		ImageDigest:           digest,
+		Env:                   envSliceToMap(inspect.Config.Env),
+		Mounts:                mountStrings(inspect.Mounts),
+		PermissionProfileHash: opts.PermissionProfileHash,
+		CreatedAt:             time.Now(),
+	}
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to marshal checkpoint manifest: %v", err))
+	}
+	if err := os.WriteFile(filepath.Join(imageDir, checkpointManifestFile), manifestBytes, 0o640); err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to write checkpoint manifest: %v", err))
+	}
+
+	if err := tarDirectory(imageDir, tarPath); err != nil {
+		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to archive checkpoint: %v", err))
+	}
+
+	logger.Infof("Checkpointed workload %s to %s", workloadID, tarPath)
+	return tarPath, nil
+}
+
+// RestoreWorkload recreates a workload from a checkpoint produced by
+// CheckpointWorkload. It first verifies the checkpoint's manifest against
+// opts -- a stale image digest or permission-profile hash fails fast with
+// ErrCheckpointIncompatible rather than handing CRIU a checkpoint it can't
+// safely apply -- then recreates the workload's network, egress, and DNS
+// sidecars exactly as DeployWorkload would, and finally hands the
+// checkpoint's CRIU image to the runtime to resume from instead of cold
+// starting the container.
+func (c *Client) RestoreWorkload(
+	ctx context.Context, checkpointRef string, opts runtime.RestoreOptions,
+) (string, error) {
+	imageDir, manifest, err := extractCheckpointTar(checkpointRef)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checkpoint %q: %w", checkpointRef, err)
+	}
+	defer os.RemoveAll(imageDir)
+
+	digest, err := c.resolveImageDigest(ctx, opts.Image)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve image digest for %q: %w", opts.Image, err)
+	}
+	if digest != manifest.ImageDigest {
+		return "", fmt.Errorf("%w: checkpoint image %s does not match %s (%s)",
+			ErrCheckpointIncompatible, manifest.ImageDigest, opts.Image, digest)
+	}
+	if opts.PermissionProfileHash != manifest.PermissionProfileHash {
+		return "", fmt.Errorf("%w: checkpoint permission profile hash %s does not match %s",
+			ErrCheckpointIncompatible, manifest.PermissionProfileHash, opts.PermissionProfileHash)
+	}
+
+	attachStdio := opts.DeployOptions == nil || opts.DeployOptions.AttachStdio
+	networkDriver := c.networkDriverFor(opts.PermissionProfile.Network)
+
+	// A restored workload is always an MCP workload and always rejoins a
+	// pod, the same as DeployWorkload's isMcpWorkload branch: the infra
+	// container owns the shared network namespace, so the workload carries
+	// no network endpoints of its own.
+	if _, err := networkDriver.EnsureNetwork(ctx, netdriver.EndpointConfig{NetworkName: "toolhive-external"}); err != nil {
+		logger.Warnf("failed to create external network %q: %v", "toolhive-external", err)
+	}
+	var portBindings map[string][]runtime.PortBinding
+	var exposedPorts map[string]struct{}
+	if opts.DeployOptions != nil {
+		portBindings = opts.DeployOptions.PortBindings
+		exposedPorts = opts.DeployOptions.ExposedPorts
+	}
+	infraContainerID, err := c.createPodInfraContainer(ctx, networkDriver, opts.Name, portBindings, exposedPorts, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pod infra container: %w", err)
+	}
+
+	egressContainerName := fmt.Sprintf("%s-egress", opts.Name)
+	dnsContainerName := fmt.Sprintf("%s-dns", opts.Name)
+	if err := c.createEgressContainers(ctx, infraContainerID, egressContainerName, dnsContainerName,
+		attachStdio, opts.PermissionProfile.Network, portBindings, exposedPorts); err != nil {
+		return "", fmt.Errorf("failed to create egress container: %w", err)
+	}
+
+	config := &container.Config{
+		Image:        opts.Image,
+		Cmd:          opts.Command,
+		Env:          convertEnvVars(opts.EnvVars),
+		Labels:       opts.Labels,
+		AttachStdin:  attachStdio,
+		AttachStdout: attachStdio,
+		AttachStderr: attachStdio,
+		OpenStdin:    attachStdio,
+	}
+	hostConfig := &container.HostConfig{
+		NetworkMode:   sharedNetnsHostConfig(infraContainerID),
+		DNS:           []string{"127.0.0.1"},
+		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+	}
+
+	// Created but not started, so the checkpoint start below is the
+	// container's first start.
+	created, err := c.client.ContainerCreate(ctx, config, hostConfig, nil, nil, opts.Name)
+	if err != nil {
+		return "", NewContainerError(err, "", fmt.Sprintf("failed to create container: %v", err))
+	}
+	containerID := created.ID
+
+	if err := c.client.ContainerStart(ctx, containerID, container.StartOptions{
+		CheckpointID:  strings.TrimSuffix(filepath.Base(checkpointRef), ".tar"),
+		CheckpointDir: imageDir,
+	}); err != nil {
+		return "", NewContainerError(err, containerID, fmt.Sprintf("failed to restore workload from checkpoint: %v", err))
+	}
+
+	logger.Infof("Restored workload %s from checkpoint %s", containerID, checkpointRef)
+	return containerID, nil
+}
+
+// resolveImageDigest returns the repo digest Docker has recorded for image,
+// falling back to its image ID when no repo digest is available (e.g. a
+// locally built image that was never pulled from a registry).
+func (c *Client) resolveImageDigest(ctx context.Context, image string) (string, error) {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("reference", image)
+
+	images, err := c.client.ImageList(ctx, dockerimage.ListOptions{Filters: filterArgs})
+	if err != nil {
+		return "", fmt.Errorf("failed to list images: %w", err)
+	}
+	if len(images) == 0 {
+		return "", fmt.Errorf("image %q not found", image)
+	}
+	if len(images[0].RepoDigests) > 0 {
+		return images[0].RepoDigests[0], nil
+	}
+	return images[0].ID, nil
+}
+
+// mountStrings renders a container's mount points as "source:target" for
+// the checkpoint manifest.
+func mountStrings(mounts []container.MountPoint) []string {
+	result := make([]string, 0, len(mounts))
+	for _, m := range mounts {
+		result = append(result, fmt.Sprintf("%s:%s", m.Source, m.Destination))
+	}
+	return result
+}
+
+// tarDirectory archives dir's contents into a tarball at tarPath.
+func tarDirectory(dir, tarPath string) error {
+	out, err := os.OpenFile(tarPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", tarPath, err)
+	}
+	defer out.Close()
+
+	tw := tar.NewWriter(out)
+	defer tw.Close()
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		if rel == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return fmt.Errorf("failed to create tar header for %q: %w", path, err)
+		}
+		header.Name = rel
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write tar header for %q: %w", rel, err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		// #nosec G304 - path is walked from the checkpoint directory we just created
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %q: %w", path, err)
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	})
+}
+
+// extractCheckpointTar extracts checkpointRef's CRIU image directory to a
+// temporary directory under the same checkpoints state root and parses its
+// manifest, returning the extracted directory so the caller can hand it
+// straight to ContainerStart's CheckpointDir.
+func extractCheckpointTar(checkpointRef string) (string, checkpointManifest, error) {
+	in, err := os.Open(checkpointRef) // #nosec G304 - checkpointRef is operator-supplied, not web input
+	if err != nil {
+		return "", checkpointManifest{}, fmt.Errorf("failed to open checkpoint tarball: %w", err)
+	}
+	defer in.Close()
+
+	destRoot := filepath.Join(filepath.Dir(checkpointRef), fmt.Sprintf("restore-%d", time.Now().UnixNano()))
+	if err := os.MkdirAll(destRoot, 0o750); err != nil {
+		return "", checkpointManifest{}, fmt.Errorf("failed to create restore directory %q: %w", destRoot, err)
+	}
+
+	tr := tar.NewReader(in)
+	var manifest checkpointManifest
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", checkpointManifest{}, fmt.Errorf("failed to read checkpoint tar entry: %w", err)
+		}
+
+		dest := filepath.Join(destRoot, filepath.Clean(header.Name)) //nolint:gosec // escape-checked below
+		if dest != destRoot && !hasPathPrefix(dest, destRoot) {
+			return "", checkpointManifest{}, fmt.Errorf("checkpoint tar entry %q escapes restore directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0o750); err != nil {
+				return "", checkpointManifest{}, fmt.Errorf("failed to create directory %q: %w", dest, err)
+			}
+		case tar.TypeReg:
+			if err := extractCheckpointFile(tr, dest, header); err != nil {
+				return "", checkpointManifest{}, err
+			}
+			if header.Name == checkpointManifestFile {
+				data, err := os.ReadFile(dest) // #nosec G304 - dest was just written by extractCheckpointFile above
+				if err != nil {
+					return "", checkpointManifest{}, fmt.Errorf("failed to read checkpoint manifest: %w", err)
+				}
+				if err := json.Unmarshal(data, &manifest); err != nil {
+					return "", checkpointManifest{}, fmt.Errorf("failed to parse checkpoint manifest: %w", err)
+				}
+			}
+		}
+	}
+
+	return destRoot, manifest, nil
+}
+
+// extractCheckpointFile writes a single regular-file tar entry to dest.
+func extractCheckpointFile(tr *tar.Reader, dest string, header *tar.Header) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %q: %w", dest, err)
+	}
+
+	// #nosec G304 - dest is validated in extractCheckpointTar before we get here
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", dest, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, tr); err != nil {
+		return fmt.Errorf("failed to write %q: %w", dest, err)
+	}
+	return nil
+}
+
+// hasPathPrefix reports whether dest is root or a descendant of root.
+func hasPathPrefix(dest, root string) bool {
+	return dest == root || len(dest) > len(root) && dest[:len(root)+1] == root+string(filepath.Separator)
+}
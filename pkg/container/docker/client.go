@@ -3,9 +3,10 @@
 package docker
 
 import (
-	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,16 +16,21 @@ import (
 	"time"
 
 	"github.com/containerd/errdefs"
-	"github.com/docker/docker/api/types/build"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	dockerimage "github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/go-connections/nat"
 
+	"github.com/stacklok/toolhive/pkg/container/docker/netdriver"
+	"github.com/stacklok/toolhive/pkg/container/kubernetes"
+	"github.com/stacklok/toolhive/pkg/container/podman"
 	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/container/runtime/portallocator"
 	"github.com/stacklok/toolhive/pkg/container/verifier"
 	lb "github.com/stacklok/toolhive/pkg/labels"
 	"github.com/stacklok/toolhive/pkg/logger"
@@ -58,6 +64,12 @@ const EgressImage = "ubuntu/squid:latest"
 // DnsImage is the default DNS image used for network permissions
 const DnsImage = "dockurr/dnsmasq:latest"
 
+// PauseImage is the minimal "infra" container a workload's pod is built
+// around: it holds the shared network namespace that the workload, its
+// egress container, and its DNS container all join, the same role
+// Kubernetes' pause container plays for a pod's sandbox.
+const PauseImage = "registry.k8s.io/pause:3.9"
+
 var supportedSocketPaths = []runtime.Type{runtime.TypePodman, runtime.TypeDocker}
 
 // Client implements the Runtime interface for container operations
@@ -67,6 +79,8 @@ type Client struct {
 	client      *client.Client
 }
 
+var _ runtime.Backend = (*Client)(nil)
+
 // NewClient creates a new container client
 func NewClient(ctx context.Context) (*Client, error) {
 	var lastErr error
@@ -101,6 +115,98 @@ func NewClient(ctx context.Context) (*Client, error) {
 	return nil, fmt.Errorf("no supported container runtime found/running")
 }
 
+// RuntimeEnvVar forces NewBackend to use a specific runtime ("docker",
+// "podman", or "kubernetes") instead of probing for whichever is available,
+// for operators who run both daemons side by side and want toolhive pinned
+// to one.
+const RuntimeEnvVar = "TOOLHIVE_RUNTIME"
+
+// inClusterServiceAccountPath is where every Kubernetes Pod's projected
+// ServiceAccount is mounted; its presence is the standard way client-go
+// programs detect that they're running inside a cluster rather than being
+// pointed at one via a kubeconfig.
+const inClusterServiceAccountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// NewBackend detects whichever container runtime is available and returns
+// the runtime.Backend for it: the Kubernetes backend when an in-cluster
+// ServiceAccount is detected (RuntimeEnvVar overrides always take priority),
+// otherwise a libpod-native podman.Client when Podman is found, or the
+// Docker-compat Client otherwise. Unlike NewClient, which always returns the
+// Docker-compat Client regardless of what was detected (forcing Podman
+// through its compat shim and losing libpod-only features like real pods and
+// generate-kube), NewBackend routes Podman through its own backend. Callers
+// that specifically need the Docker-compat client can still use
+// NewClient/NewClientWithSocketPath directly.
+func NewBackend(ctx context.Context) (runtime.Backend, error) {
+	if forced := os.Getenv(RuntimeEnvVar); forced != "" {
+		return newForcedBackend(ctx, forced)
+	}
+
+	if _, err := os.Stat(inClusterServiceAccountPath); err == nil {
+		return kubernetes.NewClient(ctx)
+	}
+
+	var lastErr error
+
+	for _, sp := range supportedSocketPaths {
+		socketPath, runtimeType, err := findContainerSocket(sp)
+		if err != nil {
+			logger.Debugf("Failed to find socket for %s: %v", sp, err)
+			lastErr = err
+			continue
+		}
+
+		if runtimeType == runtime.TypePodman {
+			backend, err := podman.NewClient(ctx, socketPath)
+			if err != nil {
+				lastErr = err
+				logger.Debugf("Failed to create podman backend for %s: %v", socketPath, err)
+				continue
+			}
+			return backend, nil
+		}
+
+		c, err := NewClientWithSocketPath(ctx, socketPath, runtimeType)
+		if err != nil {
+			lastErr = err
+			logger.Debugf("Failed to create client for %s: %v", sp, err)
+			continue
+		}
+		return c, nil
+	}
+
+	if lastErr != nil {
+		return nil, fmt.Errorf("no supported container runtime available: %w", lastErr)
+	}
+	return nil, fmt.Errorf("no supported container runtime found/running")
+}
+
+// newForcedBackend builds the runtime.Backend for whichever runtime
+// RuntimeEnvVar names, skipping auto-detection of the other.
+func newForcedBackend(ctx context.Context, forced string) (runtime.Backend, error) {
+	var runtimeType runtime.Type
+	switch strings.ToLower(forced) {
+	case "docker":
+		runtimeType = runtime.TypeDocker
+	case "podman":
+		runtimeType = runtime.TypePodman
+	case "kubernetes":
+		return kubernetes.NewClient(ctx)
+	default:
+		return nil, fmt.Errorf("invalid %s value %q: must be \"docker\", \"podman\", or \"kubernetes\"", RuntimeEnvVar, forced)
+	}
+
+	socketPath, _, err := findContainerSocket(runtimeType)
+	if err != nil {
+		return nil, fmt.Errorf("%s=%s requested but no %s socket was found: %w", RuntimeEnvVar, forced, forced, err)
+	}
+
+	if runtimeType == runtime.TypePodman {
+		return podman.NewClient(ctx, socketPath)
+	}
+	return NewClientWithSocketPath(ctx, socketPath, runtimeType)
+}
+
 // NewClientWithSocketPath creates a new container client with a specific socket path
 func NewClientWithSocketPath(ctx context.Context, socketPath string, runtimeType runtime.Type) (*Client, error) {
 	// Create platform-specific client
@@ -124,9 +230,34 @@ func NewClientWithSocketPath(ctx context.Context, socketPath string, runtimeType
 	}
 	logger.Debugf("Successfully connected to %s runtime", c.runtimeType)
 
+	c.reconcilePortAllocations(ctx)
+
 	return c, nil
 }
 
+// reconcilePortAllocations seeds the process-wide port allocator with ports
+// already bound by existing toolhive containers, so a restart doesn't hand
+// out an ephemeral port a still-running container already holds.
+func (c *Client) reconcilePortAllocations(ctx context.Context) {
+	containers, err := c.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "toolhive=true")),
+	})
+	if err != nil {
+		logger.Warnf("failed to reconcile port allocations: %v", err)
+		return
+	}
+
+	for _, cn := range containers {
+		for _, p := range cn.Ports {
+			if p.PublicPort == 0 {
+				continue
+			}
+			portallocator.Default.ReserveExisting(p.IP, p.Type, int(p.PublicPort))
+		}
+	}
+}
+
 // ping checks if the container runtime is available
 func (c *Client) ping(ctx context.Context) error {
 	_, err := c.client.Ping(ctx)
@@ -142,6 +273,51 @@ func findContainerSocket(rt runtime.Type) (string, runtime.Type, error) {
 	return findPlatformContainerSocket(rt)
 }
 
+// networkDriverFor selects the netdriver.Driver a workload's network
+// permissions ask for, defaulting to the bridge driver that preserves
+// toolhive's original hardcoded topology when perm is nil or doesn't
+// request anything else.
+func (c *Client) networkDriverFor(perm *permissions.NetworkPermissions) netdriver.Driver {
+	if perm == nil {
+		return netdriver.NewBridgeDriver(c.client)
+	}
+
+	switch perm.Driver {
+	case "", "bridge":
+		return netdriver.NewBridgeDriver(c.client)
+	case "macvlan":
+		return netdriver.NewMacvlanDriver(c.client)
+	case "cni":
+		return netdriver.NewCNIDriver(c.client, perm.CNIPluginPath, perm.CNIType, perm.CNIPath)
+	case "host":
+		return netdriver.NewHostDriver(c.client)
+	case "none":
+		return netdriver.NewNoneDriver(c.client)
+	default:
+		logger.Warnf("unknown network driver %q, falling back to bridge", perm.Driver)
+		return netdriver.NewBridgeDriver(c.client)
+	}
+}
+
+// validateHostOrNoneNetwork rejects permission profiles that ask for host or
+// none networking while also declaring outbound rules toolhive can't
+// enforce: host networking has no pod/egress proxy to apply AllowHost/
+// AllowPort against, and none networking has no network at all for them to
+// apply to. Silently ignoring those rules would make the profile look more
+// restrictive than the workload actually is.
+func validateHostOrNoneNetwork(driverName string, perm *permissions.NetworkPermissions) error {
+	if perm == nil || perm.Outbound == nil {
+		return nil
+	}
+	if perm.Outbound.InsecureAllowAll && len(perm.Outbound.AllowHost) == 0 && len(perm.Outbound.AllowPort) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"network driver %q cannot enforce outbound AllowHost/AllowPort restrictions; "+
+			"remove them or set InsecureAllowAll", driverName,
+	)
+}
+
 // CreateContainer creates a container without starting it
 // If options is nil, default options will be used
 // convertEnvVars converts a map of environment variables to a slice
@@ -185,7 +361,11 @@ func setupExposedPorts(config *container.Config, exposedPorts map[string]struct{
 	return nil
 }
 
-// setupPortBindings configures port bindings for a container
+// setupPortBindings configures port bindings for a container. A HostPort of
+// "" or "0" is resolved through the process-wide portallocator instead of
+// being passed through to the kernel, so two concurrent DeployWorkload
+// calls can't both be handed the same ephemeral port before either has
+// actually bound it.
 func setupPortBindings(hostConfig *container.HostConfig, portBindings map[string][]runtime.PortBinding) error {
 	if len(portBindings) == 0 {
 		return nil
@@ -200,9 +380,22 @@ func setupPortBindings(hostConfig *container.HostConfig, portBindings map[string
 
 		natBindings := make([]nat.PortBinding, len(bindings))
 		for i, binding := range bindings {
+			requestedPort := 0
+			if binding.HostPort != "" && binding.HostPort != "0" {
+				requestedPort, err = strconv.Atoi(binding.HostPort)
+				if err != nil {
+					return fmt.Errorf("invalid host port %q: %v", binding.HostPort, err)
+				}
+			}
+
+			reservedPort, err := portallocator.Default.RequestPort(binding.HostIP, natPort.Proto(), requestedPort)
+			if err != nil {
+				return fmt.Errorf("failed to reserve host port for %s: %w", port, err)
+			}
+
 			natBindings[i] = nat.PortBinding{
 				HostIP:   binding.HostIP,
-				HostPort: binding.HostPort,
+				HostPort: strconv.Itoa(reservedPort),
 			}
 		}
 		hostConfig.PortBindings[natPort] = natBindings
@@ -211,6 +404,96 @@ func setupPortBindings(hostConfig *container.HostConfig, portBindings map[string
 	return nil
 }
 
+// convertHealthcheck translates a runtime.Healthcheck into Docker's
+// container.HealthConfig (Podman's libpod-compatible create endpoint
+// accepts the same shape).
+func convertHealthcheck(hc *runtime.Healthcheck) *container.HealthConfig {
+	return &container.HealthConfig{
+		Test:        hc.Test,
+		Interval:    hc.Interval,
+		Timeout:     hc.Timeout,
+		StartPeriod: hc.StartPeriod,
+		Retries:     hc.Retries,
+	}
+}
+
+const (
+	defaultHealthcheckInterval = 10 * time.Second
+	defaultHealthcheckTimeout  = 3 * time.Second
+	defaultHealthcheckRetries  = 3
+)
+
+// defaultHealthcheck builds a HealthConfig appropriate for transportType
+// when the caller hasn't supplied an explicit runtime.Healthcheck: an HTTP
+// GET against the workload's first exposed port for "sse" (where the MCP
+// server listens over HTTP), and a pgrep of the entrypoint for "stdio"
+// (where there's no port to probe, only a process to confirm is alive). It
+// returns nil -- no healthcheck configured -- when there's nothing sensible
+// to probe.
+func defaultHealthcheck(transportType string, command []string, exposedPorts map[string]struct{}) *container.HealthConfig {
+	switch transportType {
+	case "sse":
+		port := firstExposedPort(exposedPorts)
+		if port == "" {
+			return nil
+		}
+		return &container.HealthConfig{
+			Test:     []string{"CMD-SHELL", fmt.Sprintf("curl -sf http://localhost:%s/health || exit 1", port)},
+			Interval: defaultHealthcheckInterval,
+			Timeout:  defaultHealthcheckTimeout,
+			Retries:  defaultHealthcheckRetries,
+		}
+	case "stdio":
+		if len(command) == 0 {
+			return nil
+		}
+		return &container.HealthConfig{
+			Test:     []string{"CMD-SHELL", fmt.Sprintf("pgrep -f %q || exit 1", command[0])},
+			Interval: defaultHealthcheckInterval,
+			Timeout:  defaultHealthcheckTimeout,
+			Retries:  defaultHealthcheckRetries,
+		}
+	default:
+		return nil
+	}
+}
+
+// firstExposedPort returns the container port number (without its "/tcp"
+// protocol suffix) from exposedPorts, or "" if there are none. Map
+// iteration order is unspecified, so this is only meaningful when a
+// workload exposes a single port -- true of every current MCP transport.
+func firstExposedPort(exposedPorts map[string]struct{}) string {
+	for port := range exposedPorts {
+		return strings.Split(port, "/")[0]
+	}
+	return ""
+}
+
+// pidsLimitPtr returns a pointer to limit for container.Resources.PidsLimit,
+// or nil if limit is 0 -- Docker's own sentinel for "no limit" on this field,
+// matching how a zero-value runtime.Resources leaves every limit unset.
+func pidsLimitPtr(limit int64) *int64 {
+	if limit == 0 {
+		return nil
+	}
+	return &limit
+}
+
+// releasePortBindings releases every host port reserved in bindings back to
+// the process-wide portallocator, called when a container carrying those
+// bindings is removed.
+func releasePortBindings(bindings nat.PortMap) {
+	for natPort, portBindings := range bindings {
+		for _, binding := range portBindings {
+			hostPort, err := strconv.Atoi(binding.HostPort)
+			if err != nil {
+				continue
+			}
+			portallocator.Default.ReleasePort(binding.HostIP, natPort.Proto(), hostPort)
+		}
+	}
+}
+
 func writeOutboundACLs(sb *strings.Builder, outbound *permissions.OutboundNetworkPermissions) {
 	if len(outbound.AllowPort) > 0 {
 		sb.WriteString("# Define allowed ports\nacl allowed_ports port")
@@ -276,7 +559,10 @@ func createTempSquidConf(
 	var sb strings.Builder
 
 	sb.WriteString(
-		"http_port 3128\n" +
+		// Bound to loopback only: Squid now lives in a netns shared with just
+		// its own workload (see the pod-style infra container in
+		// createPodInfraContainer), so no other workload can ever reach it.
+		"http_port 127.0.0.1:3128\n" +
 			"visible_hostname " + serverHostname + "-egress\n" +
 			"access_log stdio:/var/log/squid/access.log squid\n" +
 			"pid_filename /var/run/squid/squid.pid\n" +
@@ -313,8 +599,75 @@ func createTempSquidConf(
 	return tmpFile.Name(), nil
 }
 
+// upstreamDNSServer is the resolver dnsmasq forwards allowed lookups to.
+const upstreamDNSServer = "8.8.8.8"
+
+// createTempDnsmasqConf generates a dnsmasq config enforcing
+// networkPermissions.Outbound.AllowHost at the DNS layer: every domain not
+// explicitly allowed resolves to 0.0.0.0, so a workload that opens a raw
+// socket to bypass HTTP_PROXY still can't resolve a disallowed hostname in
+// the first place. This is defense-in-depth alongside Squid's dstdomain ACL,
+// which only ever sees HTTP(S) traffic.
+func createTempDnsmasqConf(networkPermissions *permissions.NetworkPermissions) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(
+		"# Don't read /etc/hosts or /etc/resolv.conf; only resolve what's configured below\n" +
+			"no-hosts\n" +
+			"no-resolv\n")
+
+	if networkPermissions == nil || (networkPermissions.Outbound != nil && networkPermissions.Outbound.InsecureAllowAll) {
+		sb.WriteString("# Allow all domains\nserver=" + upstreamDNSServer + "\n")
+	} else {
+		sb.WriteString("# Deny all domains by default\naddress=/#/0.0.0.0\n")
+		if networkPermissions.Outbound != nil {
+			for _, host := range networkPermissions.Outbound.AllowHost {
+				sb.WriteString("# Allow " + host + "\nserver=/" + host + "/" + upstreamDNSServer + "\n")
+			}
+		}
+	}
+
+	tmpFile, err := os.CreateTemp("", "dnsmasq-*.conf")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.WriteString(sb.String()); err != nil {
+		return "", fmt.Errorf("failed to write to temporary file: %v", err)
+	}
+
+	return tmpFile.Name(), nil
+}
+
+// createContainer creates containerName with the default ReuseIfMatches
+// policy. Most callers -- sidecars in particular, which have no reuse
+// preference of their own -- go through this.
 func (c *Client) createContainer(ctx context.Context, containerName string, config *container.Config,
 	hostConfig *container.HostConfig, endpointsConfig map[string]*network.EndpointSettings) (string, error) {
+	return c.createContainerWithReuse(ctx, containerName, config, hostConfig, endpointsConfig, runtime.ReuseIfMatches)
+}
+
+// createContainerWithReuse is createContainer with an explicit ReusePolicy,
+// for DeployWorkload to plumb through the caller's options.Reuse.
+//
+// Before creating (or reusing) the container, it stamps config.Labels with
+// ConfigHashLabel, a canonical hash of config+hostConfig, so a later call
+// with the same containerName can tell whether the existing container still
+// matches the desired spec without deep comparing every field Docker
+// reports back.
+func (c *Client) createContainerWithReuse(ctx context.Context, containerName string, config *container.Config,
+	hostConfig *container.HostConfig, endpointsConfig map[string]*network.EndpointSettings,
+	reuse runtime.ReusePolicy) (string, error) {
+	hash, err := computeConfigHash(config, hostConfig)
+	if err != nil {
+		return "", NewContainerError(err, "", fmt.Sprintf("failed to compute config hash: %v", err))
+	}
+	if config.Labels == nil {
+		config.Labels = map[string]string{}
+	}
+	config.Labels[ConfigHashLabel] = hash
+
 	existingID, err := c.findExistingContainer(ctx, containerName)
 	if err != nil {
 		return "", err
@@ -322,7 +675,7 @@ func (c *Client) createContainer(ctx context.Context, containerName string, conf
 
 	// If container exists, check if we need to recreate it
 	if existingID != "" {
-		canReuse, err := c.handleExistingContainer(ctx, existingID, config, hostConfig)
+		canReuse, err := c.handleExistingContainer(ctx, existingID, config, hostConfig, reuse)
 		if err != nil {
 			return "", err
 		}
@@ -361,9 +714,15 @@ func (c *Client) createContainer(ctx context.Context, containerName string, conf
 	return resp.ID, nil
 }
 
-func (c *Client) createEgressContainers(ctx context.Context, containerName string, egressContainerName string,
+// createEgressContainers creates the egress (Squid) and DNS containers for
+// a workload's pod, joining both to infraContainerID's network namespace
+// via NetworkMode("container:<infraID>") instead of attaching them to a
+// network of their own. Sharing that namespace (and so its loopback) is
+// what lets Squid bind 127.0.0.1:3128 and be reachable only from its own
+// pod, never from a neighboring workload.
+func (c *Client) createEgressContainers(ctx context.Context, infraContainerID string, egressContainerName string,
 	dnsContainerName string, attachStdio bool, perm *permissions.NetworkPermissions,
-	portBindings map[string][]runtime.PortBinding, exposedPorts map[string]struct{}) (string, string, string, error) {
+	portBindings map[string][]runtime.PortBinding, exposedPorts map[string]struct{}) error {
 	// first spin up the egress container
 	logger.Infof("Setting up egress container for %s with image %s...", egressContainerName, EgressImage)
 	egressLabels := map[string]string{}
@@ -374,13 +733,13 @@ func (c *Client) createEgressContainers(ctx context.Context, containerName strin
 	// pull the egress image if it is not already pulled
 	err := c.PullImage(ctx, EgressImage)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to pull egress image: %v", err)
+		return fmt.Errorf("failed to pull egress image: %v", err)
 	}
 
 	// pull the dns image if it is not already pulled
 	err = c.PullImage(ctx, DnsImage)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to pull DNS image: %v", err)
+		return fmt.Errorf("failed to pull DNS image: %v", err)
 	}
 
 	// Create container options
@@ -408,23 +767,37 @@ func (c *Client) createEgressContainers(ctx context.Context, containerName strin
 		Tty:          false,
 	}
 
-	// generate the squid configuration and mount it
-	squidConfPath, err := createTempSquidConf(perm, containerName, exposedPorts)
+	// generate the squid configuration, bound to loopback only, and mount it.
+	// The workload shares this pod's loopback, so ingress reverse-proxying
+	// targets 127.0.0.1 rather than the workload's container name.
+	squidConfPath, err := createTempSquidConf(perm, "127.0.0.1", exposedPorts)
 	if err != nil {
-		return "", "", "", fmt.Errorf("failed to create temporary squid.conf: %v", err)
+		return fmt.Errorf("failed to create temporary squid.conf: %v", err)
 	}
-
-	mounts := []runtime.Mount{}
-	mounts = append(mounts, runtime.Mount{
+	squidMounts := []runtime.Mount{{
 		Source:   squidConfPath,
 		Target:   "/etc/squid/squid.conf",
 		ReadOnly: true,
-	})
+	}}
+
+	// generate the dnsmasq configuration enforcing AllowHost at the DNS
+	// layer, and mount it.
+	dnsmasqConfPath, err := createTempDnsmasqConf(perm)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary dnsmasq.conf: %v", err)
+	}
+	dnsMounts := []runtime.Mount{{
+		Source:   dnsmasqConfPath,
+		Target:   "/etc/dnsmasq.conf",
+		ReadOnly: true,
+	}}
+
+	sharedNetns := sharedNetnsHostConfig(infraContainerID)
 
 	// Create egress host configuration
 	egressHostConfig := &container.HostConfig{
-		Mounts:      convertMounts(mounts),
-		NetworkMode: container.NetworkMode("bridge"),
+		Mounts:      convertMounts(squidMounts),
+		NetworkMode: sharedNetns,
 		CapAdd:      []string{"CAP_SETUID", "CAP_SETGID"},
 		CapDrop:     nil,
 		SecurityOpt: nil,
@@ -433,8 +806,8 @@ func (c *Client) createEgressContainers(ctx context.Context, containerName strin
 		},
 	}
 	dnsHostConfig := &container.HostConfig{
-		Mounts:      convertMounts(mounts),
-		NetworkMode: container.NetworkMode("bridge"),
+		Mounts:      convertMounts(dnsMounts),
+		NetworkMode: sharedNetns,
 		CapAdd:      nil,
 		CapDrop:     nil,
 		SecurityOpt: nil,
@@ -443,57 +816,29 @@ func (c *Client) createEgressContainers(ctx context.Context, containerName strin
 		},
 	}
 
-	// create networks
-	networkName := fmt.Sprintf("toolhive-%s-internal", containerName)
-	endpointsConfig := map[string]*network.EndpointSettings{
-		networkName:         {},
-		"toolhive-external": {},
+	// Containers joining another container's network namespace can't also
+	// carry their own endpoint config or port bindings; those belong to the
+	// infra container that owns the namespace (see createPodInfraContainer).
+	if _, err := c.createContainer(ctx, dnsContainerName, configDns, dnsHostConfig, nil); err != nil {
+		return fmt.Errorf("failed to create dns container: %v", err)
 	}
 
-	// now create the dns container
-	dnsContainerId, err := c.createContainer(ctx, dnsContainerName, configDns, dnsHostConfig, endpointsConfig)
-	if err != nil {
-		return "", "", "", fmt.Errorf("failed to create dns container: %v", err)
-	}
-
-	dnsContainerResponse, err := c.client.ContainerInspect(ctx, dnsContainerId)
-	if err != nil {
-		return "", "", "", fmt.Errorf("failed to inspect DNS container: %v", err)
+	if _, err := c.createContainer(ctx, egressContainerName, config, egressHostConfig, nil); err != nil {
+		return fmt.Errorf("failed to create egress container: %v", err)
 	}
 
-	dnsNetworkSettings, ok := dnsContainerResponse.NetworkSettings.Networks[networkName]
-	if !ok {
-		return "", "", "", fmt.Errorf("network %s not found in container's network settings", networkName)
-	}
-	dnsContainerIP := dnsNetworkSettings.IPAddress
-	// Setup port bindings
-	if err := setupExposedPorts(config, exposedPorts); err != nil {
-		return "", "", "", NewContainerError(err, "", err.Error())
-	}
-	if err := setupPortBindings(egressHostConfig, portBindings); err != nil {
-		return "", "", "", NewContainerError(err, "", err.Error())
-	}
-
-	// Create egress container itself
-	egressContainerId, err := c.createContainer(ctx, egressContainerName, config, egressHostConfig, endpointsConfig)
-	if err != nil {
-		return "", "", "", fmt.Errorf("failed to create egress container: %v", err)
-	}
-
-	return egressContainerId, dnsContainerId, dnsContainerIP, nil
+	return nil
 }
 
-func (c *Client) createContainerNetworks(ctx context.Context, internalNetworkName string, externalNetworkName string) error {
-	internalNetworkLabels := map[string]string{}
-	lb.AddNetworkLabels(internalNetworkLabels, internalNetworkName)
-	err := c.createNetwork(ctx, internalNetworkName, internalNetworkLabels, true)
+func (c *Client) createContainerNetworks(
+	ctx context.Context, driver netdriver.Driver, internalNetworkName string, externalNetworkName string,
+) error {
+	_, err := driver.EnsureNetwork(ctx, netdriver.EndpointConfig{NetworkName: internalNetworkName, Internal: true})
 	if err != nil {
 		return fmt.Errorf("failed to create internal network: %v", err)
 	}
 
-	externalNetworkLabels := map[string]string{}
-	lb.AddNetworkLabels(externalNetworkLabels, externalNetworkName)
-	err = c.createNetwork(ctx, externalNetworkName, externalNetworkLabels, false)
+	_, err = driver.EnsureNetwork(ctx, netdriver.EndpointConfig{NetworkName: externalNetworkName})
 	if err != nil {
 		// just log the error and continue
 		logger.Warnf("failed to create external network %q: %v", externalNetworkName, err)
@@ -518,36 +863,88 @@ func (c *Client) DeployWorkload(
 	// check if we are an mcp workload
 	isMcpWorkload := name != "inspector"
 	// Get permission config from profile
-	permissionConfig, err := c.getPermissionConfigFromProfile(permissionProfile, transportType)
+	permissionConfig, err := c.getPermissionConfigFromProfile(permissionProfile, transportType, name)
 	if err != nil {
 		return "", fmt.Errorf("failed to get permission config: %w", err)
 	}
 
+	// Resolve any CDI device names (e.g. "nvidia.com/gpu=all") the profile
+	// declared into concrete device nodes and environment variables.
+	cdiDevices, err := resolveCDIDevices(permissionConfig.Devices)
+	if err != nil {
+		return "", NewContainerError(err, "", fmt.Sprintf("failed to resolve CDI devices: %v", err))
+	}
+	if len(cdiDevices.Env) > 0 {
+		if envVars == nil {
+			envVars = make(map[string]string)
+		}
+		for _, kv := range cdiDevices.Env {
+			if k, v, ok := strings.Cut(kv, "="); ok {
+				envVars[k] = v
+			}
+		}
+	}
+
 	// Determine if we should attach stdio
 	attachStdio := options == nil || options.AttachStdio
 
-	// create networks
-	networkName := fmt.Sprintf("toolhive-%s-internal", name)
-	err = c.createContainerNetworks(ctx, networkName, "toolhive-external")
-	if err != nil {
-		return "", fmt.Errorf("failed to create container networks: %v", err)
+	// select the network driver this workload's permission profile asks for
+	networkDriver := c.networkDriverFor(permissionProfile.Network)
+	// host/none bypass toolhive's pod/egress model entirely: the workload
+	// either shares the host's own namespace or gets no network at all, so
+	// there's no per-workload isolation for a pod to provide in the first
+	// place.
+	hostOrNoneNetwork := networkDriver.Name() == "host" || networkDriver.Name() == "none"
+	if hostOrNoneNetwork {
+		if err := validateHostOrNoneNetwork(networkDriver.Name(), permissionProfile.Network); err != nil {
+			return "", err
+		}
 	}
+
 	var additionalDNS string
-	if isMcpWorkload {
+	var infraContainerID string
+	var extraHosts []string
+	networkName := fmt.Sprintf("toolhive-%s-internal", name)
+	switch {
+	case options != nil && options.PodID != "":
+		// This workload is one of several members of an already-created
+		// pod (see Client.CreatePod) rather than getting its own -- it
+		// joins that pod's network/IPC namespace directly and carries no
+		// egress/DNS sidecars of its own; those belong to whichever caller
+		// created the shared pod.
+		infraContainerID = options.PodID
+	case hostOrNoneNetwork:
+		// No pod, no egress/DNS containers, no per-workload network to
+		// create -- the container's NetworkMode is set directly below.
+	case isMcpWorkload:
+		// MCP workloads get a pod: a tiny infra container owns the shared
+		// network namespace that the workload, its egress container, and its
+		// DNS container all join, so egress's proxy is reachable only via
+		// loopback inside that namespace and never from a neighboring
+		// workload's pod.
+		if _, err := networkDriver.EnsureNetwork(ctx, netdriver.EndpointConfig{NetworkName: "toolhive-external"}); err != nil {
+			logger.Warnf("failed to create external network %q: %v", "toolhive-external", err)
+		}
+		infraContainerID, err = c.createPodInfraContainer(
+			ctx, networkDriver, name, options.PortBindings, options.ExposedPorts, nil,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to create pod infra container: %v", err)
+		}
+
 		// create egress container
 		egressContainerName := fmt.Sprintf("%s-egress", name)
 		dnsContainerName := fmt.Sprintf("%s-dns", name)
-		_, _, dnsContainerIP, err := c.createEgressContainers(ctx, name, egressContainerName, dnsContainerName,
-			attachStdio, permissionProfile.Network, options.PortBindings, options.ExposedPorts)
-		if err != nil {
+		if err := c.createEgressContainers(ctx, infraContainerID, egressContainerName, dnsContainerName,
+			attachStdio, permissionProfile.Network, options.PortBindings, options.ExposedPorts); err != nil {
 			return "", fmt.Errorf("failed to create egress container: %v", err)
 		}
-		if dnsContainerIP != "" {
-			additionalDNS = dnsContainerIP
-		}
+		// The DNS container shares the infra container's loopback, so it's
+		// always reachable there regardless of any outer network's IPAM.
+		additionalDNS = "127.0.0.1"
 
 		// add extra env vars
-		egressHost := fmt.Sprintf("http://%s:3128", egressContainerName)
+		egressHost := "http://127.0.0.1:3128"
 		if envVars == nil {
 			envVars = make(map[string]string)
 		}
@@ -557,10 +954,45 @@ func (c *Client) DeployWorkload(
 		envVars["https_proxy"] = egressHost
 		envVars["NO_PROXY"] = "localhost,127.0.0.1,::1"
 		envVars["no_proxy"] = "localhost,127.0.0.1,::1"
+
+		// Start any declared service containers (e.g. postgres, redis) before
+		// the workload itself, so its env and /etc/hosts can point at them
+		// from the moment it starts.
+		if len(permissionProfile.Services) > 0 {
+			serviceResults, err := c.RunServices(ctx, name, permissionProfile.Services)
+			if err != nil {
+				return "", fmt.Errorf("failed to start service containers: %w", err)
+			}
+			for _, svc := range serviceResults {
+				// The service shares this pod's loopback, so a plain
+				// /etc/hosts alias is enough to resolve its name -- there's
+				// no per-workload DNS server involved the way there is for
+				// AllowHost domains.
+				extraHosts = append(extraHosts, fmt.Sprintf("%s:127.0.0.1", svc.DNSName))
+
+				envKey := strings.ToUpper(strings.ReplaceAll(svc.Name, "-", "_"))
+				envVars[envKey+"_HOST"] = svc.DNSName
+				for port, addr := range svc.Addrs {
+					_, hostPort, _ := strings.Cut(addr, ":")
+					envVars[fmt.Sprintf("%s_PORT_%s", envKey, strings.Split(port, "/")[0])] = hostPort
+				}
+			}
+		}
+	default:
+		// Other workloads, such as the inspector, keep their own dedicated
+		// internal network rather than joining a pod.
+		if err := c.createContainerNetworks(ctx, networkDriver, networkName, "toolhive-external"); err != nil {
+			return "", fmt.Errorf("failed to create container networks: %v", err)
+		}
 	}
 
 	// add extra label
 	labels["toolhive-main-workload"] = "true"
+	// toolhive-runtime records which backend created this workload, so a
+	// removal issued against the other backend (e.g. after TOOLHIVE_RUNTIME
+	// changes) can at least report why it can't find the workload's
+	// sidecars, rather than silently leaving them behind.
+	labels["toolhive-runtime"] = "docker"
 
 	// Create container configuration
 	config := &container.Config{
@@ -574,6 +1006,15 @@ func (c *Client) DeployWorkload(
 		OpenStdin:    attachStdio,
 		Tty:          false,
 	}
+	if options != nil && options.Healthcheck != nil {
+		config.Healthcheck = convertHealthcheck(options.Healthcheck)
+	} else {
+		var exposedPorts map[string]struct{}
+		if options != nil {
+			exposedPorts = options.ExposedPorts
+		}
+		config.Healthcheck = defaultHealthcheck(transportType, command, exposedPorts)
+	}
 
 	// Create host configuration
 	hostConfig := &container.HostConfig{
@@ -582,6 +1023,16 @@ func (c *Client) DeployWorkload(
 		CapAdd:      permissionConfig.CapAdd,
 		CapDrop:     permissionConfig.CapDrop,
 		SecurityOpt: permissionConfig.SecurityOpt,
+		Resources: container.Resources{
+			Devices:     cdiDevices.Devices,
+			CPUShares:   permissionConfig.Resources.CPUShares,
+			CPUQuota:    permissionConfig.Resources.CPUQuota,
+			CPUPeriod:   permissionConfig.Resources.CPUPeriod,
+			Memory:      permissionConfig.Resources.Memory,
+			MemorySwap:  permissionConfig.Resources.MemorySwap,
+			PidsLimit:   pidsLimitPtr(permissionConfig.Resources.PidsLimit),
+			BlkioWeight: permissionConfig.Resources.BlkioWeight,
+		},
 		RestartPolicy: container.RestartPolicy{
 			Name: "unless-stopped",
 		},
@@ -589,32 +1040,78 @@ func (c *Client) DeployWorkload(
 	if additionalDNS != "" {
 		hostConfig.DNS = []string{additionalDNS}
 	}
-
-	// Configure ports if options are provided
-	if options != nil {
-		// Setup exposed ports
-		if err := setupExposedPorts(config, options.ExposedPorts); err != nil {
-			return "", NewContainerError(err, "", err.Error())
+	if len(extraHosts) > 0 {
+		hostConfig.ExtraHosts = extraHosts
+	}
+
+	var endpointsConfig map[string]*network.EndpointSettings
+	switch {
+	case hostOrNoneNetwork:
+		// Neither a pod nor a dedicated bridge network applies here -- the
+		// container's NetworkMode is the driver name itself, and its ports
+		// (if any) are exposed directly rather than bound on an infra
+		// container's loopback.
+		hostConfig.NetworkMode = container.NetworkMode(networkDriver.Name())
+		if options != nil {
+			if err := setupExposedPorts(config, options.ExposedPorts); err != nil {
+				return "", NewContainerError(err, "", err.Error())
+			}
+			if networkDriver.Name() != "host" {
+				// "host" networking has no port bindings to configure --
+				// the workload already listens directly on the host's own
+				// ports.
+				if err := setupPortBindings(hostConfig, options.PortBindings); err != nil {
+					return "", NewContainerError(err, "", err.Error())
+				}
+			}
+		}
+	case isMcpWorkload, options != nil && options.PodID != "":
+		// The workload joins its pod's infra container namespace, so its
+		// exposed ports/bindings live on the infra container (see
+		// createPodInfraContainer) and it carries no endpoint config of its
+		// own. A workload joining a pod it didn't create (options.PodID)
+		// also shares the pod's IPC namespace, matching a Kubernetes pod's
+		// shared IPC.
+		hostConfig.NetworkMode = sharedNetnsHostConfig(infraContainerID)
+		if options != nil && options.PodID != "" {
+			hostConfig.IpcMode = container.IpcMode(sharedNetnsHostConfig(infraContainerID))
+		}
+	default:
+		// Configure ports if options are provided
+		if options != nil {
+			if err := setupExposedPorts(config, options.ExposedPorts); err != nil {
+				return "", NewContainerError(err, "", err.Error())
+			}
+			if err := setupPortBindings(hostConfig, options.PortBindings); err != nil {
+				return "", NewContainerError(err, "", err.Error())
+			}
 		}
 
-		// Setup port bindings
-		if err := setupPortBindings(hostConfig, options.PortBindings); err != nil {
-			return "", NewContainerError(err, "", err.Error())
+		internalEndpoint := netdriver.EndpointConfig{NetworkName: networkName, Internal: true}
+		endpointsConfig = map[string]*network.EndpointSettings{}
+		if cfg := networkDriver.EndpointConfig(internalEndpoint); cfg != nil {
+			endpointsConfig[networkName] = cfg
+		}
+		// for other workloads such as inspector, add to external network
+		if cfg := networkDriver.EndpointConfig(netdriver.EndpointConfig{NetworkName: "toolhive-external"}); cfg != nil {
+			endpointsConfig["toolhive-external"] = cfg
 		}
 	}
 
-	// create mcp container
-	endpointsConfig := map[string]*network.EndpointSettings{
-		networkName: {},
+	reuse := runtime.ReuseIfMatches
+	if options != nil && options.Reuse != "" {
+		reuse = options.Reuse
 	}
-	if !isMcpWorkload {
-		// for other workloads such as inspector, add to external network
-		endpointsConfig["toolhive-external"] = &network.EndpointSettings{}
-	}
-	containerId, err := c.createContainer(ctx, name, config, hostConfig, endpointsConfig)
+	containerId, err := c.createContainerWithReuse(ctx, name, config, hostConfig, endpointsConfig, reuse)
 	if err != nil {
 		return "", fmt.Errorf("failed to create container: %v", err)
 	}
+	if !isMcpWorkload && !hostOrNoneNetwork {
+		internalEndpoint := netdriver.EndpointConfig{NetworkName: networkName, Internal: true}
+		if err := networkDriver.PostStart(ctx, containerId, internalEndpoint); err != nil {
+			return "", fmt.Errorf("failed to attach container to network: %v", err)
+		}
+	}
 
 	return containerId, nil
 }
@@ -728,6 +1225,23 @@ func (c *Client) StopWorkload(ctx context.Context, workloadID string) error {
 				logger.Warnf("Failed to stop dns container %s: %v", dnsContainerName, err)
 			}
 		}
+
+		// stop any service containers (e.g. postgres, redis) this workload started
+		if err := c.StopServices(ctx, containerName); err != nil {
+			logger.Warnf("Failed to stop service containers for %s: %v", containerName, err)
+		}
+
+		// stop the pod's infra container, if this workload had one
+		infraContainerName := podInfraContainerName(containerName)
+		infraContainerId, err := c.findExistingContainer(ctx, infraContainerName)
+		if err != nil {
+			logger.Warnf("Failed to find pod infra container %s: %v", infraContainerName, err)
+		} else if infraContainerId != "" {
+			err = c.client.ContainerStop(ctx, infraContainerId, container.StopOptions{Timeout: &timeoutSeconds})
+			if err != nil {
+				logger.Warnf("Failed to stop pod infra container %s: %v", infraContainerName, err)
+			}
+		}
 	}
 
 	return nil
@@ -746,6 +1260,10 @@ func (c *Client) RemoveWorkload(ctx context.Context, workloadID string) error {
 	containerName := containerResponse.Name
 	containerName = strings.TrimPrefix(containerName, "/")
 
+	if containerResponse.HostConfig != nil {
+		releasePortBindings(containerResponse.HostConfig.PortBindings)
+	}
+
 	err = c.client.ContainerRemove(ctx, workloadID, container.RemoveOptions{
 		Force: true,
 	})
@@ -795,6 +1313,18 @@ func (c *Client) RemoveWorkload(ctx context.Context, workloadID string) error {
 
 	}
 
+	// remove any service containers this workload started, in case it's
+	// being removed without having gone through StopWorkload first
+	if err := c.StopServices(ctx, containerName); err != nil {
+		logger.Warnf("Failed to remove service containers for %s: %v", containerName, err)
+	}
+
+	// remove the pod's infra container, if this workload had one
+	c.removePodInfraContainer(ctx, containerName)
+
+	// tear down any overlay mounts this workload was given
+	removeWorkloadOverlays(containerName)
+
 	// Delete networks if there are no containers using them.
 	toolHiveContainers, err := c.client.ContainerList(ctx, container.ListOptions{
 		All:     true,
@@ -804,16 +1334,20 @@ func (c *Client) RemoveWorkload(ctx context.Context, workloadID string) error {
 		return fmt.Errorf("failed to list containers: %v", err)
 	}
 
-	// Delete associated internal network
+	// Delete associated internal network. The driver that created it isn't
+	// tracked past deployment, so fall back to the default bridge driver;
+	// non-default drivers' EnsureNetwork/DeleteNetwork are written to treat
+	// "doesn't exist"/"already gone" as success either way.
+	networkDriver := c.networkDriverFor(nil)
 	networkName := fmt.Sprintf("toolhive-%s-internal", containerName)
-	if err := c.deleteNetwork(ctx, networkName); err != nil {
+	if err := networkDriver.DeleteNetwork(ctx, networkName); err != nil {
 		// just log the error and continue
 		logger.Warnf("failed to delete network %q: %v", networkName, err)
 	}
 
 	if len(toolHiveContainers) == 0 {
 		// remove external network
-		if err := c.deleteNetwork(ctx, "toolhive-external"); err != nil {
+		if err := networkDriver.DeleteNetwork(ctx, "toolhive-external"); err != nil {
 			// just log the error and continue
 			logger.Warnf("failed to delete network %q: %v", "toolhive-external", err)
 		}
@@ -822,39 +1356,6 @@ func (c *Client) RemoveWorkload(ctx context.Context, workloadID string) error {
 	return nil
 }
 
-// GetWorkloadLogs gets workload logs
-func (c *Client) GetWorkloadLogs(ctx context.Context, workloadID string, follow bool) (string, error) {
-	options := container.LogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-		Follow:     follow,
-		Tail:       "100",
-	}
-
-	// Get logs
-	logs, err := c.client.ContainerLogs(ctx, workloadID, options)
-	if err != nil {
-		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to get workload logs: %v", err))
-	}
-	defer logs.Close()
-
-	if follow {
-		_, err = io.Copy(os.Stdout, logs)
-		if err != nil && err != io.EOF {
-			logger.Errorf("Error reading container logs: %v", err)
-			return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to follow workload logs: %v", err))
-		}
-	}
-
-	// Read logs
-	logBytes, err := io.ReadAll(logs)
-	if err != nil {
-		return "", NewContainerError(err, workloadID, fmt.Sprintf("failed to read workload logs: %v", err))
-	}
-
-	return string(logBytes), nil
-}
-
 // IsWorkloadRunning checks if a workload is running
 func (c *Client) IsWorkloadRunning(ctx context.Context, workloadID string) (bool, error) {
 	// Inspect workload
@@ -870,6 +1371,44 @@ func (c *Client) IsWorkloadRunning(ctx context.Context, workloadID string) (bool
 	return info.State.Running, nil
 }
 
+// WaitForHealthy blocks until workloadID's healthcheck reports "healthy"
+// (Podman's inspect surfaces the same State.Health.Status field), or
+// returns an error once timeout elapses or ctx is canceled. A workload with
+// no configured healthcheck reports an empty Health.Status and is treated
+// as healthy immediately, so callers can call this unconditionally after
+// deploying a workload instead of branching on whether one asked for a
+// probe.
+func (c *Client) WaitForHealthy(ctx context.Context, workloadID string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		info, err := c.client.ContainerInspect(ctx, workloadID)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return NewContainerError(ErrContainerNotFound, workloadID, "workload not found")
+			}
+			return NewContainerError(err, workloadID, fmt.Sprintf("failed to inspect workload: %v", err))
+		}
+
+		if info.State.Health == nil || info.State.Health.Status == "" || info.State.Health.Status == "healthy" {
+			return nil
+		}
+		if info.State.Health.Status == "unhealthy" {
+			return NewContainerError(ErrContainerExited, workloadID, "workload's healthcheck reported unhealthy")
+		}
+
+		select {
+		case <-ctx.Done():
+			return NewContainerError(ctx.Err(), workloadID, "timed out waiting for workload to become healthy")
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetWorkloadInfo gets workload information
 func (c *Client) GetWorkloadInfo(ctx context.Context, workloadID string) (runtime.ContainerInfo, error) {
 	// Inspect workload
@@ -907,17 +1446,40 @@ func (c *Client) GetWorkloadInfo(ctx context.Context, workloadID string) (runtim
 	}
 
 	return runtime.ContainerInfo{
-		ID:      info.ID,
-		Name:    strings.TrimPrefix(info.Name, "/"),
-		Image:   info.Config.Image,
-		Status:  info.State.Status,
-		State:   info.State.Status,
-		Created: created,
-		Labels:  info.Config.Labels,
-		Ports:   ports,
+		ID:         info.ID,
+		Name:       strings.TrimPrefix(info.Name, "/"),
+		Image:      info.Config.Image,
+		Status:     info.State.Status,
+		State:      info.State.Status,
+		Created:    created,
+		Labels:     info.Config.Labels,
+		Ports:      ports,
+		MountModes: mountModesFor(strings.TrimPrefix(info.Name, "/"), info.Mounts),
 	}, nil
 }
 
+// mountModesFor classifies each of a workload's mounts by effective mode --
+// "overlay" for a copy-on-write mount created by addOverlayMounts, "rw" or
+// "ro" for an ordinary bind mount -- keyed by the in-container destination.
+// Overlay mounts aren't tracked separately at runtime; they're recognized by
+// their source path living under this workload's overlay state directory.
+func mountModesFor(workloadName string, mounts []container.MountPoint) map[string]string {
+	overlayRoot := filepath.Join(overlayStateDir(), workloadName) + string(filepath.Separator)
+
+	modes := make(map[string]string, len(mounts))
+	for _, m := range mounts {
+		switch {
+		case strings.HasPrefix(m.Source, overlayRoot):
+			modes[m.Destination] = "overlay"
+		case m.RW:
+			modes[m.Destination] = "rw"
+		default:
+			modes[m.Destination] = "ro"
+		}
+	}
+	return modes
+}
+
 // readCloserWrapper wraps an io.Reader to implement io.ReadCloser
 type readCloserWrapper struct {
 	reader io.Reader
@@ -960,6 +1522,290 @@ func (c *Client) AttachToWorkload(ctx context.Context, workloadID string) (io.Wr
 	return resp.Conn, readCloser, nil
 }
 
+// ExecInContainer runs cmd inside workloadID and waits for it to finish,
+// collecting its combined output. It's a non-interactive convenience
+// wrapper around ExecInContainerStream for callers that just want a result
+// (health probes, cache warmup, injecting rotated secrets without a
+// restart).
+func (c *Client) ExecInContainer(
+	ctx context.Context,
+	workloadID string,
+	cmd []string,
+	opts runtime.ExecOptions,
+) (runtime.ExecResult, error) {
+	stdin, stdout, stderr, _, wait, err := c.ExecInContainerStream(ctx, workloadID, cmd, opts)
+	if err != nil {
+		return runtime.ExecResult{}, err
+	}
+	defer stdin.Close()
+
+	var stdoutBuf, stderrBuf bytes.Buffer
+	stdoutErr := make(chan error, 1)
+	stderrErr := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(&stdoutBuf, stdout)
+		stdoutErr <- err
+	}()
+	go func() {
+		_, err := io.Copy(&stderrBuf, stderr)
+		stderrErr <- err
+	}()
+	if err := <-stdoutErr; err != nil {
+		return runtime.ExecResult{}, fmt.Errorf("failed to read exec stdout: %w", err)
+	}
+	if err := <-stderrErr; err != nil {
+		return runtime.ExecResult{}, fmt.Errorf("failed to read exec stderr: %w", err)
+	}
+
+	exitCode, err := wait()
+	if err != nil {
+		return runtime.ExecResult{}, err
+	}
+
+	return runtime.ExecResult{
+		ExitCode: exitCode,
+		Stdout:   stdoutBuf.Bytes(),
+		Stderr:   stderrBuf.Bytes(),
+	}, nil
+}
+
+// ExecInContainerStream starts cmd inside workloadID and returns live pipes
+// for its stdin/stdout/stderr, using the same exec endpoints Docker and
+// Podman's libpod-compatible API both implement (ContainerExecCreate hits
+// POST /containers/{id}/exec, ContainerExecAttach hits POST
+// /exec/{id}/start and hijacks the connection). When opts.Tty is false,
+// the daemon multiplexes stdout and stderr onto that single hijacked
+// connection with an 8-byte frame header per chunk; stdcopy.StdCopy
+// demultiplexes it into the two pipes callers get back.
+func (c *Client) ExecInContainerStream(
+	ctx context.Context,
+	workloadID string,
+	cmd []string,
+	opts runtime.ExecOptions,
+) (stdin io.WriteCloser, stdout, stderr io.ReadCloser, resize func(height, width uint), wait func() (int, error), err error) {
+	created, err := c.client.ContainerExecCreate(ctx, workloadID, container.ExecOptions{
+		Cmd:          cmd,
+		User:         opts.User,
+		WorkingDir:   opts.WorkingDir,
+		Env:          opts.Env,
+		Tty:          opts.Tty,
+		AttachStdin:  opts.AttachStdin,
+		AttachStdout: true,
+		AttachStderr: true,
+		DetachKeys:   opts.DetachKeys,
+	})
+	if err != nil {
+		return nil, nil, nil, nil, nil, NewContainerError(ErrExecFailed, workloadID, fmt.Sprintf("failed to create exec: %v", err))
+	}
+
+	attachResp, err := c.client.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{Tty: opts.Tty})
+	if err != nil {
+		return nil, nil, nil, nil, nil, NewContainerError(ErrExecFailed, workloadID, fmt.Sprintf("failed to attach to exec: %v", err))
+	}
+
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
+
+	go func() {
+		defer attachResp.Close()
+		defer stdoutWriter.Close()
+		defer stderrWriter.Close()
+
+		var copyErr error
+		if opts.Tty {
+			_, copyErr = io.Copy(stdoutWriter, attachResp.Reader)
+		} else {
+			_, copyErr = stdcopy.StdCopy(stdoutWriter, stderrWriter, attachResp.Reader)
+		}
+		if copyErr != nil && !errors.Is(copyErr, io.EOF) {
+			logger.Log.Warnf("exec stream for container %s ended with error: %v", workloadID, copyErr)
+		}
+	}()
+
+	resizeFn := func(height, width uint) {
+		resizeErr := c.client.ContainerExecResize(ctx, created.ID, container.ResizeOptions{Height: height, Width: width})
+		if resizeErr != nil {
+			logger.Log.Warnf("failed to resize exec %s for container %s: %v", created.ID, workloadID, resizeErr)
+		}
+	}
+
+	waitFn := func() (int, error) {
+		for {
+			inspect, inspectErr := c.client.ContainerExecInspect(ctx, created.ID)
+			if inspectErr != nil {
+				return 0, fmt.Errorf("failed to inspect exec %s: %w", created.ID, inspectErr)
+			}
+			if !inspect.Running {
+				return inspect.ExitCode, nil
+			}
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	}
+
+	return attachResp.Conn, stdoutReader, stderrReader, resizeFn, waitFn, nil
+}
+
+// Events subscribes to the Docker daemon's /events stream (Podman's
+// libpod-compatible API implements the same endpoint, as /libpod/events
+// with stream=true), translating each message into a runtime.Event. The
+// returned channel is closed once ctx is canceled or the underlying stream
+// ends, whichever comes first.
+func (c *Client) Events(ctx context.Context, filter runtime.EventFilter) (<-chan runtime.Event, error) {
+	eventFilters := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	if filter.ContainerID != "" {
+		eventFilters.Add("container", filter.ContainerID)
+	}
+
+	dockerEvents, dockerErrs := c.client.Events(ctx, events.ListOptions{Filters: eventFilters})
+
+	out := make(chan runtime.Event)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err, ok := <-dockerErrs:
+				if ok && err != nil {
+					logger.Log.Warnf("container event stream ended: %v", err)
+				}
+				return
+			case msg, ok := <-dockerEvents:
+				if !ok {
+					return
+				}
+				select {
+				case out <- convertDockerEvent(msg):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// convertDockerEvent translates a Docker events.Message into a
+// runtime-agnostic runtime.Event, parsing the "exitCode" attribute die
+// events carry into ExitCode.
+func convertDockerEvent(msg events.Message) runtime.Event {
+	event := runtime.Event{
+		Type:        string(msg.Type),
+		Action:      string(msg.Action),
+		ContainerID: msg.Actor.ID,
+		Timestamp:   time.Unix(0, msg.TimeNano),
+		Attributes:  msg.Actor.Attributes,
+	}
+
+	if exitCodeStr, ok := msg.Actor.Attributes["exitCode"]; ok {
+		if exitCode, err := strconv.Atoi(exitCodeStr); err == nil {
+			event.ExitCode = &exitCode
+		}
+	}
+
+	return event
+}
+
+// ContainerStats streams resource-usage samples for workloadID from
+// Docker's /containers/{id}/stats endpoint (Podman's
+// /libpod/containers/{id}/stats is wire-compatible), which emits
+// newline-delimited JSON when stream is true and a single object otherwise.
+func (c *Client) ContainerStats(ctx context.Context, workloadID string, stream bool) (<-chan runtime.ContainerStats, error) {
+	statsResp, err := c.client.ContainerStats(ctx, workloadID, stream)
+	if err != nil {
+		return nil, NewContainerError(ErrExecFailed, workloadID, fmt.Sprintf("failed to get container stats: %v", err))
+	}
+
+	out := make(chan runtime.ContainerStats)
+	go func() {
+		defer close(out)
+		defer statsResp.Body.Close()
+
+		decoder := json.NewDecoder(statsResp.Body)
+		for {
+			var raw container.StatsResponse
+			if err := decoder.Decode(&raw); err != nil {
+				if !errors.Is(err, io.EOF) {
+					logger.Log.Warnf("container stats stream for %s ended with error: %v", workloadID, err)
+				}
+				return
+			}
+
+			select {
+			case out <- convertDockerStats(workloadID, &raw):
+			case <-ctx.Done():
+				return
+			}
+
+			if !stream {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// convertDockerStats translates a Docker container.StatsResponse into a
+// runtime-agnostic runtime.ContainerStats, computing CPU% from the delta of
+// cpu_stats.cpu_usage.total_usage over system_cpu_usage times online_cpus,
+// matching Docker's documented formula so results are comparable to
+// `docker stats`.
+func convertDockerStats(containerID string, raw *container.StatsResponse) runtime.ContainerStats {
+	var rxBytes, txBytes uint64
+	for _, net := range raw.Networks {
+		rxBytes += net.RxBytes
+		txBytes += net.TxBytes
+	}
+
+	var readBytes, writeBytes uint64
+	for _, entry := range raw.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			readBytes += entry.Value
+		case "write":
+			writeBytes += entry.Value
+		}
+	}
+
+	return runtime.ContainerStats{
+		ContainerID:      containerID,
+		CPUPercent:       dockerCPUPercent(raw),
+		MemoryUsageBytes: raw.MemoryStats.Usage,
+		MemoryLimitBytes: raw.MemoryStats.Limit,
+		NetworkRxBytes:   rxBytes,
+		NetworkTxBytes:   txBytes,
+		BlockReadBytes:   readBytes,
+		BlockWriteBytes:  writeBytes,
+		Timestamp:        raw.Read,
+	}
+}
+
+// dockerCPUPercent implements Docker's documented CPU% formula:
+// (cpu_delta / system_delta) * online_cpus * 100.0.
+func dockerCPUPercent(raw *container.StatsResponse) float64 {
+	cpuDelta := float64(raw.CPUStats.CPUUsage.TotalUsage) - float64(raw.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(raw.CPUStats.SystemUsage) - float64(raw.PreCPUStats.SystemUsage)
+	if systemDelta <= 0 || cpuDelta <= 0 {
+		return 0
+	}
+
+	onlineCPUs := float64(raw.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(raw.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if onlineCPUs == 0 {
+		onlineCPUs = 1
+	}
+
+	return (cpuDelta / systemDelta) * onlineCPUs * 100.0
+}
+
 // ImageExists checks if an image exists locally
 func (c *Client) ImageExists(ctx context.Context, imageName string) (bool, error) {
 	// List images with the specified name
@@ -1041,132 +1887,34 @@ func (*Client) VerifyImage(_ context.Context, serverInfo *registry.Server, image
 	return v.VerifyServer(imageRef, serverInfo)
 }
 
-// BuildImage builds a Docker image from a Dockerfile in the specified context directory
-func (c *Client) BuildImage(ctx context.Context, contextDir, imageName string) error {
-	logger.Infof("Building image %s from context directory %s", imageName, contextDir)
-
-	// Create a tar archive of the context directory
-	tarFile, err := os.CreateTemp("", "docker-build-context-*.tar")
-	if err != nil {
-		return NewContainerError(err, "", fmt.Sprintf("failed to create temporary tar file: %v", err))
-	}
-	defer os.Remove(tarFile.Name())
-	defer tarFile.Close()
-
-	// Create a tar archive of the context directory
-	if err := createTarFromDir(contextDir, tarFile); err != nil {
-		return NewContainerError(err, "", fmt.Sprintf("failed to create tar archive: %v", err))
+// StreamContainerLogs streams workloadID's combined stdout/stderr over
+// Docker's /containers/{id}/logs endpoint (Podman's
+// /libpod/containers/{id}/logs is wire-compatible), translating opts into
+// the equivalent Docker API parameters.
+func (c *Client) StreamContainerLogs(ctx context.Context, workloadID string, opts runtime.LogOptions) (io.ReadCloser, error) {
+	tail := "all"
+	if opts.Tail > 0 {
+		tail = strconv.Itoa(opts.Tail)
 	}
 
-	// Reset the file pointer to the beginning of the file
-	if _, err := tarFile.Seek(0, 0); err != nil {
-		return NewContainerError(err, "", fmt.Sprintf("failed to reset tar file pointer: %v", err))
+	since := ""
+	if !opts.Since.IsZero() {
+		since = opts.Since.Format(time.RFC3339Nano)
 	}
 
-	// Build the image
-	buildOptions := build.ImageBuildOptions{
-		Tags:       []string{imageName},
-		Dockerfile: "Dockerfile",
-		Remove:     true,
-	}
-
-	response, err := c.client.ImageBuild(ctx, tarFile, buildOptions)
-	if err != nil {
-		return NewContainerError(err, "", fmt.Sprintf("failed to build image: %v", err))
-	}
-	defer response.Body.Close()
-
-	// Parse and log the build output
-	if err := parseBuildOutput(response.Body, os.Stdout); err != nil {
-		return NewContainerError(err, "", fmt.Sprintf("failed to process build output: %v", err))
-	}
-
-	return nil
-}
-
-// createTarFromDir creates a tar archive from a directory
-func createTarFromDir(srcDir string, writer io.Writer) error {
-	// Create a new tar writer
-	tw := tar.NewWriter(writer)
-	defer tw.Close()
-
-	// Walk through the directory and add files to the tar archive
-	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Get the relative path
-		relPath, err := filepath.Rel(srcDir, path)
-		if err != nil {
-			return fmt.Errorf("failed to get relative path: %w", err)
-		}
-
-		// Skip the root directory
-		if relPath == "." {
-			return nil
-		}
-
-		// Create a tar header
-		header, err := tar.FileInfoHeader(info, "")
-		if err != nil {
-			return fmt.Errorf("failed to create tar header: %w", err)
-		}
-
-		// Set the name to the relative path
-		header.Name = relPath
-
-		// Write the header
-		if err := tw.WriteHeader(header); err != nil {
-			return fmt.Errorf("failed to write tar header: %w", err)
-		}
-
-		// If it's a regular file, write the contents
-		if !info.IsDir() {
-			// #nosec G304 - This is safe because we're only opening files within the specified context directory
-			file, err := os.Open(path)
-			if err != nil {
-				return fmt.Errorf("failed to open file: %w", err)
-			}
-			defer file.Close()
-
-			if _, err := io.Copy(tw, file); err != nil {
-				return fmt.Errorf("failed to copy file contents: %w", err)
-			}
-		}
-
-		return nil
+	logs, err := c.client.ContainerLogs(ctx, workloadID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Tail:       tail,
+		Since:      since,
+		Timestamps: opts.Timestamps,
 	})
-}
-
-// parseBuildOutput parses the Docker image build output and formats it in a more readable way
-func parseBuildOutput(reader io.Reader, writer io.Writer) error {
-	decoder := json.NewDecoder(reader)
-	for {
-		var buildOutput struct {
-			Stream string `json:"stream,omitempty"`
-			Error  string `json:"error,omitempty"`
-		}
-
-		if err := decoder.Decode(&buildOutput); err != nil {
-			if err == io.EOF {
-				break
-			}
-			return fmt.Errorf("failed to decode build output: %w", err)
-		}
-
-		// Check for errors
-		if buildOutput.Error != "" {
-			return fmt.Errorf("build error: %s", buildOutput.Error)
-		}
-
-		// Print the stream output
-		if buildOutput.Stream != "" {
-			fmt.Fprint(writer, buildOutput.Stream)
-		}
+	if err != nil {
+		return nil, NewContainerError(err, workloadID, fmt.Sprintf("failed to stream workload logs: %v", err))
 	}
 
-	return nil
+	return logs, nil
 }
 
 // getPermissionConfigFromProfile converts a permission profile to a container permission config
@@ -1270,6 +2018,7 @@ func convertRelativePathToAbsolute(source string, mountDecl permissions.MountDec
 func (c *Client) getPermissionConfigFromProfile(
 	profile *permissions.Profile,
 	transportType string,
+	workloadName string,
 ) (*runtime.PermissionConfig, error) {
 	// Start with a default permission config
 	config := &runtime.PermissionConfig{
@@ -1278,11 +2027,22 @@ func (c *Client) getPermissionConfigFromProfile(
 		CapDrop:     []string{"ALL"},
 		CapAdd:      []string{},
 		SecurityOpt: []string{},
+		Devices:     profile.Devices,
+		Resources:   profile.Resources,
 	}
 
 	// Add mounts
 	c.addReadOnlyMounts(config, profile.Read)
 	c.addReadWriteMounts(config, profile.Write)
+	if _, err := c.addOverlayMounts(config, profile.Overlay, workloadName); err != nil {
+		return nil, fmt.Errorf("failed to set up overlay mounts: %w", err)
+	}
+
+	secOpts, err := runtime.SecurityOptsFromProfile(profile.Seccomp, profile.AppArmor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve security options: %w", err)
+	}
+	config.SecurityOpt = append(config.SecurityOpt, secOpts...)
 
 	// Validate transport type
 	if transportType != "sse" && transportType != "stdio" && transportType != "inspector" {
@@ -1317,6 +2077,22 @@ var (
 
 	// ErrContainerExited is returned when a container has exited unexpectedly
 	ErrContainerExited = fmt.Errorf("container exited unexpectedly")
+
+	// ErrExecFailed is returned when starting or attaching to an exec session fails
+	ErrExecFailed = fmt.Errorf("failed to exec in container")
+
+	// ErrInvalidCopyPath is returned when a CopyToWorkload/CopyFromWorkload
+	// container-side path escapes the workload rootfs
+	ErrInvalidCopyPath = fmt.Errorf("invalid container path")
+
+	// ErrCopyPathConflict is returned when CopyToWorkload/CopyFromWorkload's
+	// source and destination aren't both files or both directories
+	ErrCopyPathConflict = fmt.Errorf("source and destination path types conflict")
+
+	// ErrCheckpointIncompatible is returned when RestoreWorkload's checkpoint
+	// manifest doesn't match the image or permission profile it's being
+	// restored against
+	ErrCheckpointIncompatible = fmt.Errorf("checkpoint incompatible with workload")
 )
 
 // ContainerError represents an error related to container operations
@@ -1590,13 +2366,17 @@ func compareContainerConfig(
 	return true
 }
 
-// handleExistingContainer checks if an existing container's configuration matches the desired configuration
+// handleExistingContainer decides whether an existing container can be
+// reused in place of recreating it, per reuse's policy: ReuseIfMatches (the
+// default) compares containerID's ConfigHashLabel against desiredConfig's,
+// ReuseAlways reuses it unconditionally, and ReuseNever always recreates.
 // Returns true if the container can be reused, false if it was removed and needs to be recreated
 func (c *Client) handleExistingContainer(
 	ctx context.Context,
 	containerID string,
 	desiredConfig *container.Config,
 	desiredHostConfig *container.HostConfig,
+	reuse runtime.ReusePolicy,
 ) (bool, error) {
 	// Get container info
 	info, err := c.client.ContainerInspect(ctx, containerID)
@@ -1604,9 +2384,13 @@ func (c *Client) handleExistingContainer(
 		return false, NewContainerError(err, containerID, fmt.Sprintf("failed to inspect container: %v", err))
 	}
 
-	// Compare configurations
-	if compareContainerConfig(&info, desiredConfig, desiredHostConfig) {
-		// Configurations match, container can be reused
+	canReuse := reusePolicyMatches(reuse, info.Config.Labels, desiredConfig.Labels)
+	if configHashDebugEnabled() {
+		logConfigHashDisagreement(containerID, canReuse, &info, desiredConfig, desiredHostConfig)
+	}
+
+	if canReuse {
+		// Configuration matches (or reuse was forced), container can be reused
 
 		// Check if the container is running
 		if !info.State.Running {
@@ -1635,53 +2419,7 @@ func (c *Client) handleExistingContainer(
 	return false, nil
 }
 
-// CreateNetwork creates a network following configuration.
-func (c *Client) createNetwork(
-	ctx context.Context,
-	name string,
-	labels map[string]string,
-	internal bool,
-) error {
-	// Check if the network already exists
-	networks, err := c.client.NetworkList(ctx, network.ListOptions{
-		Filters: filters.NewArgs(filters.Arg("name", name)),
-	})
-	if err != nil {
-		return fmt.Errorf("failed to list networks: %w", err)
-	}
-	if len(networks) > 0 {
-		// Network already exists, return its ID
-		return nil
-	}
-
-	networkCreate := network.CreateOptions{
-		Driver:   "bridge",
-		Internal: internal,
-		Labels:   labels,
-	}
-
-	_, err = c.client.NetworkCreate(ctx, name, networkCreate)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-// DeleteNetwork deletes a network by name.
-func (c *Client) deleteNetwork(ctx context.Context, name string) error {
-	// find the network by name
-	networks, err := c.client.NetworkList(ctx, network.ListOptions{
-		Filters: filters.NewArgs(filters.Arg("name", name)),
-	})
-	if err != nil {
-		return err
-	}
-	if len(networks) == 0 {
-		return fmt.Errorf("network %s not found", name)
-	}
-
-	if err := c.client.NetworkRemove(ctx, networks[0].ID); err != nil {
-		return fmt.Errorf("failed to remove network %s: %w", name, err)
-	}
-	return nil
-}
+// CreateNetwork and DeleteNetwork used to live here as hardcoded
+// bridge-only helpers; network creation/teardown now goes through whichever
+// netdriver.Driver a workload's permission profile selects (see
+// networkDriverFor, createContainerNetworks, and RemoveWorkload).
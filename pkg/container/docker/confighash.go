@@ -0,0 +1,222 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"sort"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// ConfigHashLabel is the label createContainer stamps on every container it
+// creates, recording a canonical hash of the container.Config/HostConfig it
+// was created from. handleExistingContainer compares only this label
+// against a freshly computed hash of the desired spec, instead of deep
+// comparing every field Docker reports back -- a field the daemon fills in
+// with a default the caller never set (or a new field a daemon upgrade
+// starts reporting) can't produce a false "needs recreate" the way the old
+// field-by-field compare could.
+const ConfigHashLabel = "toolhive.config-hash"
+
+// debugConfigCompareEnvVar, when set to any non-empty value, makes
+// handleExistingContainer also run the legacy deep-compare alongside the
+// config-hash check and log a warning if they disagree. It exists purely
+// for diagnosing a hash that's drifted (e.g. across a Docker API upgrade)
+// and never affects the reuse decision itself.
+const debugConfigCompareEnvVar = "TOOLHIVE_DEBUG_CONFIG_COMPARE"
+
+// hashedConfig is the normalized, order-independent view of a container's
+// spec that ConfigHashLabel is computed over. Fields that are volatile --
+// assigned by the daemon, or that vary run to run without describing a
+// meaningful configuration difference -- are deliberately left out rather
+// than hashed and ignored, so adding a field here is an explicit decision
+// to make it part of the identity a reused container must match.
+type hashedConfig struct {
+	Image        string            `json:"image"`
+	Cmd          []string          `json:"cmd,omitempty"`
+	Env          []string          `json:"env,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	AttachStdin  bool              `json:"attachStdin"`
+	AttachStdout bool              `json:"attachStdout"`
+	AttachStderr bool              `json:"attachStderr"`
+	OpenStdin    bool              `json:"openStdin"`
+
+	NetworkMode   string                         `json:"networkMode"`
+	CapAdd        []string                       `json:"capAdd,omitempty"`
+	CapDrop       []string                       `json:"capDrop,omitempty"`
+	SecurityOpt   []string                       `json:"securityOpt,omitempty"`
+	RestartPolicy string                         `json:"restartPolicy"`
+	Mounts        []hashedMount                  `json:"mounts,omitempty"`
+	ExposedPorts  []string                       `json:"exposedPorts,omitempty"`
+	PortBindings  map[string][]hashedPortBinding `json:"portBindings,omitempty"`
+}
+
+// hashedMount is the normalized view of a single mount.Mount entry.
+type hashedMount struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"readOnly"`
+}
+
+// hashedPortBinding is the normalized view of a single nat.PortBinding entry.
+type hashedPortBinding struct {
+	HostIP   string `json:"hostIP"`
+	HostPort string `json:"hostPort"`
+}
+
+// computeConfigHash returns the hex-encoded SHA-256 digest of config and
+// hostConfig's normalized form. It ignores ConfigHashLabel itself (so
+// computing the hash, stamping it into config.Labels, then recomputing it
+// later for comparison is stable) and is deterministic regardless of map
+// iteration order or the order callers happened to build slices in.
+func computeConfigHash(config *container.Config, hostConfig *container.HostConfig) (string, error) {
+	normalized := hashedConfig{
+		Image:         config.Image,
+		Cmd:           append([]string{}, config.Cmd...),
+		Env:           sortedCopy(config.Env),
+		Labels:        withoutHashLabel(config.Labels),
+		AttachStdin:   config.AttachStdin,
+		AttachStdout:  config.AttachStdout,
+		AttachStderr:  config.AttachStderr,
+		OpenStdin:     config.OpenStdin,
+		NetworkMode:   string(hostConfig.NetworkMode),
+		CapAdd:        sortedCopy(hostConfig.CapAdd),
+		CapDrop:       sortedCopy(hostConfig.CapDrop),
+		SecurityOpt:   sortedCopy(hostConfig.SecurityOpt),
+		RestartPolicy: hostConfig.RestartPolicy.Name,
+		Mounts:        normalizeMounts(hostConfig.Mounts),
+		ExposedPorts:  sortedPortSet(config.ExposedPorts),
+		PortBindings:  normalizePortBindings(hostConfig.PortBindings),
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// withoutHashLabel returns a copy of labels with ConfigHashLabel removed, so
+// a label map that already carries a (stale) hash from a previous
+// createContainer call doesn't get baked into its own replacement.
+func withoutHashLabel(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(labels))
+	for k, v := range labels {
+		if k == ConfigHashLabel {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// sortedCopy returns a sorted copy of s, so two slices holding the same
+// elements in a different order hash identically.
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	result := append([]string{}, s...)
+	sort.Strings(result)
+	return result
+}
+
+// sortedPortSet renders a nat.PortSet as a sorted slice of its string forms.
+func sortedPortSet(ports nat.PortSet) []string {
+	if len(ports) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(ports))
+	for port := range ports {
+		result = append(result, string(port))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// normalizeMounts renders mounts sorted by target, the key they're looked
+// up by at reuse time.
+func normalizeMounts(mounts []mount.Mount) []hashedMount {
+	if len(mounts) == 0 {
+		return nil
+	}
+	result := make([]hashedMount, 0, len(mounts))
+	for _, m := range mounts {
+		result = append(result, hashedMount{Source: m.Source, Target: m.Target, ReadOnly: m.ReadOnly})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Target < result[j].Target })
+	return result
+}
+
+// normalizePortBindings renders a nat.PortMap with each port's bindings
+// sorted, so two equivalent binding lists built in a different order hash
+// identically; map keys are marshaled in sorted order by encoding/json.
+func normalizePortBindings(bindings nat.PortMap) map[string][]hashedPortBinding {
+	if len(bindings) == 0 {
+		return nil
+	}
+	result := make(map[string][]hashedPortBinding, len(bindings))
+	for port, portBindings := range bindings {
+		hashed := make([]hashedPortBinding, 0, len(portBindings))
+		for _, b := range portBindings {
+			hashed = append(hashed, hashedPortBinding{HostIP: b.HostIP, HostPort: b.HostPort})
+		}
+		sort.Slice(hashed, func(i, j int) bool {
+			if hashed[i].HostIP != hashed[j].HostIP {
+				return hashed[i].HostIP < hashed[j].HostIP
+			}
+			return hashed[i].HostPort < hashed[j].HostPort
+		})
+		result[string(port)] = hashed
+	}
+	return result
+}
+
+// configHashDebugEnabled reports whether the legacy deep-compare diagnostic
+// is enabled via debugConfigCompareEnvVar.
+func configHashDebugEnabled() bool {
+	return os.Getenv(debugConfigCompareEnvVar) != ""
+}
+
+// logConfigHashDisagreement runs the legacy deep-compare and logs a warning
+// if it disagrees with the config-hash reuse decision, for diagnosing a
+// hash that's drifted without letting the deep-compare affect behavior.
+func logConfigHashDisagreement(containerID string, hashMatches bool, existing *container.InspectResponse, desired *container.Config, desiredHost *container.HostConfig) {
+	deepMatches := compareContainerConfig(existing, desired, desiredHost)
+	if deepMatches != hashMatches {
+		logger.Warnf(
+			"config-hash reuse decision (%v) disagrees with legacy deep comparison (%v) for container %s",
+			hashMatches, deepMatches, containerID,
+		)
+	}
+}
+
+// reusePolicyMatches applies reuse's policy to decide whether existing can
+// be reused in place of desired: Always reuses whatever is already there
+// regardless of its hash, Never always recreates, and IfMatches (the
+// default) reuses only when ConfigHashLabel matches.
+func reusePolicyMatches(reuse runtime.ReusePolicy, existingLabels, desiredLabels map[string]string) bool {
+	switch reuse {
+	case runtime.ReuseAlways:
+		return true
+	case runtime.ReuseNever:
+		return false
+	case runtime.ReuseIfMatches, "":
+		fallthrough
+	default:
+		existingHash := existingLabels[ConfigHashLabel]
+		return existingHash != "" && existingHash == desiredLabels[ConfigHashLabel]
+	}
+}
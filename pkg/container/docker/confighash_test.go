@@ -0,0 +1,167 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+)
+
+func baseConfig() (*container.Config, *container.HostConfig) {
+	cfg := &container.Config{
+		Image: "example.com/image:latest",
+		Cmd:   []string{"serve", "--port", "8080"},
+		Env:   []string{"FOO=bar", "BAZ=qux"},
+		Labels: map[string]string{
+			"toolhive": "true",
+		},
+		ExposedPorts: nat.PortSet{
+			"8080/tcp": struct{}{},
+		},
+	}
+	hostCfg := &container.HostConfig{
+		NetworkMode: "bridge",
+		CapAdd:      []string{"NET_ADMIN", "SYS_TIME"},
+		Mounts: []mount.Mount{
+			{Source: "/host/data", Target: "/data", ReadOnly: true},
+			{Source: "/host/cache", Target: "/cache"},
+		},
+		PortBindings: nat.PortMap{
+			"8080/tcp": []nat.PortBinding{
+				{HostIP: "0.0.0.0", HostPort: "8080"},
+			},
+		},
+	}
+	return cfg, hostCfg
+}
+
+func TestComputeConfigHashStableAcrossSliceAndMapOrder(t *testing.T) {
+	t.Parallel()
+
+	cfg, hostCfg := baseConfig()
+	want, err := computeConfigHash(cfg, hostCfg)
+	require.NoError(t, err)
+
+	// Rebuild with env, CapAdd, and mount order shuffled -- none of this
+	// should change the hash.
+	cfg2, hostCfg2 := baseConfig()
+	cfg2.Env = []string{"BAZ=qux", "FOO=bar"}
+	hostCfg2.CapAdd = []string{"SYS_TIME", "NET_ADMIN"}
+	hostCfg2.Mounts = []mount.Mount{
+		{Source: "/host/cache", Target: "/cache"},
+		{Source: "/host/data", Target: "/data", ReadOnly: true},
+	}
+
+	got, err := computeConfigHash(cfg2, hostCfg2)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "reordering slices/maps that don't change meaning must not change the hash")
+}
+
+func TestComputeConfigHashIgnoresItsOwnLabel(t *testing.T) {
+	t.Parallel()
+
+	cfg, hostCfg := baseConfig()
+	want, err := computeConfigHash(cfg, hostCfg)
+	require.NoError(t, err)
+
+	// Simulate createContainer having already stamped a (now stale) hash
+	// label from a previous call -- recomputing must ignore it, so hashing
+	// is stable across repeated stamp/recompute cycles.
+	cfg.Labels[ConfigHashLabel] = "stale-hash-from-a-previous-call"
+
+	got, err := computeConfigHash(cfg, hostCfg)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestComputeConfigHashDetectsMeaningfulDifferences(t *testing.T) {
+	t.Parallel()
+
+	base, baseHost := baseConfig()
+	baseHash, err := computeConfigHash(base, baseHost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		mutate func(cfg *container.Config, hostCfg *container.HostConfig)
+	}{
+		{
+			name: "image changes",
+			mutate: func(cfg *container.Config, _ *container.HostConfig) {
+				cfg.Image = "example.com/image:v2"
+			},
+		},
+		{
+			name: "env value changes",
+			mutate: func(cfg *container.Config, _ *container.HostConfig) {
+				cfg.Env = []string{"FOO=different", "BAZ=qux"}
+			},
+		},
+		{
+			name: "mount target changes",
+			mutate: func(_ *container.Config, hostCfg *container.HostConfig) {
+				hostCfg.Mounts[0].Target = "/elsewhere"
+			},
+		},
+		{
+			name: "port binding host port changes",
+			mutate: func(_ *container.Config, hostCfg *container.HostConfig) {
+				hostCfg.PortBindings["8080/tcp"][0].HostPort = "9090"
+			},
+		},
+		{
+			name: "network mode changes",
+			mutate: func(_ *container.Config, hostCfg *container.HostConfig) {
+				hostCfg.NetworkMode = "host"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, hostCfg := baseConfig()
+			tt.mutate(cfg, hostCfg)
+
+			got, err := computeConfigHash(cfg, hostCfg)
+			require.NoError(t, err)
+			assert.NotEqual(t, baseHash, got, "expected this change to produce a different hash")
+		})
+	}
+}
+
+func TestReusePolicyMatches(t *testing.T) {
+	t.Parallel()
+
+	matchingLabels := map[string]string{ConfigHashLabel: "abc123"}
+	mismatchedLabels := map[string]string{ConfigHashLabel: "def456"}
+	noHashLabels := map[string]string{}
+
+	tests := []struct {
+		name      string
+		reuse     runtime.ReusePolicy
+		existing  map[string]string
+		desired   map[string]string
+		wantReuse bool
+	}{
+		{name: "always reuses even on mismatch", reuse: runtime.ReuseAlways, existing: mismatchedLabels, desired: matchingLabels, wantReuse: true},
+		{name: "never recreates even on match", reuse: runtime.ReuseNever, existing: matchingLabels, desired: matchingLabels, wantReuse: false},
+		{name: "if-matches reuses on matching hash", reuse: runtime.ReuseIfMatches, existing: matchingLabels, desired: matchingLabels, wantReuse: true},
+		{name: "if-matches recreates on mismatched hash", reuse: runtime.ReuseIfMatches, existing: mismatchedLabels, desired: matchingLabels, wantReuse: false},
+		{name: "if-matches recreates when existing has no hash", reuse: runtime.ReuseIfMatches, existing: noHashLabels, desired: matchingLabels, wantReuse: false},
+		{name: "empty policy defaults to if-matches", reuse: runtime.ReusePolicy(""), existing: matchingLabels, desired: matchingLabels, wantReuse: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantReuse, reusePolicyMatches(tt.reuse, tt.existing, tt.desired))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// CopyToWorkload tars up hostPath (a file or directory) and extracts it into
+// containerPath inside workloadID's rootfs, the way `docker cp`/`podman cp`
+// do -- used to seed an MCP workload with config files, credentials, or
+// corpora before it starts handling requests.
+func (c *Client) CopyToWorkload(ctx context.Context, workloadID, hostPath, containerPath string, opts runtime.CopyOptions) error {
+	destPath, err := resolveCopyPath(containerPath)
+	if err != nil {
+		return NewContainerError(err, workloadID, fmt.Sprintf("invalid destination path %q", containerPath))
+	}
+
+	hostInfo, err := os.Stat(hostPath)
+	if err != nil {
+		return NewContainerError(err, workloadID, fmt.Sprintf("failed to stat host path %q", hostPath))
+	}
+
+	if destStat, statErr := c.client.ContainerStatPath(ctx, workloadID, destPath); statErr == nil {
+		if destStat.Mode.IsDir() != hostInfo.IsDir() && !opts.Overwrite {
+			return NewContainerError(ErrCopyPathConflict, workloadID,
+				fmt.Sprintf("%q (%s) and %q (%s) are not both files or both directories",
+					hostPath, entryKind(hostInfo.IsDir()), destPath, entryKind(destStat.Mode.IsDir())))
+		}
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeCopyTar(pw, hostPath, hostInfo, opts.FollowSymlinks))
+	}()
+	defer pr.Close()
+
+	if err := c.client.CopyToContainer(ctx, workloadID, destPath, pr, container.CopyToContainerOptions{
+		AllowOverwriteDirWithFile: opts.Overwrite,
+		CopyUIDGID:                opts.PreserveOwnership,
+	}); err != nil {
+		return NewContainerError(err, workloadID, fmt.Sprintf("failed to copy %q to %q: %v", hostPath, destPath, err))
+	}
+
+	return nil
+}
+
+// CopyFromWorkload extracts containerPath from workloadID's rootfs and
+// writes it to hostPath, the mirror of CopyToWorkload -- used to pull
+// results, logs, or generated artifacts out of an MCP workload after a run.
+func (c *Client) CopyFromWorkload(ctx context.Context, workloadID, containerPath, hostPath string, opts runtime.CopyOptions) error {
+	reader, err := c.CopyFromWorkloadStream(ctx, workloadID, containerPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err := extractCopyTar(reader, hostPath, opts); err != nil {
+		return NewContainerError(err, workloadID, fmt.Sprintf("failed to extract %q to %q: %v", containerPath, hostPath, err))
+	}
+	return nil
+}
+
+// CopyFromWorkloadStream is the streaming variant of CopyFromWorkload for
+// large exports: it hands back the raw tar stream straight from the runtime
+// instead of buffering it to disk first, leaving extraction to the caller.
+func (c *Client) CopyFromWorkloadStream(ctx context.Context, workloadID, containerPath string) (io.ReadCloser, error) {
+	srcPath, err := resolveCopyPath(containerPath)
+	if err != nil {
+		return nil, NewContainerError(err, workloadID, fmt.Sprintf("invalid source path %q", containerPath))
+	}
+
+	reader, _, err := c.client.CopyFromContainer(ctx, workloadID, srcPath)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, NewContainerError(ErrContainerNotFound, workloadID, fmt.Sprintf("source path %q not found", srcPath))
+		}
+		return nil, NewContainerError(err, workloadID, fmt.Sprintf("failed to copy %q from workload: %v", srcPath, err))
+	}
+
+	return reader, nil
+}
+
+// resolveCopyPath cleans an in-workload path and rejects any attempt to
+// escape the workload rootfs via "..".
+func resolveCopyPath(containerPath string) (string, error) {
+	if containerPath == "" {
+		return "", fmt.Errorf("%w: empty path", ErrInvalidCopyPath)
+	}
+
+	cleaned := filepath.Clean(containerPath)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCopyPath, containerPath)
+	}
+	if !filepath.IsAbs(cleaned) {
+		cleaned = "/" + cleaned
+	}
+
+	return cleaned, nil
+}
+
+// entryKind renders isDir as the word used in CopyToWorkload/CopyFromWorkload
+// conflict error messages.
+func entryKind(isDir bool) string {
+	if isDir {
+		return "a directory"
+	}
+	return "a file"
+}
+
+// writeCopyTar tars up hostPath for CopyToWorkload, following symlinks when
+// followSymlinks is set rather than archiving them as links.
+func writeCopyTar(w io.Writer, hostPath string, info os.FileInfo, followSymlinks bool) error {
+	tw := tar.NewWriter(w)
+	defer tw.Close()
+
+	base := filepath.Base(hostPath)
+
+	if !info.IsDir() {
+		return writeCopyTarEntry(tw, hostPath, base, info)
+	}
+
+	return filepath.Walk(hostPath, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(hostPath, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		name := base
+		if rel != "." {
+			name = filepath.Join(base, rel)
+		}
+
+		if followSymlinks && fi.Mode()&os.ModeSymlink != 0 {
+			resolved, err := filepath.EvalSymlinks(path)
+			if err != nil {
+				return fmt.Errorf("failed to resolve symlink %q: %w", path, err)
+			}
+			path = resolved
+			if fi, err = os.Stat(resolved); err != nil {
+				return fmt.Errorf("failed to stat symlink target %q: %w", resolved, err)
+			}
+		}
+
+		return writeCopyTarEntry(tw, path, name, fi)
+	})
+}
+
+// writeCopyTarEntry writes a single file or directory entry to tw.
+func writeCopyTarEntry(tw *tar.Writer, path, name string, info os.FileInfo) error {
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return fmt.Errorf("failed to create tar header for %q: %w", path, err)
+	}
+	header.Name = name
+
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write tar header for %q: %w", name, err)
+	}
+	if info.IsDir() {
+		return nil
+	}
+
+	// #nosec G304 - path is walked from the caller-supplied hostPath
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", path, err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(tw, file); err != nil {
+		return fmt.Errorf("failed to copy contents of %q: %w", path, err)
+	}
+	return nil
+}
+
+// extractCopyTar extracts the tar stream r (as returned by
+// CopyFromWorkloadStream) into hostPath, refusing to clobber an existing
+// path unless opts.Overwrite is set.
+func extractCopyTar(r io.Reader, hostPath string, opts runtime.CopyOptions) error {
+	if _, err := os.Stat(hostPath); err == nil && !opts.Overwrite {
+		return fmt.Errorf("%w: %q already exists", ErrCopyPathConflict, hostPath)
+	}
+
+	destRoot := filepath.Clean(hostPath)
+	if err := os.MkdirAll(destRoot, 0o750); err != nil {
+		return fmt.Errorf("failed to create destination directory %q: %w", destRoot, err)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar entry: %w", err)
+		}
+
+		dest := filepath.Join(destRoot, filepath.Clean(header.Name)) //nolint:gosec // escape-checked below
+		if dest != destRoot && !strings.HasPrefix(dest, destRoot+string(filepath.Separator)) {
+			return fmt.Errorf("tar entry %q escapes destination directory", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0o750); err != nil {
+				return fmt.Errorf("failed to create directory %q: %w", dest, err)
+			}
+		case tar.TypeReg:
+			if err := extractCopyTarFile(tr, dest, header, opts.PreserveOwnership); err != nil {
+				return err
+			}
+		default:
+			// Symlinks and other special entry types aren't needed for
+			// config/artifact transfer; skip them rather than failing the
+			// whole extraction.
+			continue
+		}
+	}
+}
+
+// extractCopyTarFile writes a single regular-file tar entry to dest.
+func extractCopyTarFile(tr *tar.Reader, dest string, header *tar.Header, preserveOwnership bool) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %q: %w", dest, err)
+	}
+
+	// #nosec G304 - dest is validated in extractCopyTar before we get here
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", dest, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, tr); err != nil {
+		return fmt.Errorf("failed to write %q: %w", dest, err)
+	}
+
+	if preserveOwnership {
+		if err := os.Chown(dest, header.Uid, header.Gid); err != nil {
+			logger.Warnf("failed to preserve ownership of %q: %v", dest, err)
+		}
+	}
+
+	return nil
+}
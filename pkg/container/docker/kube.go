@@ -0,0 +1,216 @@
+package docker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// GenerateKube inspects workloadID and its egress/dns sidecars (if any) and
+// renders a Kubernetes Pod manifest describing the same workload, plus a
+// NetworkPolicy reconstructed from the egress container's squid.conf ACLs --
+// the same idea as `podman generate kube`. Each container's mounts are
+// written as volumeMounts only; the Pod gets no volumes section defining
+// them.
+func (c *Client) GenerateKube(ctx context.Context, workloadID string) ([]byte, error) {
+	inspect, err := c.client.ContainerInspect(ctx, workloadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect workload %s: %w", workloadID, err)
+	}
+	workloadName := strings.TrimPrefix(inspect.Name, "/")
+
+	var sb strings.Builder
+	sb.WriteString("apiVersion: v1\n")
+	sb.WriteString("kind: Pod\n")
+	sb.WriteString("metadata:\n")
+	fmt.Fprintf(&sb, "  name: %s\n", workloadName)
+	writeKubeLabels(&sb, "  ", inspect.Config.Labels)
+	sb.WriteString("spec:\n")
+	sb.WriteString("  containers:\n")
+	writeKubeContainer(&sb, workloadName, inspect)
+
+	egressName := fmt.Sprintf("%s-egress", workloadName)
+	egressID, err := c.findExistingContainer(ctx, egressName)
+	if err != nil {
+		logger.Warnf("failed to find egress container %s: %v", egressName, err)
+	} else if egressID != "" {
+		if egressInspect, err := c.client.ContainerInspect(ctx, egressID); err != nil {
+			logger.Warnf("failed to inspect egress container %s: %v", egressName, err)
+		} else {
+			writeKubeContainer(&sb, egressName, egressInspect)
+		}
+	}
+
+	dnsName := fmt.Sprintf("%s-dns", workloadName)
+	dnsID, err := c.findExistingContainer(ctx, dnsName)
+	if err != nil {
+		logger.Warnf("failed to find dns container %s: %v", dnsName, err)
+	} else if dnsID != "" {
+		if dnsInspect, err := c.client.ContainerInspect(ctx, dnsID); err != nil {
+			logger.Warnf("failed to inspect dns container %s: %v", dnsName, err)
+		} else {
+			writeKubeContainer(&sb, dnsName, dnsInspect)
+		}
+	}
+
+	if egressID != "" {
+		policy, err := generateKubeNetworkPolicyFromSquidConf(workloadName, egressID, c.client)
+		if err != nil {
+			logger.Warnf("failed to reconstruct NetworkPolicy from egress container %s: %v", egressName, err)
+		} else if policy != "" {
+			sb.WriteString("---\n")
+			sb.WriteString(policy)
+		}
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// writeKubeContainer appends a containers[] entry to sb for an inspected
+// container, translating its image, command, env, exposed ports, and bind
+// mounts into the equivalent Pod container fields.
+func writeKubeContainer(sb *strings.Builder, name string, inspect container.InspectResponse) {
+	fmt.Fprintf(sb, "    - name: %s\n", name)
+	fmt.Fprintf(sb, "      image: %s\n", inspect.Config.Image)
+
+	if len(inspect.Config.Cmd) > 0 {
+		sb.WriteString("      command:\n")
+		for _, part := range inspect.Config.Cmd {
+			fmt.Fprintf(sb, "        - %q\n", part)
+		}
+	}
+
+	if len(inspect.Config.Env) > 0 {
+		sb.WriteString("      env:\n")
+		for _, kv := range inspect.Config.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			fmt.Fprintf(sb, "        - name: %s\n          value: %q\n", k, v)
+		}
+	}
+
+	if len(inspect.Config.ExposedPorts) > 0 {
+		sb.WriteString("      ports:\n")
+		for port := range inspect.Config.ExposedPorts {
+			portNum, proto, _ := strings.Cut(string(port), "/")
+			containerPort, err := strconv.Atoi(portNum)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(sb, "        - containerPort: %d\n          protocol: %s\n", containerPort, strings.ToUpper(proto))
+		}
+	}
+
+	if len(inspect.Mounts) > 0 {
+		sb.WriteString("      volumeMounts:\n")
+		for _, m := range inspect.Mounts {
+			fmt.Fprintf(sb, "        - name: %s\n          mountPath: %s\n", kubeVolumeName(m.Destination), m.Destination)
+			if m.RW {
+				continue
+			}
+			sb.WriteString("          readOnly: true\n")
+		}
+	}
+}
+
+// writeKubeLabels appends a metadata.labels map to sb, one entry per
+// existing container label.
+func writeKubeLabels(sb *strings.Builder, indent string, containerLabels map[string]string) {
+	if len(containerLabels) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "%slabels:\n", indent)
+	for k, v := range containerLabels {
+		fmt.Fprintf(sb, "%s  %s: %q\n", indent, k, v)
+	}
+}
+
+// kubeVolumeName turns a mount path into a DNS-1123-ish label Kubernetes
+// accepts as a volume name.
+func kubeVolumeName(mountPath string) string {
+	name := strings.Trim(mountPath, "/")
+	name = strings.ReplaceAll(name, "/", "-")
+	name = strings.ReplaceAll(name, ".", "-")
+	if name == "" {
+		name = "root"
+	}
+	return name
+}
+
+// generateKubeNetworkPolicyFromSquidConf reads the AllowHost/AllowPort ACLs
+// createTempSquidConf baked into the egress container's mounted squid.conf
+// and translates them back into a NetworkPolicy egress rule -- the
+// permission profile itself isn't retained anywhere past deploy time, so
+// this is the only place those rules still exist once a workload is
+// running.
+func generateKubeNetworkPolicyFromSquidConf(workloadName, egressID string, dockerClient *client.Client) (string, error) {
+	inspect, err := dockerClient.ContainerInspect(context.Background(), egressID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect egress container: %w", err)
+	}
+
+	var squidConfPath string
+	for _, m := range inspect.Mounts {
+		if m.Destination == "/etc/squid/squid.conf" {
+			squidConfPath = m.Source
+			break
+		}
+	}
+	if squidConfPath == "" {
+		return "", nil
+	}
+
+	f, err := os.Open(squidConfPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read squid.conf: %w", err)
+	}
+	defer f.Close()
+
+	var allowHosts, allowPorts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "acl allowed_dsts dstdomain "):
+			allowHosts = strings.Fields(strings.TrimPrefix(line, "acl allowed_dsts dstdomain "))
+		case strings.HasPrefix(line, "acl allowed_ports port "):
+			allowPorts = strings.Fields(strings.TrimPrefix(line, "acl allowed_ports port "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to scan squid.conf: %w", err)
+	}
+
+	if len(allowHosts) == 0 && len(allowPorts) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("apiVersion: networking.k8s.io/v1\n")
+	sb.WriteString("kind: NetworkPolicy\n")
+	sb.WriteString("metadata:\n")
+	fmt.Fprintf(&sb, "  name: %s-egress\n", workloadName)
+	sb.WriteString("spec:\n")
+	fmt.Fprintf(&sb, "  podSelector:\n    matchLabels:\n      toolhive-main-workload: \"true\"\n")
+	sb.WriteString("  policyTypes:\n    - Egress\n")
+	sb.WriteString("  egress:\n")
+	sb.WriteString("    - to:\n")
+	for _, host := range allowHosts {
+		fmt.Fprintf(&sb, "        - ipBlock:\n            cidr: %s/32\n", host)
+	}
+	if len(allowPorts) > 0 {
+		sb.WriteString("      ports:\n")
+		for _, port := range allowPorts {
+			fmt.Fprintf(&sb, "        - port: %s\n          protocol: TCP\n", port)
+		}
+	}
+
+	return sb.String(), nil
+}
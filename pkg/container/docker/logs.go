@@ -0,0 +1,177 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// StreamWorkloadLogs demultiplexes workloadID's stdout/stderr into a channel
+// of LogEntry, replacing the old approach of io.Copy-ing to os.Stdout and
+// then separately io.ReadAll-ing the same reader -- which both broke
+// follow=true and threw away Docker's 8-byte stream-framing header that
+// distinguishes stdout from stderr on non-TTY containers.
+//
+// The returned channel is closed when ctx is cancelled, the workload's log
+// stream ends (opts.Follow false), or the workload exits (opts.Follow true).
+func (c *Client) StreamWorkloadLogs(ctx context.Context, workloadID string, opts runtime.LogOptions) (<-chan runtime.LogEntry, error) {
+	tail := "all"
+	if opts.Tail > 0 {
+		tail = strconv.Itoa(opts.Tail)
+	}
+
+	since := ""
+	if !opts.Since.IsZero() {
+		since = opts.Since.Format(time.RFC3339Nano)
+	}
+	until := ""
+	if !opts.Until.IsZero() {
+		until = opts.Until.Format(time.RFC3339Nano)
+	}
+
+	logs, err := c.client.ContainerLogs(ctx, workloadID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Tail:       tail,
+		Since:      since,
+		Until:      until,
+		Timestamps: opts.Timestamps,
+	})
+	if err != nil {
+		return nil, NewContainerError(err, workloadID, fmt.Sprintf("failed to stream workload logs: %v", err))
+	}
+
+	entries := make(chan runtime.LogEntry)
+	go demuxWorkloadLogs(ctx, workloadID, logs, opts.Timestamps, entries)
+
+	return entries, nil
+}
+
+// demuxWorkloadLogs runs stdcopy.StdCopy (the same demultiplexer the docker
+// CLI and moby use) against a pair of pipes so each stream's lines can be
+// turned into LogEntry values as they arrive, instead of buffering the
+// entire log into memory before returning anything.
+func demuxWorkloadLogs(ctx context.Context, workloadID string, logs io.ReadCloser, timestamps bool, entries chan<- runtime.LogEntry) {
+	defer close(entries)
+	defer logs.Close()
+
+	stdoutR, stdoutW := io.Pipe()
+	stderrR, stderrW := io.Pipe()
+
+	demuxDone := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(stdoutW, stderrW, logs)
+		stdoutW.CloseWithError(err)
+		stderrW.CloseWithError(err)
+		demuxDone <- err
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go scanLogStream(ctx, &wg, stdoutR, runtime.Stdout, timestamps, entries)
+	go scanLogStream(ctx, &wg, stderrR, runtime.Stderr, timestamps, entries)
+	wg.Wait()
+
+	if err := <-demuxDone; err != nil && err != io.EOF {
+		logger.Warnf("failed to demultiplex logs for workload %s: %v", workloadID, err)
+	}
+}
+
+// scanLogStream reads newline-delimited lines from r and emits one LogEntry
+// per line on entries until r is exhausted or ctx is cancelled.
+func scanLogStream(
+	ctx context.Context, wg *sync.WaitGroup, r io.Reader, stream runtime.LogStream, timestamps bool, entries chan<- runtime.LogEntry,
+) {
+	defer wg.Done()
+	// Closing the pipe's read end on an early return makes a StdCopy write
+	// blocked on it fail instead of hanging the demultiplexer.
+	if rc, ok := r.(io.Closer); ok {
+		defer rc.Close()
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		entry := runtime.LogEntry{Stream: stream}
+
+		if timestamps {
+			if ts, rest, ok := splitLogTimestamp(line); ok {
+				entry.Timestamp = ts
+				line = rest
+			}
+		}
+		entry.Line = append([]byte(nil), line...)
+
+		select {
+		case entries <- entry:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// splitLogTimestamp splits a Docker-timestamped log line ("<RFC3339Nano>
+// <rest of line>") into its timestamp and the remaining content.
+func splitLogTimestamp(line []byte) (time.Time, []byte, bool) {
+	idx := bytes.IndexByte(line, ' ')
+	if idx < 0 {
+		return time.Time{}, line, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, string(line[:idx]))
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return ts, line[idx+1:], true
+}
+
+// GetWorkloadLogs is a thin wrapper over StreamWorkloadLogs for callers that
+// just want workloadID's combined stdout/stderr as text, rather than a
+// structured stream.
+func (c *Client) GetWorkloadLogs(ctx context.Context, workloadID string, follow bool) (string, error) {
+	entries, err := c.StreamWorkloadLogs(ctx, workloadID, runtime.LogOptions{Follow: follow, Tail: 100})
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	for entry := range entries {
+		buf.Write(entry.Line)
+		buf.WriteByte('\n')
+	}
+
+	return buf.String(), nil
+}
+
+// PipeWorkloadLogs drains workloadID's log stream into sink as entries
+// arrive, rather than into a channel the caller must itself consume. This
+// is what a long-running MCP workload should use instead of GetWorkloadLogs
+// with follow=true, which would otherwise accumulate the entire log history
+// in memory for the lifetime of the workload.
+func (c *Client) PipeWorkloadLogs(ctx context.Context, workloadID string, opts runtime.LogOptions, sink runtime.LogSink) error {
+	entries, err := c.StreamWorkloadLogs(ctx, workloadID, opts)
+	if err != nil {
+		return err
+	}
+
+	for entry := range entries {
+		if err := sink.Write(entry); err != nil {
+			return fmt.Errorf("failed to write log entry for workload %s: %w", workloadID, err)
+		}
+	}
+
+	return nil
+}
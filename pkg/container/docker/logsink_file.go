@@ -0,0 +1,153 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+)
+
+// defaultMaxLogFileBytes is the size a FileLogSink rotates at when no
+// explicit MaxBytes is given.
+const defaultMaxLogFileBytes = 10 * 1024 * 1024
+
+// FileLogSink writes a workload's log entries to a file, rotating to
+// path.1, path.2, ... once the active file passes MaxBytes, so a
+// long-running MCP workload streamed with PipeWorkloadLogs can't grow its
+// log file without bound.
+type FileLogSink struct {
+	// MaxBytes is the size the active log file rotates at. Zero uses
+	// defaultMaxLogFileBytes.
+	MaxBytes int64
+	// MaxBackups is how many rotated files (path.1, path.2, ...) are kept
+	// before the oldest is deleted. Zero keeps all of them.
+	MaxBackups int
+
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	written int64
+}
+
+// NewFileLogSink opens (creating if necessary) path for appending and
+// returns a FileLogSink that writes to it, rotating once it passes
+// maxBytes (0 for the default).
+func NewFileLogSink(path string, maxBytes int64, maxBackups int) (*FileLogSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create log directory for %q: %w", path, err)
+	}
+
+	// #nosec G304 - path is an operator-supplied log destination, not user input
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file %q: %w", path, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat log file %q: %w", path, err)
+	}
+
+	return &FileLogSink{
+		MaxBytes:   maxBytes,
+		MaxBackups: maxBackups,
+		path:       path,
+		file:       file,
+		written:    info.Size(),
+	}, nil
+}
+
+// Write appends entry's line to the active log file, rotating first if it
+// would push the file past MaxBytes.
+func (s *FileLogSink) Write(entry runtime.LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	maxBytes := s.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultMaxLogFileBytes
+	}
+
+	if s.written > 0 && s.written+int64(len(entry.Line))+1 > maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(append(entry.Line, '\n'))
+	s.written += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write log entry to %q: %w", s.path, err)
+	}
+	return nil
+}
+
+// rotate renames the active file to path.1 (shifting existing path.N
+// backups up by one, dropping anything past MaxBackups), then opens a fresh
+// empty file at path.
+func (s *FileLogSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file %q before rotating: %w", s.path, err)
+	}
+
+	if s.MaxBackups > 0 {
+		oldest := fmt.Sprintf("%s.%d", s.path, s.MaxBackups)
+		if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove oldest log backup %q: %w", oldest, err)
+		}
+	}
+	top := s.MaxBackups
+	if top <= 0 {
+		// Zero keeps every backup: shift all existing path.N files up.
+		for top = 1; ; top++ {
+			if _, err := os.Stat(fmt.Sprintf("%s.%d", s.path, top)); err != nil {
+				break
+			}
+		}
+	}
+	for n := top - 1; n >= 1; n-- {
+		from := fmt.Sprintf("%s.%d", s.path, n)
+		to := fmt.Sprintf("%s.%d", s.path, n+1)
+		if err := os.Rename(from, to); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate log backup %q to %q: %w", from, to, err)
+		}
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file %q: %w", s.path, err)
+	}
+
+	// #nosec G304 - s.path is the same operator-supplied destination passed to NewFileLogSink
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to reopen log file %q after rotating: %w", s.path, err)
+	}
+	s.file = file
+	s.written = 0
+	return nil
+}
+
+// Close closes the active log file.
+func (s *FileLogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+var _ runtime.LogSink = (*FileLogSink)(nil)
+
+// NewDefaultFileLogSink returns a FileLogSink for workloadName at its
+// default location and rotation settings, for callers that don't need to
+// customize either.
+func NewDefaultFileLogSink(workloadName string) (*FileLogSink, error) {
+	return NewFileLogSink(defaultLogFilePath(workloadName), defaultMaxLogFileBytes, 5)
+}
+
+// defaultLogFilePath returns the path a workload's rotating log file lives
+// at when the caller doesn't supply one explicitly, alongside the overlay
+// scratch state under the same XDG state directory.
+func defaultLogFilePath(workloadName string) string {
+	return filepath.Join(toolhiveStateDir("logs"), workloadName+".log")
+}
@@ -0,0 +1,60 @@
+//go:build linux
+
+package docker
+
+import (
+	"fmt"
+
+	"github.com/coreos/go-systemd/v22/journal"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+)
+
+// JournaldLogSink writes a workload's log entries to the systemd journal
+// instead of a file, tagged with the workload's name and which stream each
+// entry came from -- useful on hosts where journald, not a log file, is the
+// operator's log aggregation point.
+type JournaldLogSink struct {
+	workloadName string
+}
+
+// NewJournaldLogSink returns a JournaldLogSink for workloadName, failing up
+// front if this host has no journald to write to.
+func NewJournaldLogSink(workloadName string) (*JournaldLogSink, error) {
+	if ok, err := journal.StatusCheck(); !ok {
+		return nil, fmt.Errorf("journald is not available on this host: %w", err)
+	}
+	return &JournaldLogSink{workloadName: workloadName}, nil
+}
+
+// Write sends entry to the journal at priority info (stdout) or warning
+// (stderr), tagged with the workload's name via the SYSLOG_IDENTIFIER field.
+func (s *JournaldLogSink) Write(entry runtime.LogEntry) error {
+	priority := journal.PriInfo
+	if entry.Stream == runtime.Stderr {
+		priority = journal.PriWarning
+	}
+
+	fields := map[string]string{
+		"SYSLOG_IDENTIFIER": s.workloadName,
+		"TOOLHIVE_STREAM":   streamLabel(entry.Stream),
+	}
+
+	return journal.Send(string(entry.Line), priority, fields)
+}
+
+// Close is a no-op: journald has no per-writer handle to release.
+func (*JournaldLogSink) Close() error {
+	return nil
+}
+
+// streamLabel renders stream as the word used in the TOOLHIVE_STREAM
+// journal field.
+func streamLabel(stream runtime.LogStream) string {
+	if stream == runtime.Stderr {
+		return "stderr"
+	}
+	return "stdout"
+}
+
+var _ runtime.LogSink = (*JournaldLogSink)(nil)
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package docker
+
+import "fmt"
+
+// JournaldLogSink is unavailable outside Linux; NewJournaldLogSink always
+// fails so callers fall back to FileLogSink instead.
+type JournaldLogSink struct{}
+
+// NewJournaldLogSink always returns an error on this platform: journald is
+// Linux-only.
+func NewJournaldLogSink(string) (*JournaldLogSink, error) {
+	return nil, fmt.Errorf("journald logging is not supported on this platform")
+}
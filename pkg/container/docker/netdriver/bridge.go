@@ -0,0 +1,119 @@
+package netdriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+
+	lb "github.com/stacklok/toolhive/pkg/labels"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// BridgeDriver is the default Driver, using Docker's built-in bridge
+// network driver. It preserves toolhive's original hardcoded networking
+// behavior: an internal, egress-only network per workload plus a shared
+// external network for containers that need outbound access.
+type BridgeDriver struct {
+	client *client.Client
+}
+
+// NewBridgeDriver creates a BridgeDriver that operates through dockerClient.
+func NewBridgeDriver(dockerClient *client.Client) *BridgeDriver {
+	return &BridgeDriver{client: dockerClient}
+}
+
+// Name implements Driver.
+func (*BridgeDriver) Name() string { return "bridge" }
+
+// EnsureNetwork implements Driver, creating cfg.NetworkName as a plain
+// Docker bridge network if it doesn't already exist. An existing network
+// is reused only if verifyAdoptable accepts it.
+func (d *BridgeDriver) EnsureNetwork(ctx context.Context, cfg EndpointConfig) (bool, error) {
+	found, err := findUniqueNetwork(ctx, d.client, cfg.NetworkName)
+	if err == nil {
+		if err := verifyAdoptable(found, cfg, d.Name()); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	if !errors.Is(err, ErrNetworkNotFound) {
+		return false, fmt.Errorf("failed to look up network %s: %w", cfg.NetworkName, err)
+	}
+
+	labels := map[string]string{toolhiveNetworkLabel: "true"}
+	lb.AddNetworkLabels(labels, cfg.NetworkName)
+
+	_, err = d.client.NetworkCreate(ctx, cfg.NetworkName, network.CreateOptions{
+		Driver:   "bridge",
+		Internal: cfg.Internal,
+		Labels:   labels,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteNetwork implements Driver. It leaves networks toolhive didn't create
+// (i.e. adopted via EndpointConfig.AdoptForeign) alone, since deleting a
+// network out from under whatever else owns it is worse than leaking a
+// toolhive-managed one.
+func (d *BridgeDriver) DeleteNetwork(ctx context.Context, networkName string) error {
+	found, err := findUniqueNetwork(ctx, d.client, networkName)
+	if err != nil {
+		if errors.Is(err, ErrNetworkNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !isToolhiveNetwork(found) {
+		logger.Infof("network %q was adopted rather than created by toolhive, skipping delete", networkName)
+		return nil
+	}
+
+	// Remove by ID, resolved above, rather than by name: a name re-lookup
+	// at removal time would race a network being renamed or recreated
+	// between the two calls.
+	if err := d.client.NetworkRemove(ctx, found.ID); err != nil {
+		return fmt.Errorf("failed to remove network %s: %w", networkName, err)
+	}
+	return nil
+}
+
+// EndpointConfig implements Driver: Docker's bridge driver needs no
+// per-attachment configuration beyond naming the network.
+func (*BridgeDriver) EndpointConfig(_ EndpointConfig) *network.EndpointSettings {
+	return &network.EndpointSettings{}
+}
+
+// PostStart implements Driver; attachment already happened via
+// EndpointConfig, so there's nothing left to do.
+func (*BridgeDriver) PostStart(_ context.Context, _ string, _ EndpointConfig) error {
+	return nil
+}
+
+// Detach implements Driver.
+func (d *BridgeDriver) Detach(ctx context.Context, networkName, workloadID string) error {
+	if err := d.client.NetworkDisconnect(ctx, networkName, workloadID, true); err != nil {
+		logger.Warnf("failed to disconnect %s from network %s: %v", workloadID, networkName, err)
+	}
+	return nil
+}
+
+// ResolveIP implements Driver by inspecting the workload's container and
+// reading the IP Docker's bridge driver assigned it on networkName.
+func (d *BridgeDriver) ResolveIP(ctx context.Context, networkName, workloadID string) (string, error) {
+	inspect, err := d.client.ContainerInspect(ctx, workloadID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect workload %s: %w", workloadID, err)
+	}
+
+	settings, ok := inspect.NetworkSettings.Networks[networkName]
+	if !ok {
+		return "", fmt.Errorf("network %s not found in workload %s's network settings", networkName, workloadID)
+	}
+	return settings.IPAddress, nil
+}
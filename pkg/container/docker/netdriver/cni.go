@@ -0,0 +1,206 @@
+package netdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// cniVersion is the CNI spec version toolhive speaks to plugin binaries.
+const cniVersion = "1.0.0"
+
+// cniNetConf is the network configuration JSON piped to a CNI plugin's
+// stdin, per the CNI spec's Network Configuration format.
+type cniNetConf struct {
+	CNIVersion string `json:"cniVersion"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	IPAM       *struct {
+		Type    string `json:"type"`
+		Subnet  string `json:"subnet,omitempty"`
+		Gateway string `json:"gateway,omitempty"`
+	} `json:"ipam,omitempty"`
+}
+
+// cniResult is the subset of the CNI spec's Result type toolhive reads back
+// off a plugin's stdout.
+type cniResult struct {
+	IPs []struct {
+		Address string `json:"address"`
+	} `json:"ips"`
+}
+
+// CNIDriver attaches workloads to an external network by shelling out to a
+// CNI plugin binary, mirroring the real moby -> libnetwork split: rather
+// than going through Docker's own NetworkingConfig, it plumbs the
+// container's network namespace directly once it's running, the same way a
+// Kubernetes CNI plugin attaches a pod's sandbox. This lets toolhive
+// delegate networking to whatever CNI plugin a cluster already runs instead
+// of being locked to Docker's built-in drivers.
+type CNIDriver struct {
+	client *client.Client
+	// pluginPath is the CNI plugin binary to invoke, e.g.
+	// "/opt/cni/bin/bridge".
+	pluginPath string
+	// pluginType is the "type" field of the network config handed to the
+	// plugin, e.g. "bridge" or "macvlan".
+	pluginType string
+	// cniPath is the value of the CNI_PATH env var passed to the plugin, so
+	// it can locate delegate plugins (e.g. an IPAM plugin) of its own.
+	cniPath string
+}
+
+// NewCNIDriver creates a CNIDriver that invokes the plugin binary at
+// pluginPath (of CNI type pluginType) to attach workloads to networks, with
+// cniPath passed through as CNI_PATH for the plugin's own delegate lookups.
+func NewCNIDriver(dockerClient *client.Client, pluginPath, pluginType, cniPath string) *CNIDriver {
+	return &CNIDriver{
+		client:     dockerClient,
+		pluginPath: pluginPath,
+		pluginType: pluginType,
+		cniPath:    cniPath,
+	}
+}
+
+// Name implements Driver.
+func (*CNIDriver) Name() string { return "cni" }
+
+// EnsureNetwork implements Driver. CNI plugins manage their own networks on
+// ADD, so there's no separate network object to create up front; created is
+// always false.
+func (*CNIDriver) EnsureNetwork(_ context.Context, _ EndpointConfig) (bool, error) {
+	return false, nil
+}
+
+// DeleteNetwork implements Driver. CNI networks are torn down per-attachment
+// (via Detach's DEL), not as a standalone object, so this is a no-op.
+func (*CNIDriver) DeleteNetwork(_ context.Context, _ string) error {
+	return nil
+}
+
+// EndpointConfig implements Driver by returning nil: CNI attachment happens
+// out of band in PostStart, after the container (and its network
+// namespace) already exists, not through Docker's NetworkingConfig.
+func (*CNIDriver) EndpointConfig(_ EndpointConfig) *network.EndpointSettings {
+	return nil
+}
+
+// PostStart implements Driver by running the CNI plugin's ADD command
+// against containerID's network namespace.
+func (d *CNIDriver) PostStart(ctx context.Context, containerID string, cfg EndpointConfig) error {
+	netnsPath, err := d.netnsPath(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve network namespace for %s: %w", containerID, err)
+	}
+
+	_, err = d.runPlugin(ctx, "ADD", containerID, netnsPath, cfg)
+	return err
+}
+
+// Detach implements Driver by running the CNI plugin's DEL command against
+// workloadID's network namespace.
+func (d *CNIDriver) Detach(ctx context.Context, networkName, workloadID string) error {
+	netnsPath, err := d.netnsPath(ctx, workloadID)
+	if err != nil {
+		// The container (and so its namespace) may already be gone; that's
+		// the outcome DEL would produce anyway.
+		logger.Warnf("failed to resolve network namespace for %s, skipping CNI DEL: %v", workloadID, err)
+		return nil
+	}
+
+	_, err = d.runPlugin(ctx, "DEL", workloadID, netnsPath, EndpointConfig{NetworkName: networkName})
+	return err
+}
+
+// ResolveIP implements Driver by re-running the plugin's ADD command, which
+// CNI plugins must answer idempotently with the same result for an
+// already-attached interface, and reading the IP back out of the result.
+func (d *CNIDriver) ResolveIP(ctx context.Context, networkName, workloadID string) (string, error) {
+	netnsPath, err := d.netnsPath(ctx, workloadID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve network namespace for %s: %w", workloadID, err)
+	}
+
+	result, err := d.runPlugin(ctx, "ADD", workloadID, netnsPath, EndpointConfig{NetworkName: networkName})
+	if err != nil {
+		return "", err
+	}
+	if len(result.IPs) == 0 {
+		return "", fmt.Errorf("CNI plugin returned no IPs for %s", workloadID)
+	}
+	return result.IPs[0].Address, nil
+}
+
+// netnsPath resolves containerID's network namespace path from its PID, the
+// same way Kubernetes' CNI integration derives a pod sandbox's namespace.
+func (d *CNIDriver) netnsPath(ctx context.Context, containerID string) (string, error) {
+	inspect, err := d.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect container %s: %w", containerID, err)
+	}
+	if inspect.State == nil || inspect.State.Pid == 0 {
+		return "", fmt.Errorf("container %s has no running process", containerID)
+	}
+	return fmt.Sprintf("/proc/%d/ns/net", inspect.State.Pid), nil
+}
+
+// runPlugin invokes the CNI plugin binary with the given CNI_COMMAND against
+// ifName "eth0" in netnsPath, feeding it cfg's network config as JSON on
+// stdin per the CNI spec, and returns its parsed result.
+func (d *CNIDriver) runPlugin(
+	ctx context.Context, command, containerID, netnsPath string, cfg EndpointConfig,
+) (*cniResult, error) {
+	netConf := cniNetConf{
+		CNIVersion: cniVersion,
+		Name:       cfg.NetworkName,
+		Type:       d.pluginType,
+	}
+	if cfg.Subnet != "" {
+		netConf.IPAM = &struct {
+			Type    string `json:"type"`
+			Subnet  string `json:"subnet,omitempty"`
+			Gateway string `json:"gateway,omitempty"`
+		}{Type: "host-local", Subnet: cfg.Subnet, Gateway: cfg.Gateway}
+	}
+
+	stdin, err := json.Marshal(netConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal CNI network config: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, d.pluginPath) //nolint:gosec // pluginPath is operator configuration, not user input
+	cmd.Env = append(os.Environ(),
+		"CNI_COMMAND="+command,
+		"CNI_CONTAINERID="+containerID,
+		"CNI_NETNS="+netnsPath,
+		"CNI_IFNAME=eth0",
+		"CNI_PATH="+d.cniPath,
+	)
+	cmd.Stdin = bytes.NewReader(stdin)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("CNI plugin %s %s failed: %w: %s", d.pluginPath, command, err, stderr.String())
+	}
+
+	if command == "DEL" {
+		return &cniResult{}, nil
+	}
+
+	var result cniResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse CNI plugin result: %w", err)
+	}
+	return &result, nil
+}
@@ -0,0 +1,61 @@
+package netdriver
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// HostDriver puts a workload directly on the host's own network namespace
+// (Docker's "host" NetworkMode), for rootless/netns-shared environments
+// where a container's own namespace isn't available. There's no network
+// object to create or attach to -- the container simply forgoes one of its
+// own -- so every lifecycle method beyond Name is a no-op.
+type HostDriver struct {
+	client *client.Client
+}
+
+// NewHostDriver creates a HostDriver that operates through dockerClient.
+func NewHostDriver(dockerClient *client.Client) *HostDriver {
+	return &HostDriver{client: dockerClient}
+}
+
+// Name implements Driver.
+func (*HostDriver) Name() string { return "host" }
+
+// EnsureNetwork implements Driver. Host networking has no network object of
+// its own to create.
+func (*HostDriver) EnsureNetwork(_ context.Context, _ EndpointConfig) (bool, error) {
+	return false, nil
+}
+
+// DeleteNetwork implements Driver. There's nothing for EnsureNetwork to have
+// created, so there's nothing to remove.
+func (*HostDriver) DeleteNetwork(_ context.Context, _ string) error {
+	return nil
+}
+
+// EndpointConfig implements Driver by returning nil: host networking is
+// selected via container.HostConfig.NetworkMode, not a Docker endpoint
+// attachment.
+func (*HostDriver) EndpointConfig(_ EndpointConfig) *network.EndpointSettings {
+	return nil
+}
+
+// PostStart implements Driver; there's no attachment to perform.
+func (*HostDriver) PostStart(_ context.Context, _ string, _ EndpointConfig) error {
+	return nil
+}
+
+// Detach implements Driver; a host-networked workload was never attached to
+// networkName in the first place.
+func (*HostDriver) Detach(_ context.Context, _, _ string) error {
+	return nil
+}
+
+// ResolveIP implements Driver by reporting loopback: a host-networked
+// workload is reachable on every address the host itself answers on.
+func (*HostDriver) ResolveIP(_ context.Context, _, _ string) (string, error) {
+	return "127.0.0.1", nil
+}
@@ -0,0 +1,108 @@
+package netdriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// ErrNetworkNotFound is returned by findUniqueNetwork when nameOrID matches
+// no network.
+var ErrNetworkNotFound = errors.New("network not found")
+
+// ErrAmbiguousNetwork is returned by findUniqueNetwork when nameOrID's name
+// filter matches more than one network, so the caller can refuse to guess
+// rather than silently acting on networks[0].
+var ErrAmbiguousNetwork = errors.New("ambiguous network name")
+
+// ErrForeignNetwork is returned by EnsureNetwork when a network with the
+// requested name already exists but its driver, Internal flag, or toolhive
+// labels don't match what was asked for, and EndpointConfig.AdoptForeign
+// wasn't set to allow reusing it anyway.
+var ErrForeignNetwork = errors.New("network exists with a different configuration")
+
+// toolhiveNetworkLabel is the label lb.AddNetworkLabels stamps on every
+// network toolhive creates. Its presence (and value) on an existing
+// network is what distinguishes "toolhive made this" from "this just
+// happens to have the name we wanted".
+const toolhiveNetworkLabel = "toolhive"
+
+// isToolhiveNetwork reports whether found carries toolhiveNetworkLabel, i.e.
+// whether toolhive itself created it via NetworkCreate rather than adopting
+// a pre-existing network via EndpointConfig.AdoptForeign. Unlike
+// EnsureNetwork's in-memory created return value, this is readable from the
+// network object itself, so it's what DeleteNetwork checks -- teardown
+// routinely runs in a later, separate invocation than the EnsureNetwork call
+// that (maybe) created the network, with no created bool to consult.
+func isToolhiveNetwork(found network.Inspect) bool {
+	return found.Labels[toolhiveNetworkLabel] == "true"
+}
+
+// verifyAdoptable checks that an existing network found for cfg.NetworkName
+// is safe to reuse: its driver and Internal flag must match, and it must
+// carry toolhive's own network label, unless cfg.AdoptForeign says to reuse
+// it regardless.
+func verifyAdoptable(found network.Inspect, cfg EndpointConfig, wantDriver string) error {
+	if cfg.AdoptForeign {
+		return nil
+	}
+
+	var mismatches []string
+	if found.Driver != wantDriver {
+		mismatches = append(mismatches, fmt.Sprintf("driver %q (want %q)", found.Driver, wantDriver))
+	}
+	if found.Internal != cfg.Internal {
+		mismatches = append(mismatches, fmt.Sprintf("internal=%v (want %v)", found.Internal, cfg.Internal))
+	}
+	if found.Labels[toolhiveNetworkLabel] != "true" {
+		mismatches = append(mismatches, "missing toolhive label")
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: network %q: %s", ErrForeignNetwork, cfg.NetworkName, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// findUniqueNetwork resolves nameOrID to exactly one network, the way
+// `docker network rm`/`docker network connect` do, rather than trusting
+// Docker's NetworkList name filter -- which matches by substring and can
+// return multiple networks -- to already have picked the right one.
+//
+// It first tries nameOrID as an exact ID (or ID prefix); if that doesn't
+// match, it falls back to listing by name and requires exactly one result
+// whose Name is an exact match, since a name filter for "foo" also matches
+// a pre-existing "foo-bar" network. This guards against a toolhive workload
+// named "foo" silently attaching to, or removing, a network it was never
+// meant to touch.
+func findUniqueNetwork(ctx context.Context, dockerClient *client.Client, nameOrID string) (network.Inspect, error) {
+	if inspect, err := dockerClient.NetworkInspect(ctx, nameOrID, network.InspectOptions{}); err == nil {
+		return inspect, nil
+	}
+
+	networks, err := dockerClient.NetworkList(ctx, network.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("name", nameOrID)),
+	})
+	if err != nil {
+		return network.Inspect{}, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	var match *network.Inspect
+	for i := range networks {
+		if networks[i].Name != nameOrID {
+			continue
+		}
+		if match != nil {
+			return network.Inspect{}, fmt.Errorf("%w: %q matches both %s and %s", ErrAmbiguousNetwork, nameOrID, match.ID, networks[i].ID)
+		}
+		match = &networks[i]
+	}
+	if match == nil {
+		return network.Inspect{}, fmt.Errorf("%w: %q", ErrNetworkNotFound, nameOrID)
+	}
+	return *match, nil
+}
@@ -0,0 +1,131 @@
+package netdriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+
+	lb "github.com/stacklok/toolhive/pkg/labels"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// MacvlanDriver attaches workloads to a macvlan network built on top of a
+// host interface (EndpointConfig.Parent), giving each workload its own MAC
+// and IP on the physical network instead of sharing Docker's NATed bridge.
+// This is how operators put egress workloads on an isolated VLAN.
+type MacvlanDriver struct {
+	client *client.Client
+}
+
+// NewMacvlanDriver creates a MacvlanDriver that operates through dockerClient.
+func NewMacvlanDriver(dockerClient *client.Client) *MacvlanDriver {
+	return &MacvlanDriver{client: dockerClient}
+}
+
+// Name implements Driver.
+func (*MacvlanDriver) Name() string { return "macvlan" }
+
+// EnsureNetwork implements Driver, creating a macvlan network parented on
+// cfg.Parent with cfg.Subnet/cfg.Gateway as its IPAM config. An existing
+// network is reused only if verifyAdoptable accepts it.
+func (d *MacvlanDriver) EnsureNetwork(ctx context.Context, cfg EndpointConfig) (bool, error) {
+	if cfg.Parent == "" {
+		return false, fmt.Errorf("macvlan network %s requires a parent interface", cfg.NetworkName)
+	}
+	if cfg.Subnet == "" {
+		return false, fmt.Errorf("macvlan network %s requires a subnet", cfg.NetworkName)
+	}
+
+	found, err := findUniqueNetwork(ctx, d.client, cfg.NetworkName)
+	if err == nil {
+		if err := verifyAdoptable(found, cfg, d.Name()); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	if !errors.Is(err, ErrNetworkNotFound) {
+		return false, fmt.Errorf("failed to look up network %s: %w", cfg.NetworkName, err)
+	}
+
+	labels := map[string]string{toolhiveNetworkLabel: "true"}
+	lb.AddNetworkLabels(labels, cfg.NetworkName)
+
+	_, err = d.client.NetworkCreate(ctx, cfg.NetworkName, network.CreateOptions{
+		Driver:   "macvlan",
+		Internal: cfg.Internal,
+		Options:  map[string]string{"parent": cfg.Parent},
+		IPAM: &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: cfg.Subnet, Gateway: cfg.Gateway}},
+		},
+		Labels: labels,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteNetwork implements Driver. It leaves networks toolhive didn't create
+// (i.e. adopted via EndpointConfig.AdoptForeign) alone, since deleting a
+// network out from under whatever else owns it is worse than leaking a
+// toolhive-managed one.
+func (d *MacvlanDriver) DeleteNetwork(ctx context.Context, networkName string) error {
+	found, err := findUniqueNetwork(ctx, d.client, networkName)
+	if err != nil {
+		if errors.Is(err, ErrNetworkNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !isToolhiveNetwork(found) {
+		logger.Infof("network %q was adopted rather than created by toolhive, skipping delete", networkName)
+		return nil
+	}
+
+	// Remove by ID, resolved above, rather than by name: a name re-lookup
+	// at removal time would race a network being renamed or recreated
+	// between the two calls.
+	if err := d.client.NetworkRemove(ctx, found.ID); err != nil {
+		return fmt.Errorf("failed to remove network %s: %w", networkName, err)
+	}
+	return nil
+}
+
+// EndpointConfig implements Driver: like the bridge driver, macvlan
+// attachment happens through Docker's own NetworkingConfig at container
+// creation time.
+func (*MacvlanDriver) EndpointConfig(_ EndpointConfig) *network.EndpointSettings {
+	return &network.EndpointSettings{}
+}
+
+// PostStart implements Driver; attachment already happened via
+// EndpointConfig, so there's nothing left to do.
+func (*MacvlanDriver) PostStart(_ context.Context, _ string, _ EndpointConfig) error {
+	return nil
+}
+
+// Detach implements Driver.
+func (d *MacvlanDriver) Detach(ctx context.Context, networkName, workloadID string) error {
+	if err := d.client.NetworkDisconnect(ctx, networkName, workloadID, true); err != nil {
+		logger.Warnf("failed to disconnect %s from network %s: %v", workloadID, networkName, err)
+	}
+	return nil
+}
+
+// ResolveIP implements Driver by inspecting the workload's container and
+// reading the IP macvlan's IPAM assigned it on networkName.
+func (d *MacvlanDriver) ResolveIP(ctx context.Context, networkName, workloadID string) (string, error) {
+	inspect, err := d.client.ContainerInspect(ctx, workloadID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect workload %s: %w", workloadID, err)
+	}
+
+	settings, ok := inspect.NetworkSettings.Networks[networkName]
+	if !ok {
+		return "", fmt.Errorf("network %s not found in workload %s's network settings", networkName, workloadID)
+	}
+	return settings.IPAddress, nil
+}
@@ -0,0 +1,89 @@
+// Package netdriver defines a pluggable network backend for the Docker
+// runtime. Client.createContainerNetworks and Client.DeployWorkload used to
+// hardcode a two-network bridge topology on Docker's built-in bridge
+// driver; they now go through whichever Driver is configured, so operators
+// can put workloads on an isolated macvlan VLAN or hand networking off to
+// an existing cluster CNI plugin instead.
+package netdriver
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// EndpointConfig describes how a single workload attaches to a single
+// network. Fields beyond NetworkName/Internal are only meaningful to
+// drivers that use them (e.g. Parent for macvlan).
+type EndpointConfig struct {
+	// NetworkName is the name of the network to create/attach to.
+	NetworkName string
+	// Internal marks the network as having no external (outbound)
+	// connectivity, as toolhive's per-workload "internal" network does.
+	Internal bool
+	// Parent is the host interface a macvlan network is built on top of,
+	// e.g. "eth0". Ignored by drivers that don't need one.
+	Parent string
+	// Subnet and Gateway configure IPAM for drivers that require a
+	// user-specified subnet (macvlan, CNI). Ignored by drivers that let
+	// Docker's default bridge IPAM pick one.
+	Subnet  string
+	Gateway string
+	// AdoptForeign allows EnsureNetwork to reuse an existing network whose
+	// driver, Internal flag, or labels don't match this EndpointConfig,
+	// instead of failing. Without it, a name collision with a network
+	// toolhive didn't create (or created with different settings) is
+	// treated as a configuration error rather than silently reused.
+	AdoptForeign bool
+}
+
+// Driver abstracts how workload networks are created, attached to, and
+// torn down, so the Docker runtime isn't locked to Docker's built-in
+// bridge driver. Bridge and macvlan drivers attach containers through
+// Docker's native NetworkingConfig; the CNI driver instead plumbs the
+// container's network namespace directly after it starts, mirroring the
+// real moby -> libnetwork split, where CNI plugins operate below Docker's
+// own IPAM rather than through it.
+type Driver interface {
+	// Name identifies the driver, e.g. for logging and for
+	// permissions.NetworkPermissions.Driver selection.
+	Name() string
+
+	// EnsureNetwork creates cfg's network if it doesn't already exist. If a
+	// network with this name already exists, it's reused only if its
+	// driver, Internal flag, and toolhive labels match cfg (or cfg.AdoptForeign
+	// is set); otherwise EnsureNetwork fails rather than silently attaching
+	// to an unrelated network. created reports whether EnsureNetwork itself
+	// created the network, as opposed to reusing one that was already there.
+	EnsureNetwork(ctx context.Context, cfg EndpointConfig) (created bool, err error)
+
+	// DeleteNetwork removes a network created by EnsureNetwork. Drivers
+	// treat "already gone" as success, since teardown races with other
+	// workloads releasing the same shared network. Teardown runs as its own,
+	// later invocation with no created bool to consult, so drivers that
+	// create real Docker networks (bridge, macvlan) instead check the
+	// network's own toolhive label at delete time and leave an adopted
+	// network alone rather than deleting it out from under whatever else
+	// owns it.
+	DeleteNetwork(ctx context.Context, networkName string) error
+
+	// EndpointConfig returns the Docker API endpoint settings to attach a
+	// container to cfg's network at creation time, or nil if this driver
+	// attaches out of band in PostStart instead.
+	EndpointConfig(cfg EndpointConfig) *network.EndpointSettings
+
+	// PostStart runs after containerID has started, for drivers that
+	// attach to the network namespace directly rather than through
+	// Docker's own NetworkingConfig. It's a no-op for drivers whose
+	// EndpointConfig returned non-nil.
+	PostStart(ctx context.Context, containerID string, cfg EndpointConfig) error
+
+	// Detach disconnects workloadID from networkName, treating "already
+	// detached" as success.
+	Detach(ctx context.Context, networkName, workloadID string) error
+
+	// ResolveIP returns the IP address workloadID is reachable at on
+	// networkName, e.g. so DeployWorkload can point sibling containers' DNS
+	// at it.
+	ResolveIP(ctx context.Context, networkName, workloadID string) (string, error)
+}
@@ -0,0 +1,61 @@
+package netdriver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// NoneDriver puts a workload on Docker's "none" NetworkMode, giving it no
+// network access at all beyond loopback. Like HostDriver, there's no network
+// object to create or attach to, so every lifecycle method beyond Name is a
+// no-op.
+type NoneDriver struct {
+	client *client.Client
+}
+
+// NewNoneDriver creates a NoneDriver that operates through dockerClient.
+func NewNoneDriver(dockerClient *client.Client) *NoneDriver {
+	return &NoneDriver{client: dockerClient}
+}
+
+// Name implements Driver.
+func (*NoneDriver) Name() string { return "none" }
+
+// EnsureNetwork implements Driver. "none" networking has no network object
+// of its own to create.
+func (*NoneDriver) EnsureNetwork(_ context.Context, _ EndpointConfig) (bool, error) {
+	return false, nil
+}
+
+// DeleteNetwork implements Driver. There's nothing for EnsureNetwork to have
+// created, so there's nothing to remove.
+func (*NoneDriver) DeleteNetwork(_ context.Context, _ string) error {
+	return nil
+}
+
+// EndpointConfig implements Driver by returning nil: "none" networking is
+// selected via container.HostConfig.NetworkMode, not a Docker endpoint
+// attachment.
+func (*NoneDriver) EndpointConfig(_ EndpointConfig) *network.EndpointSettings {
+	return nil
+}
+
+// PostStart implements Driver; there's no attachment to perform.
+func (*NoneDriver) PostStart(_ context.Context, _ string, _ EndpointConfig) error {
+	return nil
+}
+
+// Detach implements Driver; a "none"-networked workload was never attached
+// to networkName in the first place.
+func (*NoneDriver) Detach(_ context.Context, _, _ string) error {
+	return nil
+}
+
+// ResolveIP implements Driver. A "none"-networked workload has no IP any
+// other container could reach it at.
+func (*NoneDriver) ResolveIP(_ context.Context, _, workloadID string) (string, error) {
+	return "", fmt.Errorf("workload %s has no network (NetworkMode none)", workloadID)
+}
@@ -0,0 +1,33 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stacklok/toolhive/pkg/container/docker/netdriver"
+)
+
+// CreateNetwork creates (or reuses, if one by this name already exists and
+// matches) a private bridge network that a set of related but independent
+// workloads can be attached to via ConnectNetwork -- e.g. a retrieval tool
+// and its vector-DB sidecar that need to reach each other by container name
+// but don't need the full namespace sharing (and loopback addressing)
+// CreatePod's members get. internal, when true, gives the network no route
+// out to the host's own network, matching the isolation of toolhive's
+// per-workload internal networks.
+func (c *Client) CreateNetwork(ctx context.Context, name string, internal bool) (string, error) {
+	driver := netdriver.NewBridgeDriver(c.client)
+	if _, err := driver.EnsureNetwork(ctx, netdriver.EndpointConfig{NetworkName: name, Internal: internal}); err != nil {
+		return "", fmt.Errorf("failed to create network %q: %w", name, err)
+	}
+	return name, nil
+}
+
+// ConnectNetwork attaches an already-created container to a network created
+// by CreateNetwork.
+func (c *Client) ConnectNetwork(ctx context.Context, networkName, containerID string) error {
+	if err := c.client.NetworkConnect(ctx, networkName, containerID, nil); err != nil {
+		return fmt.Errorf("failed to connect container %s to network %s: %w", containerID, networkName, err)
+	}
+	return nil
+}
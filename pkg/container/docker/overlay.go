@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+	"github.com/stacklok/toolhive/pkg/permissions"
+)
+
+// overlayMount is one resolved overlay mount: a per-workload scratch
+// directory (an overlayfs merged view where the platform supports it, or a
+// plain copy of the lowerdir where it doesn't) that gets bind-mounted into
+// the container in place of the original host path.
+type overlayMount struct {
+	// Target is the in-container mount point.
+	Target string
+	// MergedDir is the host directory bind-mounted at Target.
+	MergedDir string
+	// stateDir is this mount's per-workload scratch directory (upperdir,
+	// workdir, and merged, or just a plain copy), removed on teardown.
+	stateDir string
+}
+
+// overlayStateDir is the root directory per-workload overlay scratch state
+// is created under.
+func overlayStateDir() string {
+	return toolhiveStateDir("overlays")
+}
+
+// addOverlayMounts resolves each overlay-tagged mount declaration in mounts
+// into a copy-on-write view of its host path and appends the result to
+// config.Mounts as an ordinary read-write bind mount, so the workload gets a
+// private, mutable copy of the source tree without ever touching the user's
+// files on disk.
+func (*Client) addOverlayMounts(
+	config *runtime.PermissionConfig, mounts []permissions.MountDeclaration, workloadName string,
+) ([]overlayMount, error) {
+	var resolved []overlayMount
+
+	for i, mountDecl := range mounts {
+		source, target, err := mountDecl.Parse()
+		if err != nil {
+			logger.Warnf("Warning: Skipping invalid overlay mount declaration: %s (%v)", mountDecl, err)
+			continue
+		}
+
+		absSource, ok := convertRelativePathToAbsolute(source, mountDecl)
+		if !ok {
+			continue
+		}
+
+		base := filepath.Join(overlayStateDir(), workloadName, strconv.Itoa(i))
+		om, err := mountOverlay(base, absSource)
+		if err != nil {
+			removeOverlayMounts(resolved)
+			return nil, fmt.Errorf("failed to set up overlay mount for %s: %w", mountDecl, err)
+		}
+		om.Target = target
+		resolved = append(resolved, om)
+
+		config.Mounts = append(config.Mounts, runtime.Mount{
+			Source:   om.MergedDir,
+			Target:   target,
+			ReadOnly: false,
+		})
+	}
+
+	return resolved, nil
+}
+
+// removeOverlayMounts tears down a partially-created set of overlay mounts,
+// used when addOverlayMounts fails partway through setting one up. It's
+// best-effort: a failure to tear down one overlay doesn't stop the others
+// from being cleaned up.
+func removeOverlayMounts(mounts []overlayMount) {
+	for _, om := range mounts {
+		if err := unmountOverlay(om.MergedDir); err != nil {
+			logger.Warnf("failed to unmount overlay %s: %v", om.MergedDir, err)
+		}
+		if err := os.RemoveAll(om.stateDir); err != nil {
+			logger.Warnf("failed to remove overlay state directory %s: %v", om.stateDir, err)
+		}
+	}
+}
+
+// removeWorkloadOverlays tears down every overlay mount workloadName was
+// given. Unlike removeOverlayMounts it doesn't need the in-memory list from
+// deploy time -- each workload's overlays all live under a directory keyed
+// only by its name, so RemoveWorkload (running long after DeployWorkload
+// returned) can rediscover and clean them up from that convention alone.
+func removeWorkloadOverlays(workloadName string) {
+	root := filepath.Join(overlayStateDir(), workloadName)
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warnf("failed to list overlay state for %s: %v", workloadName, err)
+		}
+		return
+	}
+
+	for _, entry := range entries {
+		mergedDir := filepath.Join(root, entry.Name(), "merged")
+		if err := unmountOverlay(mergedDir); err != nil {
+			logger.Warnf("failed to unmount overlay %s: %v", mergedDir, err)
+		}
+	}
+
+	if err := os.RemoveAll(root); err != nil {
+		logger.Warnf("failed to remove overlay state for %s: %v", workloadName, err)
+	}
+}
+
+// copyTree recursively copies srcDir's contents into dstDir. It's the
+// non-overlayfs fallback: a plain, independent copy the workload can freely
+// mutate without ever touching the original host path.
+func copyTree(srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		dest := filepath.Join(dstDir, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(dest, 0o700)
+		}
+
+		// #nosec G304 - path is walked from the caller-supplied srcDir
+		src, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer src.Close()
+
+		// #nosec G304 - dest is derived from the walk above, rooted at dstDir
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", dest, err)
+		}
+		defer out.Close()
+
+		_, err = io.Copy(out, src)
+		return err
+	})
+}
@@ -0,0 +1,44 @@
+//go:build linux
+
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// mountOverlay creates base/{upper,work,merged} and mounts an overlayfs at
+// base/merged with lowerDir as the read-only lower layer -- the same
+// upperdir/workdir/merged layout buildah's pkg/overlay uses. If the mount
+// itself fails (old kernel, hardened/sandboxed host), it falls back to a
+// plain copy rather than failing the whole deployment.
+func mountOverlay(base, lowerDir string) (overlayMount, error) {
+	upperDir := filepath.Join(base, "upper")
+	workDir := filepath.Join(base, "work")
+	mergedDir := filepath.Join(base, "merged")
+
+	for _, dir := range []string{upperDir, workDir, mergedDir} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return overlayMount{}, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", lowerDir, upperDir, workDir)
+	if err := unix.Mount("overlay", mergedDir, "overlay", 0, opts); err != nil {
+		if copyErr := copyTree(lowerDir, mergedDir); copyErr != nil {
+			return overlayMount{}, fmt.Errorf("overlayfs mount failed (%v) and fallback copy failed: %w", err, copyErr)
+		}
+	}
+
+	return overlayMount{MergedDir: mergedDir, stateDir: base}, nil
+}
+
+// unmountOverlay unmounts an overlayfs previously mounted by mountOverlay.
+// Unmounting a directory that was never actually mounted (the fallback-copy
+// path) just returns an error, which callers log and ignore.
+func unmountOverlay(mergedDir string) error {
+	return unix.Unmount(mergedDir, 0)
+}
@@ -0,0 +1,28 @@
+//go:build !linux
+
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// mountOverlay falls back to copying lowerDir into a scratch directory on
+// platforms with no overlayfs -- macOS, Windows, and Docker Desktop's Linux
+// VM boundary all land here.
+func mountOverlay(base, lowerDir string) (overlayMount, error) {
+	mergedDir := filepath.Join(base, "merged")
+	if err := os.MkdirAll(mergedDir, 0o700); err != nil {
+		return overlayMount{}, fmt.Errorf("failed to create %s: %w", mergedDir, err)
+	}
+	if err := copyTree(lowerDir, mergedDir); err != nil {
+		return overlayMount{}, fmt.Errorf("failed to copy %s: %w", lowerDir, err)
+	}
+	return overlayMount{MergedDir: mergedDir, stateDir: base}, nil
+}
+
+// unmountOverlay is a no-op here since mountOverlay never mounts anything.
+func unmountOverlay(string) error {
+	return nil
+}
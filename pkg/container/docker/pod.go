@@ -0,0 +1,127 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+
+	"github.com/stacklok/toolhive/pkg/container/docker/netdriver"
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	lb "github.com/stacklok/toolhive/pkg/labels"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// podInfraContainerName returns the name of the infra container that owns
+// workloadName's shared network namespace.
+func podInfraContainerName(workloadName string) string {
+	return fmt.Sprintf("%s-pod", workloadName)
+}
+
+// createPodInfraContainer creates and starts the tiny infra container that
+// owns a workload's pod: its network namespace is shared by the workload,
+// its egress container, and its DNS container (via
+// container.NetworkMode("container:<infraID>")), the same role Kubernetes'
+// pause container plays for a pod's sandbox. The infra container itself is
+// the only one attached to the shared network directly, so exposed ports
+// and port bindings for the whole pod are configured on it rather than on
+// the workload container that joins its namespace.
+func (c *Client) createPodInfraContainer(
+	ctx context.Context, driver netdriver.Driver, workloadName string,
+	portBindings map[string][]runtime.PortBinding, exposedPorts map[string]struct{}, extraLabels map[string]string,
+) (string, error) {
+	infraContainerName := podInfraContainerName(workloadName)
+
+	infraLabels := map[string]string{}
+	for k, v := range extraLabels {
+		infraLabels[k] = v
+	}
+	lb.AddStandardLabels(infraLabels, infraContainerName, infraContainerName, "stdio", 80)
+
+	config := &container.Config{
+		Image:  PauseImage,
+		Labels: infraLabels,
+	}
+	hostConfig := &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{Name: "unless-stopped"},
+		// Shareable, so pod members can join its IPC namespace with
+		// IpcMode "container:<infraID>".
+		IpcMode: container.IPCModeShareable,
+	}
+	if err := setupExposedPorts(config, exposedPorts); err != nil {
+		return "", NewContainerError(err, "", err.Error())
+	}
+	if err := setupPortBindings(hostConfig, portBindings); err != nil {
+		return "", NewContainerError(err, "", err.Error())
+	}
+
+	endpointsConfig := map[string]*network.EndpointSettings{}
+	externalEndpoint := netdriver.EndpointConfig{NetworkName: "toolhive-external"}
+	if cfg := driver.EndpointConfig(externalEndpoint); cfg != nil {
+		endpointsConfig["toolhive-external"] = cfg
+	}
+
+	infraContainerID, err := c.createContainer(ctx, infraContainerName, config, hostConfig, endpointsConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create pod infra container: %v", err)
+	}
+
+	if err := driver.PostStart(ctx, infraContainerID, externalEndpoint); err != nil {
+		return "", fmt.Errorf("failed to attach pod infra container to network: %v", err)
+	}
+
+	return infraContainerID, nil
+}
+
+// sharedNetnsHostConfig returns the HostConfig network mode that joins a
+// container to infraContainerID's network namespace, sharing its interfaces
+// (and so its loopback) instead of attaching to a network of its own.
+func sharedNetnsHostConfig(infraContainerID string) container.NetworkMode {
+	return container.NetworkMode("container:" + infraContainerID)
+}
+
+// removePodInfraContainer removes workloadName's infra container, logging
+// rather than failing if it's already gone.
+func (c *Client) removePodInfraContainer(ctx context.Context, workloadName string) {
+	infraContainerName := podInfraContainerName(workloadName)
+	infraContainerID, err := c.findExistingContainer(ctx, infraContainerName)
+	if err != nil {
+		logger.Warnf("Failed to find pod infra container %s: %v", infraContainerName, err)
+		return
+	}
+	if infraContainerID == "" {
+		return
+	}
+
+	if infraInspect, err := c.client.ContainerInspect(ctx, infraContainerID); err != nil {
+		logger.Warnf("Failed to inspect pod infra container %s: %v", infraContainerName, err)
+	} else if infraInspect.HostConfig != nil {
+		releasePortBindings(infraInspect.HostConfig.PortBindings)
+	}
+
+	if err := c.client.ContainerRemove(ctx, infraContainerID, container.RemoveOptions{Force: true}); err != nil {
+		logger.Warnf("Failed to remove pod infra container %s: %v", infraContainerName, err)
+	}
+}
+
+// CreatePod creates the shared infra container that owns a network (and
+// IPC) namespace, the same primitive DeployWorkload
+// uses internally to give a single MCP workload its own pod, but exposed
+// directly so a caller can group several otherwise-independent workloads
+// under one pod: pass the returned podID as DeployWorkloadOptions.PodID for
+// each member so they join it instead of getting a pod of their own, and
+// they'll be reachable from one another over loopback.
+func (c *Client) CreatePod(ctx context.Context, name string, opts runtime.PodOptions) (string, error) {
+	driver := netdriver.NewBridgeDriver(c.client)
+	if _, err := driver.EnsureNetwork(ctx, netdriver.EndpointConfig{NetworkName: "toolhive-external"}); err != nil {
+		logger.Warnf("failed to create external network %q: %v", "toolhive-external", err)
+	}
+	return c.createPodInfraContainer(ctx, driver, name, opts.PortBindings, opts.ExposedPorts, opts.Labels)
+}
+
+// RemovePod removes the infra container created by CreatePod for name,
+// logging rather than failing if it's already gone.
+func (c *Client) RemovePod(ctx context.Context, name string) {
+	c.removePodInfraContainer(ctx, name)
+}
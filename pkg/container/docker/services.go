@@ -0,0 +1,205 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	dockerimage "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	lb "github.com/stacklok/toolhive/pkg/labels"
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// serviceContainerName derives a service container's name from the
+// workload it belongs to and the name it was declared under, so
+// StopServices can find every service for a workload by listing on labels
+// without having to remember their exact names.
+func serviceContainerName(workloadName, serviceName string) string {
+	return fmt.Sprintf("%s-svc-%s", workloadName, serviceName)
+}
+
+// RunServices starts workloadName's declared service containers, in order,
+// joining each to the workload's pod by sharing its infra container's
+// network namespace (see createPodInfraContainer) the same way the egress
+// and DNS containers do. Because they share that namespace's loopback,
+// RunServices doesn't need to attach them to a network of their own: each
+// service's declared ports are simply reachable at 127.0.0.1 from the
+// workload once it joins the same namespace.
+func (c *Client) RunServices(
+	ctx context.Context, workloadName string, services []runtime.ServiceContainer,
+) ([]runtime.ServiceResult, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+
+	infraContainerID, err := c.findExistingContainer(ctx, podInfraContainerName(workloadName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pod infra container for %s: %w", workloadName, err)
+	}
+	if infraContainerID == "" {
+		return nil, fmt.Errorf("workload %s has no pod infra container to attach service containers to", workloadName)
+	}
+
+	started := make([]runtime.ServiceResult, 0, len(services))
+	for _, svc := range services {
+		result, err := c.startServiceContainer(ctx, workloadName, infraContainerID, svc)
+		if err != nil {
+			logger.Warnf("Failed to start service container %q for workload %s: %v, rolling back", svc.Name, workloadName, err)
+			for _, r := range started {
+				c.removeServiceContainer(ctx, r.ContainerID)
+			}
+			return nil, fmt.Errorf("failed to start service %q: %w", svc.Name, err)
+		}
+		started = append(started, result)
+	}
+
+	return started, nil
+}
+
+// startServiceContainer creates, starts, and (if declared) health-checks a
+// single service container, returning the result RunServices reports back
+// to the caller.
+func (c *Client) startServiceContainer(
+	ctx context.Context, workloadName, infraContainerID string, svc runtime.ServiceContainer,
+) (runtime.ServiceResult, error) {
+	containerName := serviceContainerName(workloadName, svc.Name)
+
+	if err := c.pullServiceImage(ctx, svc.Image, svc.Credentials); err != nil {
+		return runtime.ServiceResult{}, fmt.Errorf("failed to pull service image %s: %w", svc.Image, err)
+	}
+
+	labels := map[string]string{}
+	lb.AddStandardLabels(labels, containerName, containerName, "stdio", 80)
+	labels["toolhive-service"] = "true"
+	labels["toolhive-service-workload"] = workloadName
+
+	config := &container.Config{
+		Image:  svc.Image,
+		Cmd:    svc.Command,
+		Env:    convertEnvVars(svc.Env),
+		Labels: labels,
+	}
+	hostConfig := &container.HostConfig{
+		NetworkMode: sharedNetnsHostConfig(infraContainerID),
+		RestartPolicy: container.RestartPolicy{
+			Name: "unless-stopped",
+		},
+	}
+
+	containerID, err := c.createContainer(ctx, containerName, config, hostConfig, nil)
+	if err != nil {
+		return runtime.ServiceResult{}, fmt.Errorf("failed to create service container: %w", err)
+	}
+
+	if svc.HealthCheck != nil {
+		if err := c.waitForServiceHealthy(ctx, containerID, *svc.HealthCheck); err != nil {
+			c.removeServiceContainer(ctx, containerID)
+			return runtime.ServiceResult{}, err
+		}
+	}
+
+	addrs := make(map[string]string, len(svc.Ports))
+	for _, port := range svc.Ports {
+		hostPort, _, _ := strings.Cut(port, "/")
+		addrs[port] = fmt.Sprintf("127.0.0.1:%s", hostPort)
+	}
+
+	return runtime.ServiceResult{
+		Name:        svc.Name,
+		ContainerID: containerID,
+		DNSName:     svc.Name,
+		Addrs:       addrs,
+	}, nil
+}
+
+// waitForServiceHealthy runs hc.Test inside containerID until it exits
+// zero or the first attempt and hc.Retries retries after it have all failed.
+func (c *Client) waitForServiceHealthy(ctx context.Context, containerID string, hc runtime.ServiceHealthCheck) error {
+	var lastErr error
+	for attempt := 0; attempt <= hc.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(hc.Interval):
+			}
+		}
+
+		result, err := c.ExecInContainer(ctx, containerID, hc.Test, runtime.ExecOptions{})
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if result.ExitCode == 0 {
+			return nil
+		}
+		lastErr = fmt.Errorf("health check exited %d: %s", result.ExitCode, bytes.TrimSpace(result.Stderr))
+	}
+
+	return fmt.Errorf("service container %s never became healthy after %d retries: %w", containerID, hc.Retries, lastErr)
+}
+
+// pullServiceImage pulls image, authenticating against creds's registry
+// when set so a service's private image never needs credentials baked
+// into the main workload's own pull configuration.
+func (c *Client) pullServiceImage(ctx context.Context, image string, creds *runtime.RegistryCredentials) error {
+	opts := dockerimage.PullOptions{}
+	if creds != nil {
+		authConfig := registry.AuthConfig{
+			Username:      creds.Username,
+			Password:      creds.Password,
+			ServerAddress: creds.ServerAddress,
+		}
+		encoded, err := json.Marshal(authConfig)
+		if err != nil {
+			return fmt.Errorf("failed to encode registry credentials: %w", err)
+		}
+		opts.RegistryAuth = base64.URLEncoding.EncodeToString(encoded)
+	}
+
+	reader, err := c.client.ImagePull(ctx, image, opts)
+	if err != nil {
+		return NewContainerError(err, "", fmt.Sprintf("failed to pull image: %v", err))
+	}
+	defer reader.Close()
+
+	return parsePullOutput(reader, os.Stdout)
+}
+
+// removeServiceContainer force-removes a service container, logging rather
+// than failing if it's already gone -- used both by StopServices and by
+// RunServices' own rollback on a failed start.
+func (c *Client) removeServiceContainer(ctx context.Context, containerID string) {
+	if err := c.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true}); err != nil {
+		logger.Warnf("Failed to remove service container %s: %v", containerID, err)
+	}
+}
+
+// StopServices tears down every service container previously started for
+// workloadName by RunServices.
+func (c *Client) StopServices(ctx context.Context, workloadName string) error {
+	filterArgs := filters.NewArgs(
+		filters.Arg("label", "toolhive-service=true"),
+		filters.Arg("label", fmt.Sprintf("toolhive-service-workload=%s", workloadName)),
+	)
+	containers, err := c.client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		return fmt.Errorf("failed to list service containers for %s: %w", workloadName, err)
+	}
+
+	for _, svcContainer := range containers {
+		c.removeServiceContainer(ctx, svcContainer.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// toolhiveStateDir returns the root of toolhive's leaf state namespace
+// (e.g. "overlays", "logs", "checkpoints") under the user's XDG state
+// directory, falling back to $HOME and finally a temp directory when
+// neither is set.
+func toolhiveStateDir(leaf string) string {
+	if stateHome := os.Getenv("XDG_STATE_HOME"); stateHome != "" {
+		return filepath.Join(stateHome, "toolhive", leaf)
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".local", "state", "toolhive", leaf)
+	}
+	return filepath.Join(os.TempDir(), "toolhive", leaf)
+}
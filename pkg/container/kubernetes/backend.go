@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+	"github.com/StacklokLabs/toolhive/pkg/permissions"
+)
+
+var _ runtime.Backend = (*Client)(nil)
+
+// DeployWorkload implements runtime.Backend. Unlike the Docker/Podman
+// backends, Kubernetes has no separate create-then-start step: CreateContainer
+// already applies the workload's StatefulSet and waits for it to report
+// ready, so this is a thin pass-through. options is ignored and
+// CreateContainer gets nil options, so no fronting Service is created (even
+// for sse transports) and port bindings have no effect.
+func (c *Client) DeployWorkload(
+	ctx context.Context,
+	image, name string,
+	command []string,
+	envVars, containerLabels map[string]string,
+	permissionProfile *permissions.Profile,
+	transportType string,
+	_ *runtime.DeployWorkloadOptions,
+) (string, error) {
+	return c.CreateContainer(ctx, image, name, command, envVars, containerLabels, permissionProfile, transportType, nil)
+}
+
+// ListWorkloads implements runtime.Backend.
+func (c *Client) ListWorkloads(ctx context.Context) ([]runtime.ContainerInfo, error) {
+	return c.ListContainers(ctx)
+}
+
+// StopWorkload implements runtime.Backend.
+func (c *Client) StopWorkload(ctx context.Context, workloadID string) error {
+	return c.StopContainer(ctx, workloadID)
+}
+
+// RunServices implements runtime.Backend. Toolhive's egress/DNS sidecar
+// convention assumes a shared Docker/Podman network namespace that a second
+// container can join after its workload is already running; a Kubernetes
+// Pod's containers are all declared together in its spec up front, so
+// there's no equivalent "attach a sidecar to an already-running workload"
+// operation to perform here. Declare any sidecars the workload needs
+// alongside it in the Pod spec instead.
+func (*Client) RunServices(
+	_ context.Context, workloadName string, services []runtime.ServiceContainer,
+) ([]runtime.ServiceResult, error) {
+	if len(services) == 0 {
+		return nil, nil
+	}
+	return nil, fmt.Errorf(
+		"kubernetes backend does not support attaching sidecar service containers to workload %s after the fact",
+		workloadName,
+	)
+}
+
+// StopServices implements runtime.Backend; see RunServices for why
+// Kubernetes has nothing separate to tear down here.
+func (*Client) StopServices(_ context.Context, _ string) error {
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v4"
@@ -21,9 +22,11 @@ import (
 	appsv1apply "k8s.io/client-go/applyconfigurations/apps/v1"
 	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
 	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/client-go/tools/watch"
 
@@ -41,29 +44,121 @@ const (
 
 // Client implements the Runtime interface for container operations
 type Client struct {
-	runtimeType runtime.Type
-	client      *kubernetes.Clientset
+	runtimeType   runtime.Type
+	client        kubernetes.Interface
+	dynamicClient dynamic.Interface
+	restConfig    *rest.Config
+	namespace     string
+
+	forwardsMu sync.Mutex
+	forwards   map[string][]*activeForward
 }
 
-// NewClient creates a new container client
-func NewClient(_ context.Context) (*Client, error) {
-	// creates the in-cluster config
-	config, err := rest.InClusterConfig()
+// ClientOptions configures how NewClientWithConfig locates a cluster and
+// which namespace the client operates in. A zero-value ClientOptions gives
+// the same behavior as NewClient.
+type ClientOptions struct {
+	// KubeconfigPath is the path to a kubeconfig file. If empty, the
+	// KUBECONFIG environment variable and then the default loading rules
+	// (~/.kube/config) are consulted, the same as kubectl.
+	KubeconfigPath string
+	// Context is the kubeconfig context to use. If empty, the kubeconfig's
+	// current-context is used.
+	Context string
+	// Namespace overrides the namespace resolved from the kubeconfig
+	// context or the in-cluster service account.
+	Namespace string
+}
+
+// NewClient creates a new container client from the kubeconfig named by
+// KUBECONFIG or found at ~/.kube/config, or from in-cluster configuration
+// when there is none. It is equivalent to NewClientWithConfig(ctx, ClientOptions{}).
+func NewClient(ctx context.Context) (*Client, error) {
+	return NewClientWithConfig(ctx, ClientOptions{})
+}
+
+// NewClientWithConfig creates a new container client, resolving its
+// kubeconfig and target namespace from opts. When opts.KubeconfigPath is
+// empty, KUBECONFIG is unset and no ~/.kube/config exists, it falls back to
+// in-cluster configuration, so toolhive keeps working unchanged when it
+// actually runs inside a pod. This mirrors how kubectl resolves its
+// own target cluster and namespace.
+func NewClientWithConfig(_ context.Context, opts ClientOptions) (*Client, error) {
+	config, namespace, err := resolveClientConfig(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create in-cluster config: %v", err)
+		return nil, err
 	}
-	// creates the clientset
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes client: %v", err)
 	}
 
+	// The dynamic client is only used for Gateway API resources (HTTPRoute),
+	// which aren't part of client-go's typed API and ship as a CRD that may
+	// not be installed; building it here can't fail the way a typed client
+	// building against a missing API group would.
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %v", err)
+	}
+
 	return &Client{
-		runtimeType: runtime.TypeKubernetes,
-		client:      clientset,
+		runtimeType:   runtime.TypeKubernetes,
+		client:        clientset,
+		dynamicClient: dynamicClient,
+		restConfig:    config,
+		namespace:     namespace,
+		forwards:      make(map[string][]*activeForward),
 	}, nil
 }
 
+// resolveClientConfig builds a *rest.Config and resolves the target
+// namespace from opts, preferring an out-of-cluster kubeconfig when one is
+// configured or discoverable and falling back to in-cluster configuration
+// otherwise.
+func resolveClientConfig(opts ClientOptions) (*rest.Config, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if opts.KubeconfigPath != "" {
+		loadingRules.ExplicitPath = opts.KubeconfigPath
+	}
+
+	if _, err := os.Stat(loadingRules.GetDefaultFilename()); opts.KubeconfigPath == "" &&
+		os.Getenv("KUBECONFIG") == "" && err != nil {
+		// No kubeconfig was requested or discoverable; assume we're running
+		// in a pod and use the service account's in-cluster config.
+		config, err := rest.InClusterConfig()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create in-cluster config: %v", err)
+		}
+		namespace := opts.Namespace
+		if namespace == "" {
+			namespace = getCurrentNamespace()
+		}
+		return config, namespace, nil
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: opts.Context})
+
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		if ns, _, err := clientConfig.Namespace(); err == nil && ns != "" {
+			namespace = ns
+		} else {
+			namespace = "default"
+		}
+	}
+
+	return config, namespace, nil
+}
+
 // getNamespaceFromServiceAccount attempts to read the namespace from the service account token file
 func getNamespaceFromServiceAccount() (string, error) {
 	data, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
@@ -111,7 +206,7 @@ func (c *Client) AttachContainer(ctx context.Context, containerID string) (io.Wr
 	// as it requires setting up an exec session to the pod
 
 	// First, we need to find the pod associated with the containerID (which is actually the statefulset name)
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 	pods, err := c.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: fmt.Sprintf("app=%s", containerID),
 	})
@@ -138,16 +233,13 @@ func (c *Client) AttachContainer(ctx context.Context, containerID string) (io.Wr
 	req := c.client.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(podName).
-		Namespace(getCurrentNamespace()).
+		Namespace(c.namespace).
 		SubResource("attach").
 		VersionedParams(attachOpts, scheme.ParameterCodec)
 
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		panic(fmt.Errorf("failed to create k8s config: %v", err))
-	}
-	// Create a SPDY executor
-	exec, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+	// Reuse the config this Client was constructed with rather than
+	// re-resolving in-cluster config, which panics when running out-of-cluster.
+	exec, err := remotecommand.NewSPDYExecutor(c.restConfig, "POST", req.URL())
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create SPDY executor: %v", err)
 	}
@@ -199,7 +291,7 @@ func (c *Client) AttachContainer(ctx context.Context, containerID string) (io.Wr
 // ContainerLogs implements runtime.Runtime.
 func (c *Client) ContainerLogs(ctx context.Context, containerID string) (string, error) {
 	// In Kubernetes, containerID is the statefulset name
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 
 	// Get the pods associated with this statefulset
 	pods, err := c.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
@@ -240,6 +332,53 @@ func (c *Client) ContainerLogs(ctx context.Context, containerID string) (string,
 	return string(logBytes), nil
 }
 
+// StreamContainerLogs implements runtime.Runtime.
+func (c *Client) StreamContainerLogs(ctx context.Context, containerID string, opts runtime.LogOptions) (io.ReadCloser, error) {
+	podName, err := c.firstPodName(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	logOptions := &corev1.PodLogOptions{
+		Container:  containerID,
+		Follow:     opts.Follow,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.Tail > 0 {
+		tail := int64(opts.Tail)
+		logOptions.TailLines = &tail
+	}
+	if !opts.Since.IsZero() {
+		sinceTime := metav1.NewTime(opts.Since)
+		logOptions.SinceTime = &sinceTime
+	}
+
+	req := c.client.CoreV1().Pods(c.namespace).GetLogs(podName, logOptions)
+	stream, err := req.Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream logs for pod %s: %w", podName, err)
+	}
+
+	return stream, nil
+}
+
+// firstPodName returns the name of the first pod backing the statefulset
+// named containerID.
+func (c *Client) firstPodName(ctx context.Context, containerID string) (string, error) {
+	pods, err := c.client.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s", containerID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for statefulset %s: %w", containerID, err)
+	}
+
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pods found for statefulset %s", containerID)
+	}
+
+	return pods.Items[0].Name, nil
+}
+
 // CreateContainer implements runtime.Runtime.
 func (c *Client) CreateContainer(ctx context.Context,
 	image string,
@@ -247,21 +386,25 @@ func (c *Client) CreateContainer(ctx context.Context,
 	command []string,
 	envVars map[string]string,
 	containerLabels map[string]string,
-	_ *permissions.Profile, // TODO: Implement permission profile support for Kubernetes
+	permissionProfile *permissions.Profile,
 	transportType string,
 	options *runtime.CreateContainerOptions) (string, error) {
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 	containerLabels["app"] = containerName
 	containerLabels["toolhive"] = "true"
 
 	attachStdio := options == nil || options.AttachStdio
 
-	// Convert environment variables to Kubernetes format
-	var envVarList []*corev1apply.EnvVarApplyConfiguration
-	for k, v := range envVars {
-		envVarList = append(envVarList, corev1apply.EnvVar().WithName(k).WithValue(v))
+	if options != nil && len(options.SecretData) > 0 {
+		if err := c.upsertWorkloadSecret(ctx, containerName, namespace, options.SecretData); err != nil {
+			return "", err
+		}
 	}
 
+	// Convert environment variables to Kubernetes format, rendering any
+	// names in options.SecretEnvVars as SecretKeyRef instead of inline values.
+	envVarList := buildEnvVars(envVars, options)
+
 	// Create container configuration
 	containerConfig := corev1apply.Container().
 		WithName(containerName).
@@ -280,9 +423,45 @@ func (c *Client) CreateContainer(ctx context.Context,
 		}
 	}
 
+	if options != nil && options.ImagePullPolicy != "" {
+		containerConfig = containerConfig.WithImagePullPolicy(corev1.PullPolicy(options.ImagePullPolicy))
+	}
+
+	// Translate the permission profile into Pod/container-level security
+	// settings and the volumes its mount allow-list requires.
+	podSecurityContext := buildPodSecurityContext(permissionProfile)
+	if permissionProfile != nil {
+		containerConfig = containerConfig.WithSecurityContext(buildContainerSecurityContext(permissionProfile))
+	}
+	volumes, volumeMounts := buildProfileVolumes(permissionProfile)
+	if len(volumeMounts) > 0 {
+		containerConfig = containerConfig.WithVolumeMounts(volumeMounts...)
+	}
+	containerConfig = applyContainerResources(containerConfig, options)
+
+	podSpec := corev1apply.PodSpec().
+		WithContainers(containerConfig).
+		WithRestartPolicy(corev1.RestartPolicyAlways).
+		WithSecurityContext(podSecurityContext)
+	if len(volumes) > 0 {
+		podSpec = podSpec.WithVolumes(volumes...)
+	}
+	podSpec = applyPodScheduling(podSpec, options)
+
+	// Layer user-supplied scheduling labels/annotations on top of the
+	// toolhive=true/app=<name> labels set above.
+	podLabels, podAnnotations := applyPodLabelsAndAnnotations(containerLabels, options)
+
+	podTemplate := corev1apply.PodTemplateSpec().
+		WithLabels(podLabels).
+		WithSpec(podSpec)
+	if len(podAnnotations) > 0 {
+		podTemplate = podTemplate.WithAnnotations(podAnnotations)
+	}
+
 	// Create an apply configuration for the statefulset
 	statefulSetApply := appsv1apply.StatefulSet(containerName, namespace).
-		WithLabels(containerLabels).
+		WithLabels(podLabels).
 		WithSpec(appsv1apply.StatefulSetSpec().
 			WithReplicas(1).
 			WithSelector(metav1apply.LabelSelector().
@@ -290,11 +469,7 @@ func (c *Client) CreateContainer(ctx context.Context,
 					"app": containerName,
 				})).
 			WithServiceName(containerName).
-			WithTemplate(corev1apply.PodTemplateSpec().
-				WithLabels(containerLabels).
-				WithSpec(corev1apply.PodSpec().
-					WithContainers(containerConfig).
-					WithRestartPolicy(corev1.RestartPolicyAlways))))
+			WithTemplate(podTemplate))
 
 	// Apply the statefulset using server-side apply
 	fieldManager := "toolhive-container-manager"
@@ -309,11 +484,15 @@ func (c *Client) CreateContainer(ctx context.Context,
 
 	logger.Log.Infof("Applied statefulset %s", createdStatefulSet.Name)
 
+	if err := c.applyNetworkPolicy(ctx, containerName, namespace, map[string]string{"app": containerName}, permissionProfile); err != nil {
+		return createdStatefulSet.Name, err
+	}
+
 	if transportType == string(transtypes.TransportTypeSSE) && options != nil {
-		// Create a headless service for SSE transport
-		err := c.createHeadlessService(ctx, containerName, namespace, containerLabels, options)
+		// Create the service fronting the StatefulSet for SSE transport
+		err := c.createService(ctx, containerName, namespace, containerLabels, options)
 		if err != nil {
-			return "", fmt.Errorf("failed to create headless service: %v", err)
+			return "", fmt.Errorf("failed to create service: %v", err)
 		}
 	}
 
@@ -329,7 +508,7 @@ func (c *Client) CreateContainer(ctx context.Context,
 // GetContainerInfo implements runtime.Runtime.
 func (c *Client) GetContainerInfo(ctx context.Context, containerID string) (runtime.ContainerInfo, error) {
 	// In Kubernetes, containerID is the statefulset name
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 
 	// Get the statefulset
 	statefulset, err := c.client.AppsV1().StatefulSets(namespace).Get(ctx, containerID, metav1.GetOptions{})
@@ -361,17 +540,23 @@ func (c *Client) GetContainerInfo(ctx context.Context, containerID string) (runt
 		ports = extractPortMappingsFromService(service, ports)
 	}
 
-	// Determine status and state
+	ports = c.attachIngressURL(ctx, namespace, containerID, ports)
+
+	// Determine status and state. spec.Replicas is the desired scale (set to
+	// 0 by StopContainer), while status.Replicas/ReadyReplicas reflect what's
+	// actually running, so a StatefulSet whose pods haven't finished
+	// terminating yet is still "stopped" rather than "pending".
 	var status, state string
-	if statefulset.Status.ReadyReplicas > 0 {
+	switch {
+	case desiredReplicas(statefulset) == 0:
+		status = "Stopped"
+		state = "stopped"
+	case statefulset.Status.ReadyReplicas > 0:
 		status = "Running"
 		state = "running"
-	} else if statefulset.Status.Replicas > 0 {
+	default:
 		status = "Pending"
 		state = "pending"
-	} else {
-		status = "Stopped"
-		state = "stopped"
 	}
 
 	// Get the image from the pod template
@@ -392,30 +577,21 @@ func (c *Client) GetContainerInfo(ctx context.Context, containerID string) (runt
 	}, nil
 }
 
-// ImageExists implements runtime.Runtime.
-func (*Client) ImageExists(_ context.Context, imageName string) (bool, error) {
-	// In Kubernetes, we can't directly check if an image exists in the cluster
-	// without trying to use it. For simplicity, we'll assume the image exists
-	// if it's a valid image name.
-	//
-	// In a more complete implementation, we could:
-	// 1. Create a temporary pod with the image to see if it can be pulled
-	// 2. Use the Kubernetes API to check node status for the image
-	// 3. Use an external registry API to check if the image exists
-
-	// For now, just return true if the image name is not empty
+// ImageExists implements runtime.Runtime by probing the image's registry
+// directly, rather than assuming every well-formed reference exists. See
+// imageExistsInRegistry in imagecheck.go for the actual registry call.
+func (c *Client) ImageExists(ctx context.Context, imageName string) (bool, error) {
 	if imageName == "" {
 		return false, fmt.Errorf("image name cannot be empty")
 	}
 
-	// We could add more validation here if needed
-	return true, nil
+	return c.imageExistsInRegistry(ctx, imageName)
 }
 
 // IsContainerRunning implements runtime.Runtime.
 func (c *Client) IsContainerRunning(ctx context.Context, containerID string) (bool, error) {
 	// In Kubernetes, containerID is the statefulset name
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 
 	// Get the statefulset
 	statefulset, err := c.client.AppsV1().StatefulSets(namespace).Get(ctx, containerID, metav1.GetOptions{})
@@ -426,8 +602,18 @@ func (c *Client) IsContainerRunning(ctx context.Context, containerID string) (bo
 		return false, fmt.Errorf("failed to get statefulset %s: %w", containerID, err)
 	}
 
-	// Check if the statefulset has at least one ready replica
-	return statefulset.Status.ReadyReplicas > 0, nil
+	// A StatefulSet scaled to 0 by StopContainer is never "running", even if
+	// a pod hasn't finished terminating yet.
+	return desiredReplicas(statefulset) > 0 && statefulset.Status.ReadyReplicas > 0, nil
+}
+
+// desiredReplicas returns a StatefulSet's desired replica count, defaulting
+// to 1 to match the Kubernetes API's own default when spec.replicas is unset.
+func desiredReplicas(statefulset *appsv1.StatefulSet) int32 {
+	if statefulset.Spec.Replicas == nil {
+		return 1
+	}
+	return *statefulset.Spec.Replicas
 }
 
 // ListContainers implements runtime.Runtime.
@@ -436,7 +622,7 @@ func (c *Client) ListContainers(ctx context.Context) ([]runtime.ContainerInfo, e
 	labelSelector := "toolhive=true"
 
 	// List pods with the toolhive label
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 	pods, err := c.client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
@@ -457,6 +643,8 @@ func (c *Client) ListContainers(ctx context.Context) ([]runtime.ContainerInfo, e
 			ports = extractPortMappingsFromService(service, ports)
 		}
 
+		ports = c.attachIngressURL(ctx, namespace, pod.Name, ports)
+
 		// Get container status
 		status := UnknownStatus
 		state := UnknownStatus
@@ -503,18 +691,18 @@ func (*Client) PullImage(_ context.Context, imageName string) error {
 }
 
 // BuildImage implements runtime.Runtime.
-func (*Client) BuildImage(_ context.Context, _, _ string) error {
+func (*Client) BuildImage(_ context.Context, _ runtime.BuildOptions) (string, io.ReadCloser, error) {
 	// In Kubernetes, we don't build images directly within the cluster.
 	// Images should be built externally and pushed to a registry.
 	logger.Log.Warnf("BuildImage is not supported in Kubernetes runtime. " +
 		"Images should be built externally and pushed to a registry.")
-	return fmt.Errorf("building images directly is not supported in Kubernetes runtime")
+	return "", nil, fmt.Errorf("building images directly is not supported in Kubernetes runtime")
 }
 
 // RemoveContainer implements runtime.Runtime.
 func (c *Client) RemoveContainer(ctx context.Context, containerID string) error {
 	// In Kubernetes, we remove a container by deleting the statefulset
-	namespace := getCurrentNamespace()
+	namespace := c.namespace
 
 	// Delete the statefulset
 	deleteOptions := metav1.DeleteOptions{}
@@ -528,17 +716,105 @@ func (c *Client) RemoveContainer(ctx context.Context, containerID string) error
 		return fmt.Errorf("failed to delete statefulset %s: %w", containerID, err)
 	}
 
+	if err := c.deleteWorkloadSecret(ctx, containerID, namespace); err != nil {
+		return err
+	}
+
+	if err := c.deleteIngress(ctx, containerID, namespace); err != nil {
+		return err
+	}
+
+	if err := c.deleteHTTPRoute(ctx, containerID, namespace); err != nil {
+		return err
+	}
+
+	c.stopForwards(containerID)
+
 	logger.Log.Infof("Deleted statefulset %s", containerID)
 	return nil
 }
 
-// StopContainer implements runtime.Runtime.
-func (*Client) StopContainer(_ context.Context, _ string) error {
+// defaultTerminationGracePeriod is used when a StatefulSet's pod template
+// doesn't set terminationGracePeriodSeconds, matching the Kubernetes API's
+// own default.
+const defaultTerminationGracePeriod = 30 * time.Second
+
+// StopContainer implements runtime.Runtime. It scales the StatefulSet's
+// replicas to 0 via server-side apply and waits for its pods to terminate,
+// rather than deleting anything, so CreateContainer can resume the same
+// workload later by scaling back to 1.
+func (c *Client) StopContainer(ctx context.Context, containerID string) error {
+	namespace := c.namespace
+
+	statefulset, err := c.client.AppsV1().StatefulSets(namespace).Get(ctx, containerID, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Log.Infof("Statefulset %s not found, nothing to stop", containerID)
+			return nil
+		}
+		return fmt.Errorf("failed to get statefulset %s: %w", containerID, err)
+	}
+
+	zero := int32(0)
+	scaleDownApply := appsv1apply.StatefulSet(containerID, namespace).
+		WithSpec(appsv1apply.StatefulSetSpec().WithReplicas(zero))
+
+	_, err = c.client.AppsV1().StatefulSets(namespace).Apply(ctx, scaleDownApply, metav1.ApplyOptions{
+		FieldManager: "toolhive-container-manager",
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scale statefulset %s to 0: %w", containerID, err)
+	}
+
+	logger.Log.Infof("Scaled statefulset %s to 0 replicas", containerID)
+
+	gracePeriod := defaultTerminationGracePeriod
+	if tgps := statefulset.Spec.Template.Spec.TerminationGracePeriodSeconds; tgps != nil {
+		gracePeriod = time.Duration(*tgps) * time.Second
+	}
+
+	if err := waitForPodsTerminated(ctx, c.client, namespace, containerID, gracePeriod+30*time.Second); err != nil {
+		return fmt.Errorf("statefulset scaled to 0 but pods failed to terminate: %w", err)
+	}
+
 	return nil
 }
 
+// waitForPodsTerminated polls for the workload's pods to disappear after a
+// scale-down, up to timeout. Unlike waitForStatefulSetReady, it polls rather
+// than watches a single object, since it needs to observe a set of pods
+// draining to zero rather than one object reaching a target state.
+func waitForPodsTerminated(ctx context.Context, clientset kubernetes.Interface, namespace, name string, timeout time.Duration) error {
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		pods, err := clientset.CoreV1().Pods(namespace).List(timeoutCtx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("app=%s", name),
+		})
+		if err != nil {
+			return fmt.Errorf("error listing pods: %w", err)
+		}
+		if len(pods.Items) == 0 {
+			return nil
+		}
+
+		logger.Log.Infof("Waiting for %d pod(s) of %s to terminate...", len(pods.Items), name)
+
+		select {
+		case <-timeoutCtx.Done():
+			return fmt.Errorf("timed out waiting for pods to terminate: %w", timeoutCtx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // waitForStatefulSetReady waits for a statefulset to be ready using the watch API
-func waitForStatefulSetReady(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) error {
+func waitForStatefulSetReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	// Create a field selector to watch only this specific statefulset
 	fieldSelector := fmt.Sprintf("metadata.name=%s", name)
 
@@ -593,6 +869,27 @@ func parsePortString(portStr string) (int, error) {
 	return portNum, nil
 }
 
+// parsePortProtocol parses the "/protocol" suffix of a "port/protocol"
+// string (e.g. "51820/udp"), defaulting to TCP when no protocol is given.
+// Only tcp, udp, and sctp are supported, matching corev1.Protocol.
+func parsePortProtocol(portStr string) (corev1.Protocol, error) {
+	parts := strings.SplitN(portStr, "/", 2)
+	if len(parts) < 2 || parts[1] == "" {
+		return corev1.ProtocolTCP, nil
+	}
+
+	switch strings.ToLower(parts[1]) {
+	case "tcp":
+		return corev1.ProtocolTCP, nil
+	case "udp":
+		return corev1.ProtocolUDP, nil
+	case "sctp":
+		return corev1.ProtocolSCTP, nil
+	default:
+		return "", fmt.Errorf("unsupported port protocol %q: must be tcp, udp, or sctp", parts[1])
+	}
+}
+
 // configureContainerPorts adds port configurations to a container for SSE transport
 func configureContainerPorts(
 	containerConfig *corev1apply.ContainerApplyConfiguration,
@@ -602,51 +899,54 @@ func configureContainerPorts(
 		return containerConfig, nil
 	}
 
-	// Use a map to track which ports have been added
-	portMap := make(map[int32]bool)
+	// Use a map to track which port/protocol pairs have been added, since a
+	// single port number can be bound by both TCP and UDP (or SCTP).
+	type portKey struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+	seen := make(map[portKey]bool)
 	var containerPorts []*corev1apply.ContainerPortApplyConfiguration
 
-	// Process exposed ports
-	for portStr := range options.ExposedPorts {
+	addPort := func(portStr string) error {
 		portNum, err := parsePortString(portStr)
 		if err != nil {
-			return nil, err
+			return err
 		}
-
-		// Check for integer overflow
 		if portNum < 0 || portNum > 65535 {
-			return nil, fmt.Errorf("port number %d is out of valid range (0-65535)", portNum)
+			return fmt.Errorf("port number %d is out of valid range (0-65535)", portNum)
+		}
+		protocol, err := parsePortProtocol(portStr)
+		if err != nil {
+			return err
 		}
 
-		// Add port if not already in the map
 		portInt32 := int32(portNum)
-		if !portMap[portInt32] {
-			containerPorts = append(containerPorts, corev1apply.ContainerPort().
+		key := portKey{port: portInt32, protocol: protocol}
+		if !seen[key] {
+			containerPort := corev1apply.ContainerPort().
 				WithContainerPort(portInt32).
-				WithProtocol(corev1.ProtocolTCP))
-			portMap[portInt32] = true
+				WithProtocol(protocol)
+			if name := options.PortNames[portStr]; name != "" {
+				containerPort = containerPort.WithName(name)
+			}
+			containerPorts = append(containerPorts, containerPort)
+			seen[key] = true
 		}
+		return nil
 	}
 
-	// Process port bindings
-	for portStr := range options.PortBindings {
-		portNum, err := parsePortString(portStr)
-		if err != nil {
+	// Process exposed ports
+	for portStr := range options.ExposedPorts {
+		if err := addPort(portStr); err != nil {
 			return nil, err
 		}
+	}
 
-		// Check for integer overflow
-		if portNum < 0 || portNum > 65535 {
-			return nil, fmt.Errorf("port number %d is out of valid range (0-65535)", portNum)
-		}
-
-		// Add port if not already in the map
-		portInt32 := int32(portNum)
-		if !portMap[portInt32] {
-			containerPorts = append(containerPorts, corev1apply.ContainerPort().
-				WithContainerPort(portInt32).
-				WithProtocol(corev1.ProtocolTCP))
-			portMap[portInt32] = true
+	// Process port bindings
+	for portStr := range options.PortBindings {
+		if err := addPort(portStr); err != nil {
+			return nil, err
 		}
 	}
 
@@ -666,37 +966,58 @@ func validatePortNumber(portNum int) error {
 	return nil
 }
 
-// createServicePortConfig creates a service port configuration for a given port number
-func createServicePortConfig(portNum int) *corev1apply.ServicePortApplyConfiguration {
+// createServicePortConfig creates a service port configuration for a given
+// port number and protocol. When name is non-empty, the port is exposed
+// under that name with a name-based target port, so Endpoints consumers
+// (e.g. service mesh sidecars) can address it by name instead of number;
+// otherwise it falls back to the synthesized "port-<n>-<protocol>" name.
+func createServicePortConfig(portNum int, protocol corev1.Protocol, name string) *corev1apply.ServicePortApplyConfiguration {
 	//nolint:gosec // G115: Safe int->int32 conversion, range is checked in validatePortNumber
 	portInt32 := int32(portNum)
+	targetPort := intstr.FromInt(portNum)
+	if name == "" {
+		name = fmt.Sprintf("port-%d-%s", portNum, strings.ToLower(string(protocol)))
+	} else {
+		targetPort = intstr.FromString(name)
+	}
+
 	return corev1apply.ServicePort().
-		WithName(fmt.Sprintf("port-%d", portNum)).
+		WithName(name).
 		WithPort(portInt32).
-		WithTargetPort(intstr.FromInt(portNum)).
-		WithProtocol(corev1.ProtocolTCP)
+		WithTargetPort(targetPort).
+		WithProtocol(protocol)
+}
+
+// servicePortKey identifies a service port by its port number and protocol,
+// since the same port number can be bound by both TCP and UDP (or SCTP).
+type servicePortKey struct {
+	port     int32
+	protocol corev1.Protocol
 }
 
 // processExposedPorts processes exposed ports and adds them to the port map
 func processExposedPorts(
 	options *runtime.CreateContainerOptions,
-	portMap map[int32]*corev1apply.ServicePortApplyConfiguration,
+	portMap map[servicePortKey]*corev1apply.ServicePortApplyConfiguration,
 ) error {
 	for portStr := range options.ExposedPorts {
 		portNum, err := parsePortString(portStr)
 		if err != nil {
 			return err
 		}
-
 		if err := validatePortNumber(portNum); err != nil {
 			return err
 		}
+		protocol, err := parsePortProtocol(portStr)
+		if err != nil {
+			return err
+		}
 
 		//nolint:gosec // G115: Safe int->int32 conversion, range is checked in validatePortNumber
-		portInt32 := int32(portNum)
+		key := servicePortKey{port: int32(portNum), protocol: protocol}
 		// Add port if not already in the map
-		if _, exists := portMap[portInt32]; !exists {
-			portMap[portInt32] = createServicePortConfig(portNum)
+		if _, exists := portMap[key]; !exists {
+			portMap[key] = createServicePortConfig(portNum, protocol, options.PortNames[portStr])
 		}
 	}
 	return nil
@@ -708,8 +1029,8 @@ func createServicePorts(options *runtime.CreateContainerOptions) ([]*corev1apply
 		return nil, nil
 	}
 
-	// Use a map to track which ports have been added
-	portMap := make(map[int32]*corev1apply.ServicePortApplyConfiguration)
+	// Use a map to track which port/protocol pairs have been added
+	portMap := make(map[servicePortKey]*corev1apply.ServicePortApplyConfiguration)
 
 	// Process exposed ports
 	if err := processExposedPorts(options, portMap); err != nil {
@@ -722,17 +1043,20 @@ func createServicePorts(options *runtime.CreateContainerOptions) ([]*corev1apply
 		if err != nil {
 			return nil, err
 		}
-
 		if err := validatePortNumber(portNum); err != nil {
 			return nil, err
 		}
+		protocol, err := parsePortProtocol(portStr)
+		if err != nil {
+			return nil, err
+		}
 
 		//nolint:gosec // G115: Safe int->int32 conversion, range is checked in validatePortNumber
-		portInt32 := int32(portNum)
-		servicePort := portMap[portInt32]
+		key := servicePortKey{port: int32(portNum), protocol: protocol}
+		servicePort := portMap[key]
 		if servicePort == nil {
 			// Create new service port if not in map
-			servicePort = createServicePortConfig(portNum)
+			servicePort = createServicePortConfig(portNum, protocol, options.PortNames[portStr])
 		}
 
 		// If there are bindings with a host port, use the first one as node port
@@ -748,8 +1072,7 @@ func createServicePorts(options *runtime.CreateContainerOptions) ([]*corev1apply
 			}
 		}
 
-		//nolint:gosec // G115: Safe int->int32 conversion, range is checked above
-		portMap[int32(portNum)] = servicePort
+		portMap[key] = servicePort
 	}
 
 	// Convert map to slice
@@ -761,8 +1084,34 @@ func createServicePorts(options *runtime.CreateContainerOptions) ([]*corev1apply
 	return servicePorts, nil
 }
 
-// createHeadlessService creates a headless Kubernetes service for the StatefulSet
-func (c *Client) createHeadlessService(
+// defaultSessionAffinityTimeoutSeconds is 3 hours, the Kubernetes default
+// for ClientIP session affinity (corev1.DefaultClientIPServiceAffinitySeconds);
+// the enforced maximum (corev1.MaxClientIPServiceAffinitySeconds) is one
+// day. Note that with
+// the StatefulSet's single replica, session affinity only matters if the
+// workload is later scaled beyond 1; at replica=1 every request already
+// lands on the only pod regardless of affinity settings.
+const defaultSessionAffinityTimeoutSeconds = int32(10800)
+
+// sessionAffinityTimeoutSeconds returns options.SessionAffinityTimeoutSeconds
+// if set, otherwise defaultSessionAffinityTimeoutSeconds.
+func sessionAffinityTimeoutSeconds(options *runtime.CreateContainerOptions) int32 {
+	if options.SessionAffinityTimeoutSeconds != 0 {
+		return options.SessionAffinityTimeoutSeconds
+	}
+	return defaultSessionAffinityTimeoutSeconds
+}
+
+// createService creates or updates the Kubernetes Service fronting the
+// StatefulSet. options.ServiceType selects ClusterIP/NodePort/LoadBalancer/
+// Headless; an empty ServiceType preserves the historical behavior of a
+// headless service that falls back to NodePort when a port binding requested
+// one. Because the Service is re-applied with Force: true on every restart,
+// any API-server-allocated fields (NodePort, ClusterIP, HealthCheckNodePort)
+// that the caller didn't request explicitly are copied forward from the
+// existing Service first, so reconciliation doesn't clobber them out from
+// under already-connected clients.
+func (c *Client) createService(
 	ctx context.Context,
 	containerName string,
 	namespace string,
@@ -781,25 +1130,64 @@ func (c *Client) createHeadlessService(
 		return nil
 	}
 
-	// Create service type based on whether we have node ports
-	serviceType := corev1.ServiceTypeClusterIP
-	for _, sp := range servicePorts {
-		if sp.NodePort != nil {
-			serviceType = corev1.ServiceTypeNodePort
-			break
+	existing, err := c.getExistingService(ctx, containerName, namespace)
+	if err != nil {
+		return err
+	}
+	preserveAllocatedNodePorts(servicePorts, existing)
+
+	serviceSpec := corev1apply.ServiceSpec().
+		WithSelector(map[string]string{
+			"app": containerName,
+		}).
+		WithPorts(servicePorts...)
+
+	switch options.ServiceType {
+	case "LoadBalancer":
+		serviceSpec = serviceSpec.WithType(corev1.ServiceTypeLoadBalancer)
+		if len(options.LoadBalancerSourceRanges) > 0 {
+			serviceSpec = serviceSpec.WithLoadBalancerSourceRanges(options.LoadBalancerSourceRanges...)
 		}
+		if options.LoadBalancerIP != "" {
+			serviceSpec = serviceSpec.WithLoadBalancerIP(options.LoadBalancerIP)
+		}
+		if len(options.ExternalIPs) > 0 {
+			serviceSpec = serviceSpec.WithExternalIPs(options.ExternalIPs...)
+		}
+		if options.HealthCheckNodePort != 0 {
+			serviceSpec = serviceSpec.WithHealthCheckNodePort(options.HealthCheckNodePort)
+		}
+	case "NodePort":
+		serviceSpec = serviceSpec.WithType(corev1.ServiceTypeNodePort)
+	case "ClusterIP":
+		serviceSpec = serviceSpec.WithType(corev1.ServiceTypeClusterIP)
+	default:
+		// Historical behavior: headless, falling back to NodePort if a port
+		// binding asked for one.
+		serviceType := corev1.ServiceTypeClusterIP
+		for _, sp := range servicePorts {
+			if sp.NodePort != nil {
+				serviceType = corev1.ServiceTypeNodePort
+				break
+			}
+		}
+		serviceSpec = serviceSpec.WithType(serviceType).WithClusterIP("None")
+	}
+
+	serviceSpec = preserveClusterIP(serviceSpec, existing)
+	serviceSpec = preserveHealthCheckNodePort(serviceSpec, existing, options)
+
+	if options.SessionAffinity == string(corev1.ServiceAffinityClientIP) {
+		serviceSpec = serviceSpec.
+			WithSessionAffinity(corev1.ServiceAffinityClientIP).
+			WithSessionAffinityConfig(corev1apply.SessionAffinityConfig().
+				WithClientIP(corev1apply.ClientIPConfig().
+					WithTimeoutSeconds(sessionAffinityTimeoutSeconds(options))))
 	}
 
-	// Create the service apply configuration
 	serviceApply := corev1apply.Service(containerName, namespace).
 		WithLabels(labels).
-		WithSpec(corev1apply.ServiceSpec().
-			WithSelector(map[string]string{
-				"app": containerName,
-			}).
-			WithPorts(servicePorts...).
-			WithType(serviceType).
-			WithClusterIP("None")) // "None" makes it a headless service
+		WithSpec(serviceSpec)
 
 	// Apply the service using server-side apply
 	fieldManager := "toolhive-container-manager"
@@ -813,7 +1201,31 @@ func (c *Client) createHeadlessService(
 		return fmt.Errorf("failed to apply service: %v", err)
 	}
 
-	logger.Log.Infof("Created headless service %s for SSE transport", containerName)
+	logger.Log.Infof("Created service %s for SSE transport", containerName)
+
+	if options.IngressConfig != nil {
+		var servicePortName string
+		var servicePortNumber int32
+		if len(servicePorts) > 0 {
+			if servicePorts[0].Name != nil {
+				servicePortName = *servicePorts[0].Name
+			}
+			if servicePorts[0].Port != nil {
+				servicePortNumber = *servicePorts[0].Port
+			}
+		}
+
+		if err := c.applyIngress(ctx, containerName, namespace, servicePortName, options.IngressConfig); err != nil {
+			return err
+		}
+
+		if options.IngressConfig.GatewayHTTPRoute != nil {
+			if err := c.applyHTTPRoute(ctx, containerName, namespace, servicePortNumber, options.IngressConfig); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -827,6 +1239,7 @@ func extractPortMappingsFromPod(pod *corev1.Pod) []runtime.PortMapping {
 				ContainerPort: int(port.ContainerPort),
 				HostPort:      int(port.HostPort),
 				Protocol:      string(port.Protocol),
+				Name:          port.Name,
 			})
 		}
 	}
@@ -834,7 +1247,11 @@ func extractPortMappingsFromPod(pod *corev1.Pod) []runtime.PortMapping {
 	return ports
 }
 
-// extractPortMappingsFromService extracts port mappings from a Kubernetes service
+// extractPortMappingsFromService extracts port mappings from a Kubernetes
+// service, including the externally-reachable hostname/IP once a
+// LoadBalancer-type service's ingress has been assigned, so callers (e.g.
+// the transport/proxy layer) can discover the public endpoint of an SSE MCP
+// server running behind a cloud load balancer.
 func extractPortMappingsFromService(service *corev1.Service, existingPorts []runtime.PortMapping) []runtime.PortMapping {
 	// Create a map of existing ports for easy lookup and updating
 	portMap := make(map[int]runtime.PortMapping)
@@ -842,6 +1259,8 @@ func extractPortMappingsFromService(service *corev1.Service, existingPorts []run
 		portMap[p.ContainerPort] = p
 	}
 
+	externalHostname := loadBalancerIngress(service)
+
 	// Update or add ports from the service
 	for _, port := range service.Spec.Ports {
 		containerPort := int(port.Port)
@@ -852,9 +1271,11 @@ func extractPortMappingsFromService(service *corev1.Service, existingPorts []run
 
 		// Update existing port or add new one
 		portMap[containerPort] = runtime.PortMapping{
-			ContainerPort: containerPort,
-			HostPort:      hostPort,
-			Protocol:      string(port.Protocol),
+			ContainerPort:    containerPort,
+			HostPort:         hostPort,
+			Protocol:         string(port.Protocol),
+			ExternalHostname: externalHostname,
+			Name:             port.Name,
 		}
 	}
 
@@ -866,3 +1287,17 @@ func extractPortMappingsFromService(service *corev1.Service, existingPorts []run
 
 	return result
 }
+
+// loadBalancerIngress returns the first assigned LoadBalancer ingress
+// hostname (preferred, for DNS-based cloud LBs) or IP, or "" if the service
+// isn't a LoadBalancer or its ingress hasn't been assigned yet.
+func loadBalancerIngress(service *corev1.Service) string {
+	if len(service.Status.LoadBalancer.Ingress) == 0 {
+		return ""
+	}
+	ingress := service.Status.LoadBalancer.Ingress[0]
+	if ingress.Hostname != "" {
+		return ingress.Hostname
+	}
+	return ingress.IP
+}
@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+)
+
+// newTestClient returns a Client backed by a fake clientset, for tests that
+// exercise createService without a real cluster.
+func newTestClient() *Client {
+	return &Client{
+		client:    fake.NewSimpleClientset(),
+		namespace: "default",
+		forwards:  make(map[string][]*activeForward),
+	}
+}
+
+func TestCreateServicePreservesNodePortAcrossReapply(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient()
+	options := &runtime.CreateContainerOptions{
+		ExposedPorts: map[string]struct{}{"8080/tcp": {}},
+	}
+
+	err := c.createService(context.Background(), "my-workload", "default", map[string]string{"app": "my-workload"}, options)
+	require.NoError(t, err)
+
+	svc, err := c.client.CoreV1().Services("default").Get(context.Background(), "my-workload", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, svc.Spec.Ports, 1)
+
+	// Simulate the API server allocating a NodePort, the way it would for a
+	// real NodePort/LoadBalancer service even though our apply config didn't
+	// request one.
+	svc.Spec.Ports[0].NodePort = 31234
+	_, err = c.client.CoreV1().Services("default").Update(context.Background(), svc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// Re-apply, simulating a toolhive restart. The allocated NodePort must
+	// survive even though options never set one explicitly.
+	err = c.createService(context.Background(), "my-workload", "default", map[string]string{"app": "my-workload"}, options)
+	require.NoError(t, err)
+
+	svc, err = c.client.CoreV1().Services("default").Get(context.Background(), "my-workload", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, svc.Spec.Ports, 1)
+	assert.Equal(t, int32(31234), svc.Spec.Ports[0].NodePort)
+}
+
+func TestCreateServicePreservesClusterIPAndHealthCheckNodePort(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient()
+	options := &runtime.CreateContainerOptions{
+		ExposedPorts: map[string]struct{}{"8080/tcp": {}},
+		ServiceType:  "LoadBalancer",
+	}
+
+	err := c.createService(context.Background(), "my-workload", "default", map[string]string{"app": "my-workload"}, options)
+	require.NoError(t, err)
+
+	svc, err := c.client.CoreV1().Services("default").Get(context.Background(), "my-workload", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	svc.Spec.ClusterIP = "10.0.0.5"
+	svc.Spec.HealthCheckNodePort = 31555
+	_, err = c.client.CoreV1().Services("default").Update(context.Background(), svc, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	err = c.createService(context.Background(), "my-workload", "default", map[string]string{"app": "my-workload"}, options)
+	require.NoError(t, err)
+
+	svc, err = c.client.CoreV1().Services("default").Get(context.Background(), "my-workload", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.5", svc.Spec.ClusterIP)
+	assert.Equal(t, int32(31555), svc.Spec.HealthCheckNodePort)
+}
+
+func TestPreserveAllocatedNodePortsSkipsExplicitRequest(t *testing.T) {
+	t.Parallel()
+
+	existing := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "port-8080-tcp", Port: 8080, Protocol: corev1.ProtocolTCP, NodePort: 31000},
+			},
+		},
+	}
+
+	sp := createServicePortConfig(8080, corev1.ProtocolTCP, "")
+	sp.WithNodePort(30500)
+
+	preserveAllocatedNodePorts([]*corev1apply.ServicePortApplyConfiguration{sp}, existing)
+
+	require.NotNil(t, sp.NodePort)
+	assert.Equal(t, int32(30500), *sp.NodePort, "an explicitly requested NodePort must not be overwritten")
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// httpRouteGVR identifies the Gateway API HTTPRoute resource. HTTPRoute isn't
+// part of client-go's typed API (it ships as a CRD installed by whatever
+// Gateway API implementation the cluster runs), so it's managed through the
+// dynamic client as unstructured data instead of a generated apply
+// configuration.
+var httpRouteGVR = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1",
+	Resource: "httproutes",
+}
+
+// buildHTTPRoute translates config.GatewayHTTPRoute into an HTTPRoute bound
+// to the user-named Gateway, routing servicePort on containerName's Service.
+// This is the path-based multi-tenancy alternative to an Ingress: several MCP
+// servers can share one Gateway, each claiming a distinct path or hostname.
+func buildHTTPRoute(containerName, namespace string, servicePort int32, config *runtime.IngressConfig) *unstructured.Unstructured {
+	route := config.GatewayHTTPRoute
+
+	gatewayNamespace := route.GatewayNamespace
+	if gatewayNamespace == "" {
+		gatewayNamespace = namespace
+	}
+
+	path := config.Path
+	if path == "" {
+		path = defaultIngressPath
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{
+				"name":      route.GatewayName,
+				"namespace": gatewayNamespace,
+			},
+		},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": []interface{}{
+					map[string]interface{}{
+						"path": map[string]interface{}{
+							"type":  "PathPrefix",
+							"value": path,
+						},
+					},
+				},
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": containerName,
+						"port": int64(servicePort),
+					},
+				},
+			},
+		},
+	}
+	if config.Host != "" {
+		spec["hostnames"] = []interface{}{config.Host}
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "gateway.networking.k8s.io/v1",
+			"kind":       "HTTPRoute",
+			"metadata": map[string]interface{}{
+				"name":      containerName,
+				"namespace": namespace,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// applyHTTPRoute creates or updates the HTTPRoute routing traffic to
+// containerName's service, gated on config.GatewayHTTPRoute being set so
+// clusters without the Gateway API CRDs installed never hit this path.
+func (c *Client) applyHTTPRoute(
+	ctx context.Context,
+	containerName, namespace string,
+	servicePort int32,
+	config *runtime.IngressConfig,
+) error {
+	route := buildHTTPRoute(containerName, namespace, servicePort, config)
+
+	_, err := c.dynamicClient.Resource(httpRouteGVR).Namespace(namespace).Apply(
+		ctx, containerName, route, metav1.ApplyOptions{
+			FieldManager: "toolhive-container-manager",
+			Force:        true,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to apply HTTPRoute: %w", err)
+	}
+
+	logger.Log.Infof("Applied HTTPRoute %s", containerName)
+	return nil
+}
+
+// deleteHTTPRoute removes the HTTPRoute created by applyHTTPRoute, if any. A
+// missing HTTPRoute, or a cluster without the Gateway API CRDs installed at
+// all, is not an error since not every workload uses one.
+func (c *Client) deleteHTTPRoute(ctx context.Context, containerName, namespace string) error {
+	err := c.dynamicClient.Resource(httpRouteGVR).Namespace(namespace).Delete(ctx, containerName, metav1.DeleteOptions{})
+	if err == nil {
+		logger.Log.Infof("Deleted HTTPRoute %s", containerName)
+		return nil
+	}
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("failed to delete HTTPRoute %s: %w", containerName, err)
+}
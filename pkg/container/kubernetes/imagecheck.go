@@ -0,0 +1,46 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// imageExistsInRegistry resolves credentials from the target namespace's
+// ServiceAccount (the same dockerconfigjson imagePullSecrets the kubelet
+// itself would use to pull the image) and issues a HEAD request for the
+// image's manifest, so a caller checking ImageExists before CreateContainer
+// can fail fast with a clear error instead of creating a StatefulSet that
+// ends up in ErrImagePull. CreateContainer itself does not call it.
+func (c *Client) imageExistsInRegistry(ctx context.Context, imageName string) (bool, error) {
+	ref, err := name.ParseReference(imageName)
+	if err != nil {
+		return false, fmt.Errorf("invalid image reference %q: %w", imageName, err)
+	}
+
+	keychain, err := k8schain.New(ctx, c.client, k8schain.Options{Namespace: c.namespace})
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve image pull credentials: %w", err)
+	}
+
+	_, err = remote.Head(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain))
+	if err == nil {
+		return true, nil
+	}
+
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	logger.Log.Warnf("Failed to check if image %s exists: %v", imageName, err)
+	return false, fmt.Errorf("failed to check image %s: %w", imageName, err)
+}
@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networkingv1apply "k8s.io/client-go/applyconfigurations/networking/v1"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// defaultIngressPath is used when config.Path is empty, routing the whole
+// host to the SSE service the way most single-tenant MCP server deployments
+// want.
+const defaultIngressPath = "/"
+
+// buildIngress translates an IngressConfig into an Ingress fronting
+// servicePortName on containerName's Service, named the same as the
+// StatefulSet and Service it fronts so RemoveContainer can find it by name.
+func buildIngress(
+	containerName, namespace, servicePortName string,
+	config *runtime.IngressConfig,
+) *networkingv1apply.IngressApplyConfiguration {
+	pathType := networkingv1.PathTypePrefix
+	if config.PathType != nil {
+		pathType = *config.PathType
+	}
+	path := config.Path
+	if path == "" {
+		path = defaultIngressPath
+	}
+
+	backend := networkingv1apply.IngressBackend().
+		WithService(networkingv1apply.IngressServiceBackend().
+			WithName(containerName).
+			WithPort(networkingv1apply.ServiceBackendPort().WithName(servicePortName)))
+
+	rule := networkingv1apply.IngressRule().
+		WithHTTP(networkingv1apply.HTTPIngressRuleValue().
+			WithPaths(networkingv1apply.HTTPIngressPath().
+				WithPath(path).
+				WithPathType(pathType).
+				WithBackend(backend)))
+	if config.Host != "" {
+		rule = rule.WithHost(config.Host)
+	}
+
+	ingressSpec := networkingv1apply.IngressSpec().WithRules(rule)
+	if config.IngressClassName != "" {
+		ingressSpec = ingressSpec.WithIngressClassName(config.IngressClassName)
+	}
+	if config.TLSSecretName != "" {
+		tls := networkingv1apply.IngressTLS().WithSecretName(config.TLSSecretName)
+		if config.Host != "" {
+			tls = tls.WithHosts(config.Host)
+		}
+		ingressSpec = ingressSpec.WithTLS(tls)
+	}
+
+	ingressApply := networkingv1apply.Ingress(containerName, namespace).WithSpec(ingressSpec)
+	if len(config.Annotations) > 0 {
+		ingressApply = ingressApply.WithAnnotations(config.Annotations)
+	}
+	return ingressApply
+}
+
+// applyIngress creates or updates the Ingress exposing containerName's
+// service externally, using the same server-side apply pattern as the
+// StatefulSet and Service.
+func (c *Client) applyIngress(
+	ctx context.Context,
+	containerName, namespace, servicePortName string,
+	config *runtime.IngressConfig,
+) error {
+	ingressApply := buildIngress(containerName, namespace, servicePortName, config)
+
+	_, err := c.client.NetworkingV1().Ingresses(namespace).Apply(ctx, ingressApply, metav1.ApplyOptions{
+		FieldManager: "toolhive-container-manager",
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply ingress: %v", err)
+	}
+
+	logger.Log.Infof("Applied ingress %s", containerName)
+	return nil
+}
+
+// deleteIngress removes the Ingress created by applyIngress, if any. A
+// missing Ingress is not an error since not every workload has one.
+func (c *Client) deleteIngress(ctx context.Context, containerName, namespace string) error {
+	err := c.client.NetworkingV1().Ingresses(namespace).Delete(ctx, containerName, metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete ingress %s: %w", containerName, err)
+	}
+
+	logger.Log.Infof("Deleted ingress %s", containerName)
+	return nil
+}
+
+// attachIngressURL sets ExternalURL on each port in ports to the externally
+// reachable address of containerName's Ingress, if one exists. Workloads
+// with no Ingress (the common case) are left untouched.
+func (c *Client) attachIngressURL(
+	ctx context.Context,
+	namespace, containerName string,
+	ports []runtime.PortMapping,
+) []runtime.PortMapping {
+	ingress, err := c.client.NetworkingV1().Ingresses(namespace).Get(ctx, containerName, metav1.GetOptions{})
+	if err != nil {
+		return ports
+	}
+
+	url := externalURLFromIngress(ingress)
+	if url == "" {
+		return ports
+	}
+
+	for i := range ports {
+		ports[i].ExternalURL = url
+	}
+	return ports
+}
+
+// externalURLFromIngress derives the URL an operator would use to reach
+// ingress from outside the cluster: the scheme reflects whether TLS is
+// configured, the host comes from the first rule (falling back to the
+// Ingress controller's assigned load balancer address), and the path comes
+// from the first rule's first path.
+func externalURLFromIngress(ingress *networkingv1.Ingress) string {
+	scheme := "http"
+	if len(ingress.Spec.TLS) > 0 {
+		scheme = "https"
+	}
+
+	host := ""
+	path := defaultIngressPath
+	if len(ingress.Spec.Rules) > 0 {
+		rule := ingress.Spec.Rules[0]
+		host = rule.Host
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 && rule.HTTP.Paths[0].Path != "" {
+			path = rule.HTTP.Paths[0].Path
+		}
+	}
+	if host == "" {
+		for _, lb := range ingress.Status.LoadBalancer.Ingress {
+			if lb.Hostname != "" {
+				host = lb.Hostname
+				break
+			}
+			if lb.IP != "" {
+				host = lb.IP
+				break
+			}
+		}
+	}
+	if host == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, host, path)
+}
@@ -0,0 +1,114 @@
+package kubernetes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+)
+
+// applyPodLabelsAndAnnotations merges options.K8sPodOptions.PodLabels/
+// PodAnnotations into the workload's own labels/annotations. The caller's
+// values are layered on top so user-supplied scheduling metadata (e.g. a
+// cost-center annotation) composes with, rather than overwrites, the
+// toolhive=true and app=<name> labels CreateContainer already sets.
+func applyPodLabelsAndAnnotations(
+	labels map[string]string,
+	options *runtime.CreateContainerOptions,
+) (map[string]string, map[string]string) {
+	annotations := map[string]string{}
+	if options == nil || options.K8sPodOptions == nil {
+		return labels, annotations
+	}
+	podOpts := options.K8sPodOptions
+
+	for k, v := range podOpts.PodLabels {
+		if _, exists := labels[k]; !exists {
+			labels[k] = v
+		}
+	}
+	for k, v := range podOpts.PodAnnotations {
+		annotations[k] = v
+	}
+
+	return labels, annotations
+}
+
+// applyPodScheduling layers the user-supplied scheduling knobs in
+// options.K8sPodOptions onto podSpec: nodeSelector, tolerations, affinity,
+// imagePullSecrets, serviceAccountName, and priorityClassName. It is a
+// no-op when options or options.K8sPodOptions is nil, so existing callers
+// that don't set it see no behavior change.
+func applyPodScheduling(
+	podSpec *corev1apply.PodSpecApplyConfiguration,
+	options *runtime.CreateContainerOptions,
+) *corev1apply.PodSpecApplyConfiguration {
+	if options == nil || options.K8sPodOptions == nil {
+		return podSpec
+	}
+	podOpts := options.K8sPodOptions
+
+	if len(podOpts.NodeSelector) > 0 {
+		podSpec = podSpec.WithNodeSelector(podOpts.NodeSelector)
+	}
+
+	if len(podOpts.Tolerations) > 0 {
+		tolerations := make([]*corev1apply.TolerationApplyConfiguration, 0, len(podOpts.Tolerations))
+		for _, t := range podOpts.Tolerations {
+			tolerations = append(tolerations, corev1apply.Toleration().
+				WithKey(t.Key).
+				WithOperator(t.Operator).
+				WithValue(t.Value).
+				WithEffect(t.Effect).
+				WithTolerationSeconds(t.TolerationSeconds))
+		}
+		podSpec = podSpec.WithTolerations(tolerations...)
+	}
+
+	if podOpts.Affinity != nil {
+		podSpec = podSpec.WithAffinity(podOpts.Affinity)
+	}
+
+	if len(podOpts.ImagePullSecrets) > 0 {
+		refs := make([]*corev1apply.LocalObjectReferenceApplyConfiguration, 0, len(podOpts.ImagePullSecrets))
+		for _, name := range podOpts.ImagePullSecrets {
+			refs = append(refs, corev1apply.LocalObjectReference().WithName(name))
+		}
+		podSpec = podSpec.WithImagePullSecrets(refs...)
+	}
+
+	if podOpts.ServiceAccountName != "" {
+		podSpec = podSpec.WithServiceAccountName(podOpts.ServiceAccountName)
+	}
+
+	if podOpts.PriorityClassName != "" {
+		podSpec = podSpec.WithPriorityClassName(podOpts.PriorityClassName)
+	}
+
+	return podSpec
+}
+
+// applyContainerResources sets the container's resource requests/limits from
+// options.K8sPodOptions.Resources, if supplied.
+func applyContainerResources(
+	containerConfig *corev1apply.ContainerApplyConfiguration,
+	options *runtime.CreateContainerOptions,
+) *corev1apply.ContainerApplyConfiguration {
+	if options == nil || options.K8sPodOptions == nil {
+		return containerConfig
+	}
+	resources := options.K8sPodOptions.Resources
+	if len(resources.Requests) == 0 && len(resources.Limits) == 0 {
+		return containerConfig
+	}
+
+	resourceRequirements := corev1apply.ResourceRequirements()
+	if len(resources.Requests) > 0 {
+		resourceRequirements = resourceRequirements.WithRequests(corev1.ResourceList(resources.Requests))
+	}
+	if len(resources.Limits) > 0 {
+		resourceRequirements = resourceRequirements.WithLimits(corev1.ResourceList(resources.Limits))
+	}
+
+	return containerConfig.WithResources(resourceRequirements)
+}
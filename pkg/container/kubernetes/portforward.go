@@ -0,0 +1,140 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// PortForwarder is an optional capability implemented by runtimes that can
+// expose a running workload's ports on localhost without a Kubernetes
+// Service of type LoadBalancer/NodePort. Runtimes that don't support it
+// (Docker, Podman, where the container's ports are already reachable
+// locally) simply don't implement this interface; callers should type-assert
+// a runtime.Runtime against it before using it.
+type PortForwarder interface {
+	// PortForward forwards localPort on the caller's machine to remotePort
+	// on the running workload named containerID, returning a stop function
+	// that tears the forward down.
+	PortForward(ctx context.Context, containerID string, localPort, remotePort int) (stop func(), err error)
+}
+
+// activeForward tracks a running port-forward so RemoveContainer can tear it
+// down along with the workload it targets.
+type activeForward struct {
+	stop func()
+}
+
+// PortForward implements PortForwarder. It locates a ready pod for
+// containerID via the same app=<name> selector used elsewhere in this
+// client, then forwards localPort to remotePort on that pod over a SPDY
+// upgrade of the stored *rest.Config, the same transport kubectl port-forward
+// uses.
+func (c *Client) PortForward(ctx context.Context, containerID string, localPort, remotePort int) (func(), error) {
+	podName, err := c.findReadyPod(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := c.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(c.namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	transport, upgrader, err := spdy.RoundTripperFor(c.restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPDY round tripper: %w", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, req.URL())
+
+	stopCh := make(chan struct{})
+	readyCh := make(chan struct{})
+	ports := []string{fmt.Sprintf("%d:%d", localPort, remotePort)}
+
+	fw, err := portforward.New(dialer, ports, stopCh, readyCh, io.Discard, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up port forward to pod %s: %w", podName, err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fw.ForwardPorts()
+	}()
+
+	select {
+	case <-readyCh:
+	case err := <-errCh:
+		return nil, fmt.Errorf("port forward to pod %s exited before becoming ready: %w", podName, err)
+	case <-time.After(30 * time.Second):
+		close(stopCh)
+		return nil, fmt.Errorf("timed out waiting for port forward to pod %s to become ready", podName)
+	}
+
+	logger.Log.Infof("Forwarding local port %d to port %d on pod %s", localPort, remotePort, podName)
+
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() {
+			close(stopCh)
+		})
+	}
+
+	c.forwardsMu.Lock()
+	c.forwards[containerID] = append(c.forwards[containerID], &activeForward{stop: stop})
+	c.forwardsMu.Unlock()
+
+	return stop, nil
+}
+
+// findReadyPod returns the name of a ready pod selected by app=containerID,
+// the same selector CreateContainer's StatefulSet uses.
+func (c *Client) findReadyPod(ctx context.Context, containerID string) (string, error) {
+	pods, err := c.client.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app=%s", containerID),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to find pod for container %s: %w", containerID, err)
+	}
+
+	for _, pod := range pods.Items {
+		if isPodReady(&pod) {
+			return pod.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("no ready pods found for container %s", containerID)
+}
+
+// isPodReady reports whether pod has a Ready condition of status True.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// stopForwards tears down any active port-forwards for containerID. It is
+// called from RemoveContainer so forwards don't outlive the workload they
+// point at.
+func (c *Client) stopForwards(containerID string) {
+	c.forwardsMu.Lock()
+	defer c.forwardsMu.Unlock()
+
+	for _, fwd := range c.forwards[containerID] {
+		fwd.stop()
+	}
+	delete(c.forwards, containerID)
+}
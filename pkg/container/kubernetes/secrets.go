@@ -0,0 +1,101 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// secretName returns the name of the per-workload Secret holding
+// containerName's sensitive environment values.
+func secretName(containerName string) string {
+	return containerName + "-secrets"
+}
+
+// buildEnvVars renders envVars as Kubernetes EnvVar apply configurations,
+// substituting a SecretKeyRef for any entry named in options.SecretEnvVars
+// instead of inlining its value in the StatefulSet spec. This follows the
+// same credential-injection pattern used by other Kubernetes-native
+// runtimes: secrets are mounted via the API server's RBAC-protected Secret
+// object rather than baked into the pod spec in plaintext.
+func buildEnvVars(envVars map[string]string, options *runtime.CreateContainerOptions) []*corev1apply.EnvVarApplyConfiguration {
+	var envVarList []*corev1apply.EnvVarApplyConfiguration
+
+	var secretRefs map[string]runtime.SecretKeyRef
+	if options != nil {
+		secretRefs = options.SecretEnvVars
+	}
+
+	for k, v := range envVars {
+		if ref, ok := secretRefs[k]; ok {
+			envVarList = append(envVarList, corev1apply.EnvVar().
+				WithName(k).
+				WithValueFrom(corev1apply.EnvVarSource().
+					WithSecretKeyRef(corev1apply.SecretKeySelector().
+						WithName(ref.Name).
+						WithKey(ref.Key))))
+			continue
+		}
+		envVarList = append(envVarList, corev1apply.EnvVar().WithName(k).WithValue(v))
+	}
+
+	// Env vars named only in SecretEnvVars (no plaintext counterpart) still
+	// need a reference emitted.
+	for k, ref := range secretRefs {
+		if _, ok := envVars[k]; ok {
+			continue
+		}
+		envVarList = append(envVarList, corev1apply.EnvVar().
+			WithName(k).
+			WithValueFrom(corev1apply.EnvVarSource().
+				WithSecretKeyRef(corev1apply.SecretKeySelector().
+					WithName(ref.Name).
+					WithKey(ref.Key))))
+	}
+
+	return envVarList
+}
+
+// upsertWorkloadSecret creates or updates the per-workload Secret holding
+// data, using the same server-side apply pattern and field manager as the
+// StatefulSet so the Secret's lifecycle is tied to this client.
+func (c *Client) upsertWorkloadSecret(ctx context.Context, containerName, namespace string, data map[string]string) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	secretApply := corev1apply.Secret(secretName(containerName), namespace).
+		WithStringData(data)
+
+	_, err := c.client.CoreV1().Secrets(namespace).Apply(ctx, secretApply, metav1.ApplyOptions{
+		FieldManager: "toolhive-container-manager",
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply secret: %v", err)
+	}
+
+	logger.Log.Infof("Applied secret %s", secretName(containerName))
+	return nil
+}
+
+// deleteWorkloadSecret removes the Secret created by upsertWorkloadSecret, if
+// any. A missing Secret is not an error since not every workload has one.
+func (c *Client) deleteWorkloadSecret(ctx context.Context, containerName, namespace string) error {
+	err := c.client.CoreV1().Secrets(namespace).Delete(ctx, secretName(containerName), metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete secret %s: %w", secretName(containerName), err)
+	}
+
+	logger.Log.Infof("Deleted secret %s", secretName(containerName))
+	return nil
+}
@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	networkingv1apply "k8s.io/client-go/applyconfigurations/networking/v1"
+
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+	"github.com/StacklokLabs/toolhive/pkg/permissions"
+)
+
+// buildPodSecurityContext derives the pod-level SecurityContext from a
+// permission profile. ToolHive containers are never expected to run as root,
+// so this is applied regardless of what the profile allows at the mount/network
+// level.
+func buildPodSecurityContext(_ *permissions.Profile) *corev1apply.PodSecurityContextApplyConfiguration {
+	runAsNonRoot := true
+	return corev1apply.PodSecurityContext().
+		WithRunAsNonRoot(runAsNonRoot)
+}
+
+// buildContainerSecurityContext derives the container-level SecurityContext
+// from a permission profile, mirroring the hardening the Docker/Podman
+// runtime gets for free from CapDrop: []string{"ALL"} (see
+// getPermissionConfigFromProfile in pkg/container/client.go). The root
+// filesystem is only writable when the profile declares at least one write
+// mount, since a read-only profile has nowhere it's allowed to write anyway.
+func buildContainerSecurityContext(profile *permissions.Profile) *corev1apply.SecurityContextApplyConfiguration {
+	allowPrivilegeEscalation := false
+	readOnlyRootFS := len(profile.Write) == 0
+
+	return corev1apply.SecurityContext().
+		WithAllowPrivilegeEscalation(allowPrivilegeEscalation).
+		WithReadOnlyRootFilesystem(readOnlyRootFS).
+		WithCapabilities(corev1apply.Capabilities().
+			WithDrop(corev1.Capability("ALL"))).
+		WithSeccompProfile(corev1apply.SeccompProfile().
+			WithType(corev1.SeccompProfileTypeRuntimeDefault))
+}
+
+// buildProfileVolumes materializes the profile's read/write mount
+// declarations as pod volumes and matching container volume mounts. Each
+// declaration names a host path, so it becomes a hostPath volume; mounts
+// named in profile.Read are read-only, mounts named in profile.Write are
+// read-write (matching addReadOnlyMounts/addReadWriteMounts in the
+// Docker/Podman runtime).
+func buildProfileVolumes(profile *permissions.Profile) (
+	[]*corev1apply.VolumeApplyConfiguration,
+	[]*corev1apply.VolumeMountApplyConfiguration,
+) {
+	var volumes []*corev1apply.VolumeApplyConfiguration
+	var mounts []*corev1apply.VolumeMountApplyConfiguration
+
+	seen := make(map[string]bool)
+	addMount := func(decl permissions.MountDeclaration, readOnly bool) {
+		source, target, err := decl.Parse()
+		if err != nil {
+			logger.Log.Warnf("Skipping invalid mount declaration: %s (%v)", decl, err)
+			return
+		}
+
+		volumeName := fmt.Sprintf("mount-%d", len(volumes))
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		volumes = append(volumes, corev1apply.Volume().
+			WithName(volumeName).
+			WithHostPath(corev1apply.HostPathVolumeSource().
+				WithPath(source).
+				WithType(hostPathType)))
+
+		mounts = append(mounts, corev1apply.VolumeMount().
+			WithName(volumeName).
+			WithMountPath(target).
+			WithReadOnly(readOnly))
+
+		seen[target] = readOnly
+	}
+
+	for _, decl := range profile.Read {
+		addMount(decl, true)
+	}
+	for _, decl := range profile.Write {
+		addMount(decl, false)
+	}
+
+	return volumes, mounts
+}
+
+// needsEgress reports whether the profile's outbound network permissions
+// require any egress at all, mirroring needsNetworkAccess in
+// pkg/container/client.go.
+func needsEgress(profile *permissions.Profile) bool {
+	if profile == nil || profile.Network == nil || profile.Network.Outbound == nil {
+		return false
+	}
+	outbound := profile.Network.Outbound
+	return outbound.InsecureAllowAll ||
+		len(outbound.AllowTransport) > 0 ||
+		len(outbound.AllowHost) > 0 ||
+		len(outbound.AllowPort) > 0
+}
+
+// buildNetworkPolicy translates a permission profile's outbound network
+// rules into a NetworkPolicy scoped to the workload's pods. With no profile,
+// or a profile that allows no outbound access, the policy denies all egress;
+// InsecureAllowAll drops the Egress policy type entirely so traffic is
+// unrestricted, matching the Docker runtime's "allow all" Squid config.
+func buildNetworkPolicy(
+	containerName, namespace string,
+	podSelectorLabels map[string]string,
+	profile *permissions.Profile,
+) *networkingv1apply.NetworkPolicyApplyConfiguration {
+	policy := networkingv1apply.NetworkPolicy(containerName, namespace).
+		WithSpec(networkingv1apply.NetworkPolicySpec().
+			WithPodSelector(metav1apply.LabelSelector().
+				WithMatchLabels(podSelectorLabels)).
+			WithPolicyTypes(networkingv1.PolicyTypeEgress))
+
+	if profile == nil || profile.Network == nil || profile.Network.Outbound == nil {
+		return policy
+	}
+	outbound := profile.Network.Outbound
+
+	if outbound.InsecureAllowAll {
+		// No PolicyTypes restriction for egress means all egress is allowed;
+		// drop Egress from PolicyTypes so this NetworkPolicy is a no-op for it.
+		policy.Spec.PolicyTypes = []networkingv1.PolicyType{}
+		return policy
+	}
+
+	if !needsEgress(profile) {
+		// PolicyTypes already includes Egress with no rules, which denies all
+		// outbound traffic for the selected pods.
+		return policy
+	}
+
+	rule := networkingv1apply.NetworkPolicyEgressRule()
+	for _, host := range outbound.AllowHost {
+		if _, ipNet, err := net.ParseCIDR(host); err == nil {
+			rule = rule.WithTo(networkingv1apply.NetworkPolicyPeer().
+				WithIPBlock(networkingv1apply.IPBlock().WithCIDR(ipNet.String())))
+			continue
+		}
+		logger.Log.Warnf("AllowHost entry %q is not a CIDR; NetworkPolicy egress rules only support CIDRs, "+
+			"DNS-based destination filtering is enforced by the egress proxy instead", host)
+	}
+
+	for _, port := range outbound.AllowPort {
+		portVal := intstr.FromInt(port)
+		rule = rule.WithPorts(networkingv1apply.NetworkPolicyPort().
+			WithProtocol(corev1.ProtocolTCP).
+			WithPort(portVal))
+	}
+
+	policy.Spec.WithEgress(rule)
+	return policy
+}
+
+// applyNetworkPolicy creates or updates the NetworkPolicy guarding a
+// workload's pods, using the same server-side apply pattern as the
+// StatefulSet and headless Service.
+func (c *Client) applyNetworkPolicy(
+	ctx context.Context,
+	containerName, namespace string,
+	podSelectorLabels map[string]string,
+	profile *permissions.Profile,
+) error {
+	policy := buildNetworkPolicy(containerName, namespace, podSelectorLabels, profile)
+
+	_, err := c.client.NetworkingV1().NetworkPolicies(namespace).Apply(ctx, policy, metav1.ApplyOptions{
+		FieldManager: "toolhive-container-manager",
+		Force:        true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply network policy: %v", err)
+	}
+
+	logger.Log.Infof("Applied network policy %s", containerName)
+	return nil
+}
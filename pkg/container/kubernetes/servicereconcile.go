@@ -0,0 +1,110 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1apply "k8s.io/client-go/applyconfigurations/core/v1"
+
+	"github.com/StacklokLabs/toolhive/pkg/container/runtime"
+)
+
+// getExistingService fetches containerName's current Service, returning a nil
+// Service (not an error) when it doesn't exist yet, which is the common case
+// on first create.
+func (c *Client) getExistingService(ctx context.Context, containerName, namespace string) (*corev1.Service, error) {
+	existing, err := c.client.CoreV1().Services(namespace).Get(ctx, containerName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing service %s: %w", containerName, err)
+	}
+	return existing, nil
+}
+
+// preserveAllocatedNodePorts copies the NodePort the API server allocated for
+// each matching port in existing into servicePorts, for any port whose apply
+// configuration doesn't already request a specific NodePort. Without this,
+// re-applying the Service with Force: true on every toolhive restart would
+// clobber the allocation with a nil NodePort, the same class of churn fixed
+// upstream by kubernetes/kubernetes#103532.
+func preserveAllocatedNodePorts(servicePorts []*corev1apply.ServicePortApplyConfiguration, existing *corev1.Service) {
+	if existing == nil {
+		return
+	}
+
+	for _, sp := range servicePorts {
+		if sp.NodePort != nil {
+			// The caller explicitly requested a NodePort; don't override it.
+			continue
+		}
+		if existingPort := findExistingServicePort(existing, sp); existingPort != nil && existingPort.NodePort != 0 {
+			sp.WithNodePort(existingPort.NodePort)
+		}
+	}
+}
+
+// findExistingServicePort locates the port in existing matching sp, by name
+// first since names stay stable across re-applies, falling back to port
+// number plus protocol.
+func findExistingServicePort(existing *corev1.Service, sp *corev1apply.ServicePortApplyConfiguration) *corev1.ServicePort {
+	if sp.Name != nil {
+		for i := range existing.Spec.Ports {
+			if existing.Spec.Ports[i].Name == *sp.Name {
+				return &existing.Spec.Ports[i]
+			}
+		}
+	}
+
+	if sp.Port != nil && sp.Protocol != nil {
+		for i := range existing.Spec.Ports {
+			p := &existing.Spec.Ports[i]
+			if p.Port == *sp.Port && p.Protocol == *sp.Protocol {
+				return p
+			}
+		}
+	}
+
+	return nil
+}
+
+// preserveClusterIP copies the existing Service's allocated ClusterIP onto
+// serviceSpec, unless the caller already set one explicitly (e.g. "None" for
+// a headless service). Re-applying with no ClusterIP set would have the API
+// server allocate a new one, breaking any client that resolved the old
+// address.
+func preserveClusterIP(
+	serviceSpec *corev1apply.ServiceSpecApplyConfiguration,
+	existing *corev1.Service,
+) *corev1apply.ServiceSpecApplyConfiguration {
+	if existing == nil || serviceSpec.ClusterIP != nil {
+		return serviceSpec
+	}
+	if existing.Spec.ClusterIP == "" || existing.Spec.ClusterIP == corev1.ClusterIPNone {
+		return serviceSpec
+	}
+	return serviceSpec.WithClusterIP(existing.Spec.ClusterIP)
+}
+
+// preserveHealthCheckNodePort copies the existing Service's allocated
+// HealthCheckNodePort onto serviceSpec when options didn't request one
+// explicitly. Like a NodePort, it's allocated once by the API server (for
+// LoadBalancer/NodePort services with ExternalTrafficPolicy: Local) and must
+// be carried forward across re-applies.
+func preserveHealthCheckNodePort(
+	serviceSpec *corev1apply.ServiceSpecApplyConfiguration,
+	existing *corev1.Service,
+	options *runtime.CreateContainerOptions,
+) *corev1apply.ServiceSpecApplyConfiguration {
+	if existing == nil || options.HealthCheckNodePort != 0 {
+		return serviceSpec
+	}
+	if existing.Spec.HealthCheckNodePort == 0 {
+		return serviceSpec
+	}
+	return serviceSpec.WithHealthCheckNodePort(existing.Spec.HealthCheckNodePort)
+}
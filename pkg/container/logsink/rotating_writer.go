@@ -0,0 +1,169 @@
+// Package logsink provides an opt-in, size- and age-based rotating file
+// sink (à la lumberjack) that toolhive can write a managed server's
+// stdout/stderr to, so operators can retain logs across container restarts.
+package logsink
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options configures a rotating Writer.
+type Options struct {
+	// Directory is where the active log file and its rotated backups live.
+	Directory string
+	// Filename is the base name of the active log file; rotated backups are
+	// renamed with a timestamp suffix appended.
+	Filename string
+	// MaxSizeBytes rotates the active file once writing would grow it past
+	// this size. 0 disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAge rotates the active file once it's been open longer than this,
+	// and prunes backups older than this. 0 disables age-based rotation and
+	// pruning.
+	MaxAge time.Duration
+	// MaxBackups caps the number of rotated backups kept, oldest removed
+	// first. 0 means unlimited.
+	MaxBackups int
+}
+
+// Writer is an io.WriteCloser that rotates the underlying file by size
+// and/or age, per Options.
+type Writer struct {
+	opts Options
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// New creates a rotating Writer per opts, creating the log directory if it
+// doesn't already exist.
+func New(opts Options) (*Writer, error) {
+	if err := os.MkdirAll(opts.Directory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	return &Writer{opts: opts}, nil
+}
+
+// Write implements io.Writer, rotating the active file first if needed.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.rotateIfNeeded(len(p)); err != nil {
+		return 0, err
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close implements io.Closer.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	return w.file.Close()
+}
+
+func (w *Writer) activePath() string {
+	return filepath.Join(w.opts.Directory, w.opts.Filename)
+}
+
+func (w *Writer) rotateIfNeeded(nextWrite int) error {
+	if w.file == nil {
+		return w.openActiveFile()
+	}
+
+	sizeExceeded := w.opts.MaxSizeBytes > 0 && w.size+int64(nextWrite) > w.opts.MaxSizeBytes
+	ageExceeded := w.opts.MaxAge > 0 && time.Since(w.openedAt) > w.opts.MaxAge
+	if !sizeExceeded && !ageExceeded {
+		return nil
+	}
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file before rotating: %w", err)
+	}
+
+	backupName := fmt.Sprintf("%s.%s", w.opts.Filename, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(w.activePath(), filepath.Join(w.opts.Directory, backupName)); err != nil {
+		return fmt.Errorf("failed to archive log file: %w", err)
+	}
+
+	if err := w.pruneBackups(); err != nil {
+		return err
+	}
+
+	return w.openActiveFile()
+}
+
+// pruneBackups removes backups older than MaxAge and, beyond that, the
+// oldest backups past MaxBackups.
+func (w *Writer) pruneBackups() error {
+	if w.opts.MaxAge <= 0 && w.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(w.opts.Directory)
+	if err != nil {
+		return fmt.Errorf("failed to list log directory: %w", err)
+	}
+
+	prefix := w.opts.Filename + "."
+	var backups []os.DirEntry
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			backups = append(backups, e)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name() < backups[j].Name() })
+
+	var kept []os.DirEntry
+	for _, e := range backups {
+		if w.opts.MaxAge > 0 {
+			info, err := e.Info()
+			if err == nil && time.Since(info.ModTime()) > w.opts.MaxAge {
+				_ = os.Remove(filepath.Join(w.opts.Directory, e.Name()))
+				continue
+			}
+		}
+		kept = append(kept, e)
+	}
+
+	if w.opts.MaxBackups > 0 && len(kept) > w.opts.MaxBackups {
+		for _, e := range kept[:len(kept)-w.opts.MaxBackups] {
+			_ = os.Remove(filepath.Join(w.opts.Directory, e.Name()))
+		}
+	}
+
+	return nil
+}
+
+func (w *Writer) openActiveFile() error {
+	f, err := os.OpenFile(w.activePath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,383 @@
+package mirror
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// blobStore persists content-addressed blobs and their manifests under a
+// data directory, in a layout simple enough to tar up for import/export:
+//
+//	<dataDir>/blobs/<algorithm>/<hex digest>
+//	<dataDir>/manifests/<repo>/<ref>
+//	<dataDir>/manifests/<repo>/<ref>.mediatype
+type blobStore struct {
+	dataDir string
+}
+
+func newBlobStore(dataDir string) (*blobStore, error) {
+	for _, dir := range []string{"blobs", "manifests"} {
+		if err := os.MkdirAll(filepath.Join(dataDir, dir), 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create %s dir: %w", dir, err)
+		}
+	}
+	return &blobStore{dataDir: dataDir}, nil
+}
+
+// blobPath maps a digest of the form "sha256:<hex>" to its on-disk path.
+func (s *blobStore) blobPath(digest string) (string, error) {
+	alg, hex, ok := strings.Cut(digest, ":")
+	if !ok || alg == "" || hex == "" {
+		return "", fmt.Errorf("invalid digest %q", digest)
+	}
+	return filepath.Join(s.dataDir, "blobs", alg, hex), nil
+}
+
+func (s *blobStore) manifestPath(repo, ref string) string {
+	return filepath.Join(s.dataDir, "manifests", repo, ref)
+}
+
+// openBlob returns the contents of the blob for digest and its size.
+func (s *blobStore) openBlob(digest string) (*os.File, int64, error) {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(path) //nolint:gosec // path is derived from a content digest under our own data dir
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close() //nolint:errcheck
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// saveBlob writes data to the blob store under digest, if it isn't already cached.
+func (s *blobStore) saveBlob(digest string, data io.Reader) error {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil // already cached
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp) //nolint:gosec // path is derived from a content digest under our own data dir
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	if _, err := io.Copy(f, data); err != nil {
+		f.Close()      //nolint:errcheck
+		os.Remove(tmp) //nolint:errcheck
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close blob file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadManifest returns the cached manifest body and media type for repo/ref.
+func (s *blobStore) loadManifest(repo, ref string) ([]byte, string, error) {
+	path := s.manifestPath(repo, ref)
+	data, err := os.ReadFile(path) //nolint:gosec // path is built from caller-controlled repo/ref, scoped under our data dir
+	if err != nil {
+		return nil, "", err
+	}
+	mediaType, err := os.ReadFile(path + ".mediatype") //nolint:gosec // sidecar of the manifest read above
+	if err != nil {
+		return nil, "", err
+	}
+	return data, string(mediaType), nil
+}
+
+// saveManifest caches a manifest body and its media type for repo/ref.
+func (s *blobStore) saveManifest(repo, ref string, data []byte, mediaType string) error {
+	path := s.manifestPath(repo, ref)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o640); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return os.WriteFile(path+".mediatype", []byte(mediaType), 0o640)
+}
+
+// stats reports the number of cached blobs and their total size on disk.
+func (s *blobStore) stats() (count int, size int64, err error) {
+	root := filepath.Join(s.dataDir, "blobs")
+	err = filepath.Walk(root, func(_ string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !info.IsDir() {
+			count++
+			size += info.Size()
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	return count, size, err
+}
+
+// manifestCount reports the number of distinct cached image references.
+func (s *blobStore) manifestCount() (int, error) {
+	count := 0
+	root := filepath.Join(s.dataDir, "manifests")
+	err := filepath.Walk(root, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if !info.IsDir() && !strings.HasSuffix(path, ".mediatype") {
+			count++
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// PruneResult reports what Prune removed.
+type PruneResult struct {
+	// RemovedBlobs is the number of blobs deleted because no cached
+	// manifest referenced them.
+	RemovedBlobs int
+	// ReclaimedBytes is the total size of the removed blobs.
+	ReclaimedBytes int64
+}
+
+// prune removes every blob not referenced by any cached manifest.
+func (s *blobStore) prune() (PruneResult, error) {
+	referenced, err := s.referencedDigests()
+	if err != nil {
+		return PruneResult{}, fmt.Errorf("failed to collect referenced digests: %w", err)
+	}
+
+	var result PruneResult
+	root := filepath.Join(s.dataDir, "blobs")
+	err = filepath.Walk(root, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		digest := strings.ReplaceAll(rel, string(filepath.Separator), ":")
+		if referenced[digest] {
+			return nil
+		}
+		result.RemovedBlobs++
+		result.ReclaimedBytes += info.Size()
+		return os.Remove(path)
+	})
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	return result, err
+}
+
+// referencedDigests returns every blob digest mentioned by a cached
+// manifest's raw body. This is a best-effort text scan rather than a full
+// OCI manifest parse, since the mirror only needs to know which blobs are
+// still reachable, not their structure.
+func (s *blobStore) referencedDigests() (map[string]bool, error) {
+	referenced := make(map[string]bool)
+	root := filepath.Join(s.dataDir, "manifests")
+	err := filepath.Walk(root, func(path string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if info.IsDir() || strings.HasSuffix(path, ".mediatype") {
+			return nil
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // path comes from our own walk of the manifest directory
+		if err != nil {
+			return err
+		}
+		for _, digest := range extractDigests(string(data)) {
+			referenced[digest] = true
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return referenced, nil
+	}
+	return referenced, err
+}
+
+// extractDigests scans a manifest body for "sha256:<hex>"-shaped substrings.
+func extractDigests(body string) []string {
+	var digests []string
+	const prefix = "sha256:"
+	for idx := 0; ; {
+		start := strings.Index(body[idx:], prefix)
+		if start == -1 {
+			break
+		}
+		start += idx
+		end := start + len(prefix)
+		for end < len(body) && isHexDigit(body[end]) {
+			end++
+		}
+		if end-start > len(prefix) {
+			digests = append(digests, body[start:end])
+		}
+		idx = end
+	}
+	return digests
+}
+
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+// importTarball loads a gzip-compressed tar of a blobStore's data directory
+// (as produced by exportTarball) into this store, for air-gapped bootstrap.
+func (s *blobStore) importTarball(tarPath string) error {
+	f, err := os.Open(tarPath) //nolint:gosec // path is an operator-supplied CLI argument
+	if err != nil {
+		return fmt.Errorf("failed to open tarball: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to open tarball gzip stream: %w", err)
+	}
+	defer gz.Close() //nolint:errcheck
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball entry: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dest := filepath.Join(s.dataDir, filepath.Clean(hdr.Name)) //nolint:gosec // cleaned below
+		if !strings.HasPrefix(dest, filepath.Clean(s.dataDir)+string(filepath.Separator)) {
+			return fmt.Errorf("tarball entry %q escapes data dir", hdr.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0o750); err != nil {
+			return fmt.Errorf("failed to create directory for %q: %w", hdr.Name, err)
+		}
+		out, err := os.Create(dest) //nolint:gosec // dest is validated above
+		if err != nil {
+			return fmt.Errorf("failed to create %q: %w", hdr.Name, err)
+		}
+		if _, err := io.Copy(out, tr); err != nil { //nolint:gosec // tar entry size is bounded by the archive itself
+			out.Close() //nolint:errcheck
+			return fmt.Errorf("failed to write %q: %w", hdr.Name, err)
+		}
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to close %q: %w", hdr.Name, err)
+		}
+	}
+}
+
+// exportTarball writes the manifests (and their referenced blobs) for refs,
+// formatted as "repo:ref", to a gzip-compressed tar at tarPath.
+func (s *blobStore) exportTarball(tarPath string, refs []string) error {
+	out, err := os.Create(tarPath) //nolint:gosec // path is an operator-supplied CLI argument
+	if err != nil {
+		return fmt.Errorf("failed to create tarball: %w", err)
+	}
+	defer out.Close() //nolint:errcheck
+
+	gz := gzip.NewWriter(out)
+	defer gz.Close() //nolint:errcheck
+
+	tw := tar.NewWriter(gz)
+	defer tw.Close() //nolint:errcheck
+
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		repo, tag, ok := strings.Cut(ref, ":")
+		if !ok {
+			return fmt.Errorf("invalid image reference %q: expected repo:tag", ref)
+		}
+
+		manifestPath := s.manifestPath(repo, tag)
+		if err := addFileToTar(tw, s.dataDir, manifestPath); err != nil {
+			return fmt.Errorf("failed to export manifest for %q: %w", ref, err)
+		}
+		if err := addFileToTar(tw, s.dataDir, manifestPath+".mediatype"); err != nil {
+			return fmt.Errorf("failed to export manifest media type for %q: %w", ref, err)
+		}
+
+		data, _, err := s.loadManifest(repo, tag)
+		if err != nil {
+			return fmt.Errorf("failed to read manifest for %q: %w", ref, err)
+		}
+		for _, digest := range extractDigests(string(data)) {
+			if seen[digest] {
+				continue
+			}
+			seen[digest] = true
+			blobPath, err := s.blobPath(digest)
+			if err != nil {
+				return err
+			}
+			if err := addFileToTar(tw, s.dataDir, blobPath); err != nil {
+				return fmt.Errorf("failed to export blob %s: %w", digest, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func addFileToTar(tw *tar.Writer, dataDir, path string) error {
+	rel, err := filepath.Rel(dataDir, path)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path) //nolint:gosec // path is built from our own data dir layout
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = rel
+
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,284 @@
+// Package mirror implements an embedded, OCI distribution-spec-compatible
+// image mirror. It acts as a read-through cache on first pull and serves
+// strictly from its local blob store when the upstream registry is
+// unreachable, so `thv run` can resolve MCP images in air-gapped
+// environments and across multiple local container runtimes without
+// re-pulling.
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+)
+
+// Config controls whether the embedded mirror runs and where it stores data.
+type Config struct {
+	// Enabled is reported back by Status. Nothing starts the mirror based on
+	// it: the container factory does not start the mirror whether it is true
+	// or false, and the listener runs only once Start is called.
+	Enabled bool
+
+	// ListenAddr is the localhost address the mirror listens on, e.g.
+	// "127.0.0.1:5050". Defaults to DefaultListenAddr when empty.
+	ListenAddr string
+
+	// DataDir is where blobs and manifests are persisted, normally a
+	// subdirectory of the ToolHive data dir. Required: New returns an error
+	// when it is empty. DefaultDataDir builds the usual path under a base dir.
+	DataDir string
+}
+
+// DefaultListenAddr is used when Config.ListenAddr is empty.
+const DefaultListenAddr = "127.0.0.1:5050"
+
+// DefaultDataDirName is the subdirectory of the ToolHive data dir that
+// DefaultDataDir returns.
+const DefaultDataDirName = "mirror"
+
+// Status reports the current state of the mirror's local cache.
+type Status struct {
+	// Enabled mirrors Config.Enabled.
+	Enabled bool
+	// ListenAddr is the address the mirror is (or would be) listening on.
+	ListenAddr string
+	// Images is the number of distinct image references cached locally.
+	Images int
+	// Blobs is the number of content-addressed blobs in the store.
+	Blobs int
+	// SizeBytes is the total size on disk of the blob store.
+	SizeBytes int64
+}
+
+// Mirror is an embedded OCI distribution-spec mirror: a read-through cache
+// on first pull, read-only when the upstream registry can't be reached.
+type Mirror struct {
+	cfg   Config
+	store *blobStore
+
+	mu     sync.Mutex
+	server *http.Server
+}
+
+// New creates a Mirror from cfg, filling in DefaultListenAddr when ListenAddr
+// is empty; an empty DataDir is an error. It does not start the HTTP listener; call Start for that.
+func New(cfg Config) (*Mirror, error) {
+	if cfg.ListenAddr == "" {
+		cfg.ListenAddr = DefaultListenAddr
+	}
+	if cfg.DataDir == "" {
+		return nil, fmt.Errorf("mirror: DataDir must be set")
+	}
+
+	store, err := newBlobStore(cfg.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("mirror: failed to open blob store: %w", err)
+	}
+
+	return &Mirror{cfg: cfg, store: store}, nil
+}
+
+// Start begins listening on cfg.ListenAddr and serving the distribution-spec
+// endpoints backed by the local blob store, pulling through to the image's
+// real upstream registry on a cache miss.
+func (m *Mirror) Start(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.server != nil {
+		return fmt.Errorf("mirror: already started")
+	}
+
+	ln, err := net.Listen("tcp", m.cfg.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("mirror: failed to listen on %s: %w", m.cfg.ListenAddr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", m.handleDistributionAPI)
+
+	m.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Log.Info(fmt.Sprintf("Image mirror listening on %s, caching blobs under %s", m.cfg.ListenAddr, m.cfg.DataDir))
+		if err := m.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			logger.Log.Error(fmt.Sprintf("Image mirror server error: %v", err))
+		}
+	}()
+
+	return nil
+}
+
+// Stop shuts down the mirror's HTTP listener.
+func (m *Mirror) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.server == nil {
+		return nil
+	}
+	err := m.server.Shutdown(ctx)
+	m.server = nil
+	return err
+}
+
+// Status reports the current size and contents of the local cache.
+func (m *Mirror) Status() (Status, error) {
+	blobs, size, err := m.store.stats()
+	if err != nil {
+		return Status{}, fmt.Errorf("mirror: failed to read blob store stats: %w", err)
+	}
+	images, err := m.store.manifestCount()
+	if err != nil {
+		return Status{}, fmt.Errorf("mirror: failed to count cached manifests: %w", err)
+	}
+
+	return Status{
+		Enabled:    m.cfg.Enabled,
+		ListenAddr: m.cfg.ListenAddr,
+		Images:     images,
+		Blobs:      blobs,
+		SizeBytes:  size,
+	}, nil
+}
+
+// Prune removes every blob that is not referenced by any cached manifest,
+// analogous to `podman container prune` for the mirror's own cache.
+func (m *Mirror) Prune() (PruneResult, error) {
+	return m.store.prune()
+}
+
+// Import loads the image tarball at tarPath into the local cache, for
+// air-gapped bootstrap of a new mirror instance.
+func (m *Mirror) Import(tarPath string) error {
+	return m.store.importTarball(tarPath)
+}
+
+// Export writes the cached manifests and blobs for refs to a tarball at
+// tarPath, for transfer to an air-gapped environment.
+func (m *Mirror) Export(tarPath string, refs []string) error {
+	return m.store.exportTarball(tarPath, refs)
+}
+
+// handleDistributionAPI serves the small subset of the OCI distribution spec
+// the mirror needs: manifest and blob retrieval, with a read-through fetch
+// from the image's upstream registry on a cache miss.
+func (m *Mirror) handleDistributionAPI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v2/" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	repo, kind, ref, ok := parseDistributionPath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch kind {
+	case "manifests":
+		m.serveManifest(w, r, repo, ref)
+	case "blobs":
+		m.serveBlob(w, r, repo, ref)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *Mirror) serveManifest(w http.ResponseWriter, r *http.Request, repo, ref string) {
+	data, mediaType, err := m.store.loadManifest(repo, ref)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data, mediaType, err = pullThroughManifest(r.Context(), repo, ref)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("manifest %s/%s not available offline: %v", repo, ref, err), http.StatusNotFound)
+			return
+		}
+		if err := m.store.saveManifest(repo, ref, data, mediaType); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: failed to cache manifest %s/%s: %v", repo, ref, err))
+		}
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	if _, err := w.Write(data); err != nil {
+		logger.Log.Warn(fmt.Sprintf("Warning: failed to write manifest response: %v", err))
+	}
+}
+
+func (m *Mirror) serveBlob(w http.ResponseWriter, r *http.Request, repo, digest string) {
+	f, size, err := m.store.openBlob(digest)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		path, fetchErr := pullThroughBlob(r.Context(), repo, digest, m.store)
+		if fetchErr != nil {
+			http.Error(w, fmt.Sprintf("blob %s not available offline: %v", digest, fetchErr), http.StatusNotFound)
+			return
+		}
+		f, err = os.Open(path) //nolint:gosec // path is derived from a content digest under our own data dir
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		info, statErr := f.Stat()
+		if statErr == nil {
+			size = info.Size()
+		}
+	}
+	defer f.Close() //nolint:errcheck
+
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", size))
+	w.Header().Set("Docker-Content-Digest", digest)
+	if _, err := io.Copy(w, f); err != nil {
+		logger.Log.Warn(fmt.Sprintf("Warning: failed to write blob response: %v", err))
+	}
+}
+
+// parseDistributionPath splits a request path of the form
+// /v2/<repo>/manifests/<ref> or /v2/<repo>/blobs/<digest> into its parts.
+func parseDistributionPath(path string) (repo, kind, ref string, ok bool) {
+	const prefix = "/v2/"
+	if len(path) <= len(prefix) {
+		return "", "", "", false
+	}
+	rest := path[len(prefix):]
+
+	for _, kind := range []string{"manifests", "blobs"} {
+		marker := "/" + kind + "/"
+		if idx := lastIndex(rest, marker); idx >= 0 {
+			return rest[:idx], kind, rest[idx+len(marker):], true
+		}
+	}
+	return "", "", "", false
+}
+
+func lastIndex(s, substr string) int {
+	for i := len(s) - len(substr); i >= 0; i-- {
+		if s[i:i+len(substr)] == substr {
+			return i
+		}
+	}
+	return -1
+}
+
+// DefaultDataDir returns the mirror's default data directory under
+// baseDataDir (the ToolHive data dir).
+func DefaultDataDir(baseDataDir string) string {
+	return filepath.Join(baseDataDir, DefaultDataDirName)
+}
@@ -0,0 +1,94 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pullThroughClient is used for upstream fetches on a cache miss. A short
+// timeout keeps the mirror from hanging for the default HTTP request
+// duration when the environment is actually air-gapped.
+var pullThroughClient = &http.Client{Timeout: 10 * time.Second}
+
+// splitUpstream separates the registry host from the rest of a mirror repo
+// path. The mirror expects repo to be of the form "<host>/<path>", e.g.
+// "docker.io/library/nginx", so it can route a cache miss to the right
+// upstream registry.
+func splitUpstream(repo string) (host, path string, ok bool) {
+	host, path, ok = strings.Cut(repo, "/")
+	return host, path, ok && host != "" && path != ""
+}
+
+// pullThroughManifest fetches a manifest from the image's upstream registry
+// on a cache miss. It returns an "upstream registry unreachable" error when
+// the upstream can't be reached, since that's the expected state in an
+// air-gapped environment.
+func pullThroughManifest(ctx context.Context, repo, ref string) ([]byte, string, error) {
+	host, path, ok := splitUpstream(repo)
+	if !ok {
+		return nil, "", fmt.Errorf("repo %q is not of the form <registry host>/<path>", repo)
+	}
+
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, path, ref)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json")
+
+	resp, err := pullThroughClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("upstream registry unreachable: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("upstream registry returned %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest response: %w", err)
+	}
+
+	mediaType := resp.Header.Get("Content-Type")
+	if mediaType == "" {
+		mediaType = "application/vnd.oci.image.manifest.v1+json"
+	}
+	return data, mediaType, nil
+}
+
+// pullThroughBlob fetches a blob from the image's upstream registry on a
+// cache miss, stores it in store, and returns its on-disk path.
+func pullThroughBlob(ctx context.Context, repo, digest string, store *blobStore) (string, error) {
+	host, path, ok := splitUpstream(repo)
+	if !ok {
+		return "", fmt.Errorf("repo %q is not of the form <registry host>/<path>", repo)
+	}
+
+	url := fmt.Sprintf("https://%s/v2/%s/blobs/%s", host, path, digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := pullThroughClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("upstream registry unreachable: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("upstream registry returned %s", resp.Status)
+	}
+
+	if err := store.saveBlob(digest, resp.Body); err != nil {
+		return "", fmt.Errorf("failed to cache blob: %w", err)
+	}
+
+	return store.blobPath(digest)
+}
@@ -0,0 +1,73 @@
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RegistriesConfPath is where WriteRegistriesConf writes Podman/Buildah's
+// registry mirror configuration, following the documented location for a
+// drop-in under containers-registries.conf.d(5).
+const RegistriesConfPath = "/etc/containers/registries.conf.d/999-toolhive-mirror.conf"
+
+// WriteRegistriesConf writes a Podman-style registries.conf drop-in that
+// points pulls of docker.io images at the embedded mirror first, falling
+// back to docker.io when the mirror doesn't have the image cached; pulls
+// from other registries are not redirected. This is
+// the host-level integration path for Podman; Docker has no equivalent
+// per-registry mirror config and instead needs the explicit image rewrite
+// performed by RewriteImageRef.
+func WriteRegistriesConf(mirrorAddr string) error {
+	contents := fmt.Sprintf(`# Managed by ToolHive. Do not edit by hand.
+[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry.mirror]]
+location = %q
+insecure = true
+`, mirrorAddr)
+
+	if err := os.MkdirAll(filepath.Dir(RegistriesConfPath), 0o755); err != nil { //nolint:gosec // standard config dir perms
+		return fmt.Errorf("failed to create registries.conf.d directory: %w", err)
+	}
+	if err := os.WriteFile(RegistriesConfPath, []byte(contents), 0o644); err != nil { //nolint:gosec // registries.conf is world-readable config
+		return fmt.Errorf("failed to write registries.conf mirror entry: %w", err)
+	}
+	return nil
+}
+
+// RemoveRegistriesConf removes the drop-in written by WriteRegistriesConf.
+func RemoveRegistriesConf() error {
+	err := os.Remove(RegistriesConfPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// RewriteImageRef rewrites image to point at the embedded mirror instead of
+// its upstream registry, for runtimes like Docker that have no host-level
+// registry mirror config. The original registry host is preserved as the
+// first path segment so the mirror can still route a cache miss upstream
+// (see splitUpstream).
+func RewriteImageRef(mirrorAddr, image string) string {
+	host, path, ok := splitUpstream(normalizeImageRef(image))
+	if !ok {
+		return image
+	}
+	return fmt.Sprintf("%s/%s/%s", mirrorAddr, host, path)
+}
+
+// normalizeImageRef adds the implicit "docker.io" host Docker assumes for
+// unqualified image references (e.g. "nginx" or "library/nginx"), so
+// RewriteImageRef always has a host to route a cache miss back to.
+func normalizeImageRef(image string) string {
+	first, _, hasSlash := strings.Cut(image, "/")
+	if hasSlash && (strings.Contains(first, ".") || strings.Contains(first, ":") || first == "localhost") {
+		return image
+	}
+	return "docker.io/" + image
+}
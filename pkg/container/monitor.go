@@ -17,6 +17,13 @@ type Monitor struct {
 	wg            sync.WaitGroup
 	running       bool
 	mutex         sync.Mutex
+
+	// memoryThresholdBytes and cpuThresholdPercent, when non-zero, make
+	// StartMonitoring also watch ContainerStats and forward a
+	// ErrResourceThresholdExceeded on errorCh the first time a sample
+	// breaches either one. Set via SetResourceThresholds.
+	memoryThresholdBytes uint64
+	cpuThresholdPercent  float64
 }
 
 // NewMonitor creates a new container monitor
@@ -30,6 +37,18 @@ func NewMonitor(runtime Runtime, containerID, containerName string) *Monitor {
 	}
 }
 
+// SetResourceThresholds arms memory and/or CPU threshold monitoring: once
+// StartMonitoring is running, a ContainerStats sample that exceeds either
+// threshold is reported as a ErrResourceThresholdExceeded on errorCh. Pass 0
+// for a threshold to leave it unmonitored.
+func (m *Monitor) SetResourceThresholds(memoryThresholdBytes uint64, cpuThresholdPercent float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.memoryThresholdBytes = memoryThresholdBytes
+	m.cpuThresholdPercent = cpuThresholdPercent
+}
+
 // StartMonitoring starts monitoring the container
 func (m *Monitor) StartMonitoring(ctx context.Context) (<-chan error, error) {
 	m.mutex.Lock()
@@ -54,6 +73,11 @@ func (m *Monitor) StartMonitoring(ctx context.Context) (<-chan error, error) {
 	// Start monitoring in a goroutine
 	go m.monitor(ctx)
 
+	if m.memoryThresholdBytes > 0 || m.cpuThresholdPercent > 0 {
+		m.wg.Add(1)
+		go m.monitorResourceThresholds(ctx)
+	}
+
 	return m.errorCh, nil
 }
 
@@ -71,11 +95,127 @@ func (m *Monitor) StopMonitoring() {
 	m.running = false
 }
 
-// monitor checks the container status periodically
+// monitor subscribes to the runtime's event stream and reacts to the
+// monitored container's die/oom/kill/destroy events as they arrive, instead
+// of polling IsContainerRunning on a ticker. It falls back to pollMonitor's
+// 5-second polling if the subscription can't be established, or if the
+// stream ends without ever reporting the container's exit.
 func (m *Monitor) monitor(ctx context.Context) {
 	defer m.wg.Done()
 
-	// Check interval
+	events, err := m.runtime.Events(ctx, EventFilter{ContainerID: m.containerID})
+	if err != nil {
+		m.pollMonitor(ctx)
+		return
+	}
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case event, ok := <-events:
+			if !ok {
+				// The stream ended without telling us the container exited;
+				// fall back to polling rather than monitor silently.
+				m.pollMonitor(ctx)
+				return
+			}
+			if event.ContainerID != m.containerID {
+				continue
+			}
+			if exitErr := m.exitErrorForEvent(event); exitErr != nil {
+				m.errorCh <- exitErr
+				return
+			}
+		}
+	}
+}
+
+// exitErrorForEvent returns the error StartMonitoring's caller should see
+// for event, or nil if event isn't a lifecycle-ending one.
+func (m *Monitor) exitErrorForEvent(event Event) error {
+	switch event.Action {
+	case "die":
+		exitCode := 0
+		if event.ExitCode != nil {
+			exitCode = *event.ExitCode
+		}
+		return NewContainerError(
+			ErrContainerExited,
+			m.containerID,
+			fmt.Sprintf("Container %s (%s) exited with code %d", m.containerName, m.containerID, exitCode),
+		)
+	case "oom":
+		return NewContainerError(
+			ErrContainerExited,
+			m.containerID,
+			fmt.Sprintf("Container %s (%s) was killed by the OOM killer", m.containerName, m.containerID),
+		)
+	case "kill", "destroy":
+		return NewContainerError(
+			ErrContainerExited,
+			m.containerID,
+			fmt.Sprintf("Container %s (%s) was %s", m.containerName, m.containerID, event.Action),
+		)
+	default:
+		return nil
+	}
+}
+
+// monitorResourceThresholds streams ContainerStats for the monitored
+// container and reports the first sample that breaches either configured
+// threshold as a ErrResourceThresholdExceeded on errorCh. It exits quietly if
+// the stats stream can't be established, leaving die/oom/kill monitoring in
+// monitor to catch an actual crash.
+func (m *Monitor) monitorResourceThresholds(ctx context.Context) {
+	defer m.wg.Done()
+
+	stats, err := m.runtime.ContainerStats(ctx, m.containerID, true)
+	if err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case sample, ok := <-stats:
+			if !ok {
+				return
+			}
+			if breachErr := m.thresholdErrorForStats(sample); breachErr != nil {
+				m.errorCh <- breachErr
+				return
+			}
+		}
+	}
+}
+
+// thresholdErrorForStats returns a ErrResourceThresholdExceeded if stats
+// breaches the configured memory or CPU threshold, or nil otherwise.
+func (m *Monitor) thresholdErrorForStats(stats ContainerStats) error {
+	if m.memoryThresholdBytes > 0 && stats.MemoryUsageBytes > m.memoryThresholdBytes {
+		return NewContainerError(
+			ErrResourceThresholdExceeded,
+			m.containerID,
+			fmt.Sprintf("Container %s (%s) memory usage %d bytes exceeds threshold %d bytes",
+				m.containerName, m.containerID, stats.MemoryUsageBytes, m.memoryThresholdBytes),
+		)
+	}
+	if m.cpuThresholdPercent > 0 && stats.CPUPercent > m.cpuThresholdPercent {
+		return NewContainerError(
+			ErrResourceThresholdExceeded,
+			m.containerID,
+			fmt.Sprintf("Container %s (%s) CPU usage %.2f%% exceeds threshold %.2f%%",
+				m.containerName, m.containerID, stats.CPUPercent, m.cpuThresholdPercent),
+		)
+	}
+	return nil
+}
+
+// pollMonitor is the original 5-second polling implementation, used when the
+// event stream can't be established or drops out from under monitor.
+func (m *Monitor) pollMonitor(ctx context.Context) {
 	checkInterval := 5 * time.Second
 
 	ticker := time.NewTicker(checkInterval)
@@ -121,4 +261,4 @@ func (m *Monitor) monitor(ctx context.Context) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,111 @@
+// Package podman implements the libpod-native container runtime backend.
+// Rather than going through Podman's Docker-compatibility socket (the path
+// pkg/container/docker takes for every runtime, Podman included), it speaks
+// libpod's own REST API directly, so toolhive can use libpod features the
+// compat shim doesn't expose -- real pods, healthchecks, generate-kube/
+// play-kube, and per-container DNS server lists among them.
+package podman
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+)
+
+// apiVersion is the libpod REST API version toolhive speaks.
+const apiVersion = "v4.0.0"
+
+// Client implements runtime.Backend against a libpod REST API socket.
+type Client struct {
+	http       *http.Client
+	socketPath string
+}
+
+var _ runtime.Backend = (*Client)(nil)
+
+// NewClient creates a Client that speaks libpod's REST API over the Unix
+// socket at socketPath, and verifies the daemon is reachable before
+// returning.
+func NewClient(ctx context.Context, socketPath string) (*Client, error) {
+	c := &Client{
+		socketPath: socketPath,
+		http: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+
+	if err := c.ping(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ping verifies the libpod daemon behind the socket is reachable.
+func (c *Client) ping(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodGet, "/libpod/_ping", nil)
+	if err != nil {
+		return fmt.Errorf("failed to ping libpod: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("libpod ping returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// do issues an HTTP request for path (relative to /<apiVersion>) over the
+// Unix socket. Requests are addressed to a fixed "localhost" host since
+// libpod's REST API, like Docker's, ignores it and only uses the socket.
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	url := fmt.Sprintf("http://localhost/%s%s", apiVersion, path)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.http.Do(req)
+}
+
+// doJSON issues an HTTP request with reqBody marshaled as its JSON body (if
+// non-nil) and decodes the response into respBody (if non-nil), returning an
+// error for any non-2xx status.
+func (c *Client) doJSON(ctx context.Context, method, path string, reqBody, respBody any) error {
+	var body io.Reader
+	if reqBody != nil {
+		encoded, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	resp, err := c.do(ctx, method, path, body)
+	if err != nil {
+		return fmt.Errorf("libpod request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotModified {
+		errBody, _ := io.ReadAll(resp.Body)
+		return &apiError{statusCode: resp.StatusCode, body: string(errBody)}
+	}
+
+	if respBody == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(respBody)
+}
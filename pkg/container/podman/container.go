@@ -0,0 +1,199 @@
+package podman
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// inspectResponse is the subset of libpod's container inspect response
+// toolhive reads.
+type inspectResponse struct {
+	State struct {
+		Running bool `json:"Running"`
+	} `json:"State"`
+}
+
+// IsWorkloadRunning reports whether workloadID is currently running.
+func (c *Client) IsWorkloadRunning(ctx context.Context, workloadID string) (bool, error) {
+	var inspect inspectResponse
+	err := c.doJSON(ctx, http.MethodGet, fmt.Sprintf("/libpod/containers/%s/json", workloadID), nil, &inspect)
+	if err != nil {
+		if isNotFound(err) {
+			return false, fmt.Errorf("%w: %s", ErrContainerNotFound, workloadID)
+		}
+		return false, fmt.Errorf("failed to inspect workload %s: %w", workloadID, err)
+	}
+	return inspect.State.Running, nil
+}
+
+// RemoveWorkload removes a workload along with its egress/dns sidecars and
+// pod, if any. Removing an already-removed workload is a no-op.
+func (c *Client) RemoveWorkload(ctx context.Context, workloadID string) error {
+	entries, err := c.ListWorkloads(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	var workloadName string
+	for _, entry := range entries {
+		if entry.ID == workloadID {
+			workloadName = entry.Name
+			break
+		}
+	}
+
+	if err := c.removeContainer(ctx, workloadID); err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to remove workload %s: %w", workloadID, err)
+	}
+
+	if workloadName == "" {
+		// The workload was already gone before we could resolve its name,
+		// so its sidecars and pod can't be looked up by name either.
+		return nil
+	}
+
+	for _, sidecarName := range []string{fmt.Sprintf("%s-egress", workloadName), fmt.Sprintf("%s-dns", workloadName)} {
+		sidecarID, err := c.findContainerByName(ctx, sidecarName)
+		if err != nil {
+			logger.Warnf("failed to find sidecar container %s: %v", sidecarName, err)
+			continue
+		}
+		if sidecarID == "" {
+			continue
+		}
+		if err := c.removeContainer(ctx, sidecarID); err != nil && !isNotFound(err) {
+			logger.Warnf("failed to remove sidecar container %s: %v", sidecarName, err)
+		}
+	}
+
+	podName := fmt.Sprintf("%s-pod", workloadName)
+	if err := c.doJSON(ctx, http.MethodDelete, fmt.Sprintf("/libpod/pods/%s?force=true", podName), nil, nil); err != nil && !isNotFound(err) {
+		logger.Warnf("failed to remove pod %s: %v", podName, err)
+	}
+
+	return nil
+}
+
+// removeContainer force-removes containerID.
+func (c *Client) removeContainer(ctx context.Context, containerID string) error {
+	return c.doJSON(ctx, http.MethodDelete, fmt.Sprintf("/libpod/containers/%s?force=true", containerID), nil, nil)
+}
+
+// findContainerByName returns the ID of the container named name, or "" if
+// none exists.
+func (c *Client) findContainerByName(ctx context.Context, name string) (string, error) {
+	encoded, err := encodeFilters(map[string][]string{"name": {fmt.Sprintf("^%s$", name)}})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode filters: %w", err)
+	}
+
+	var entries []containerListEntry
+	path := fmt.Sprintf("/libpod/containers/json?all=true&filters=%s", encoded)
+	if err := c.doJSON(ctx, http.MethodGet, path, nil, &entries); err != nil {
+		return "", fmt.Errorf("failed to list containers: %w", err)
+	}
+	if len(entries) == 0 {
+		return "", nil
+	}
+	return entries[0].ID, nil
+}
+
+// GetWorkloadLogs returns workloadID's combined stdout/stderr. Libpod's log
+// endpoint multiplexes stdout/stderr with the same framing Docker uses,
+// hence reusing Docker's stdcopy to demultiplex it here.
+func (c *Client) GetWorkloadLogs(ctx context.Context, workloadID string, follow bool) (string, error) {
+	path := fmt.Sprintf("/libpod/containers/%s/logs?stdout=true&stderr=true&follow=%t&tail=100", workloadID, follow)
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workload logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: %s", ErrContainerNotFound, workloadID)
+	}
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("workload logs request returned status %d", resp.StatusCode)
+	}
+
+	if follow {
+		if _, err := stdcopy.StdCopy(os.Stdout, os.Stdout, resp.Body); err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to follow workload logs: %w", err)
+		}
+		return "", nil
+	}
+
+	var out writerBuf
+	if _, err := stdcopy.StdCopy(&out, &out, resp.Body); err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read workload logs: %w", err)
+	}
+	return out.String(), nil
+}
+
+// AttachToWorkload attaches stdin/stdout/stderr to a running workload by
+// issuing an HTTP Upgrade request over the raw Unix socket, since attach is
+// a bidirectional stream rather than a request/response call -- the libpod
+// equivalent of Docker's hijacked ContainerAttach connection.
+func (c *Client) AttachToWorkload(ctx context.Context, workloadID string) (io.WriteCloser, io.ReadCloser, error) {
+	running, err := c.IsWorkloadRunning(ctx, workloadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !running {
+		return nil, nil, fmt.Errorf("workload %s is not running", workloadID)
+	}
+
+	conn, err := net.Dial("unix", c.socketPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial libpod socket: %w", err)
+	}
+
+	path := fmt.Sprintf("/%s/libpod/containers/%s/attach?stdin=true&stdout=true&stderr=true&stream=true", apiVersion, workloadID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost"+path, nil)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to build attach request: %w", err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to send attach request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("failed to read attach response: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+		return nil, nil, fmt.Errorf("attach request returned status %d", resp.StatusCode)
+	}
+
+	return conn, io.NopCloser(reader), nil
+}
+
+// writerBuf accumulates stdcopy's demultiplexed output.
+type writerBuf struct {
+	data []byte
+}
+
+func (w *writerBuf) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+func (w *writerBuf) String() string { return string(w.data) }
@@ -0,0 +1,38 @@
+package podman
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrContainerNotFound is returned when a workload or sidecar doesn't
+// exist -- this backend's equivalent of the Docker backend's
+// ErrContainerNotFound/errdefs.IsNotFound.
+var ErrContainerNotFound = fmt.Errorf("container not found")
+
+// apiError wraps a non-2xx libpod API response with its HTTP status code,
+// the raw signal libpod gives callers for "not found" vs. a real failure
+// (the same role errdefs.IsNotFound plays for the Docker backend).
+type apiError struct {
+	statusCode int
+	body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("libpod API error (status %d): %s", e.statusCode, e.body)
+}
+
+// isNotFound reports whether err is a libpod 404 response.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound
+}
+
+// isConflict reports whether err is a libpod 409 response, libpod's signal
+// that a create request collided with something that already exists (e.g.
+// a network by this name).
+func isConflict(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == http.StatusConflict
+}
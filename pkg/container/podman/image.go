@@ -0,0 +1,198 @@
+package podman
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/container/verifier"
+	"github.com/stacklok/toolhive/pkg/logger"
+	"github.com/stacklok/toolhive/pkg/registry"
+)
+
+// VerifyImage verifies a container image's provenance. This doesn't touch
+// the runtime API at all, so it behaves identically on the Docker and
+// podman backends.
+func (*Client) VerifyImage(_ context.Context, serverInfo *registry.Server, imageRef string) (bool, error) {
+	v, err := verifier.New(serverInfo)
+	if err != nil {
+		return false, err
+	}
+	return v.VerifyServer(imageRef, serverInfo)
+}
+
+// BuildImage builds an image from a Dockerfile in opts.ContextDir, streaming
+// a tar of the context to libpod's /libpod/build endpoint (wire-compatible
+// with Docker's /build, which the Docker backend's BuildImage uses). It
+// blocks until the build completes, returning the built image's ID and the
+// plain-text log parseBuildOutput decodes from the JSON-lines progress stream.
+func (c *Client) BuildImage(ctx context.Context, opts runtime.BuildOptions) (string, io.ReadCloser, error) {
+	logger.Infof("Building image %s from context directory %s", strings.Join(opts.Tags, ","), opts.ContextDir)
+
+	tarFile, err := os.CreateTemp("", "podman-build-context-*.tar")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary tar file: %w", err)
+	}
+	defer os.Remove(tarFile.Name())
+	defer tarFile.Close()
+
+	if err := createTarFromDir(opts.ContextDir, tarFile); err != nil {
+		return "", nil, fmt.Errorf("failed to create tar archive: %w", err)
+	}
+	if _, err := tarFile.Seek(0, 0); err != nil {
+		return "", nil, fmt.Errorf("failed to reset tar file pointer: %w", err)
+	}
+
+	dockerfile := opts.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	query := url.Values{}
+	for _, tag := range opts.Tags {
+		query.Add("t", tag)
+	}
+	query.Set("dockerfile", dockerfile)
+	if opts.Target != "" {
+		query.Set("target", opts.Target)
+	}
+	if opts.Platform != "" {
+		query.Set("platform", opts.Platform)
+	}
+	if opts.Pull {
+		query.Set("pull", "true")
+	}
+	if opts.NoCache {
+		query.Set("nocache", "true")
+	}
+	if len(opts.BuildArgs) > 0 {
+		encoded, err := json.Marshal(opts.BuildArgs)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal build args: %w", err)
+		}
+		query.Set("buildargs", string(encoded))
+	}
+	if len(opts.Labels) > 0 {
+		encoded, err := json.Marshal(opts.Labels)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal labels: %w", err)
+		}
+		query.Set("labels", string(encoded))
+	}
+	for _, cacheFrom := range opts.CacheFrom {
+		query.Add("cachefrom", cacheFrom)
+	}
+	if len(opts.CacheTo) > 0 {
+		logger.Warnf("BuildImage: CacheTo is not supported by libpod's build API; ignoring %v", opts.CacheTo)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/libpod/build?"+query.Encode(), tarFile)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		errBody, _ := io.ReadAll(resp.Body)
+		return "", nil, fmt.Errorf("build request returned status %d: %s", resp.StatusCode, string(errBody))
+	}
+
+	var logBuf strings.Builder
+	imageID, err := parseBuildOutput(resp.Body, &logBuf)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to process build output: %w", err)
+	}
+
+	return imageID, io.NopCloser(strings.NewReader(logBuf.String())), nil
+}
+
+// createTarFromDir tars up srcDir's contents for a build context upload.
+func createTarFromDir(srcDir string, writer io.Writer) error {
+	tw := tar.NewWriter(writer)
+	defer tw.Close()
+
+	return filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		if relPath == "." {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return fmt.Errorf("failed to create tar header: %w", err)
+		}
+		header.Name = relPath
+
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write tar header: %w", err)
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		// #nosec G304 - only opening files within the specified context directory
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+
+		if _, err := io.Copy(tw, file); err != nil {
+			return fmt.Errorf("failed to copy file contents: %w", err)
+		}
+		return nil
+	})
+}
+
+// parseBuildOutput parses libpod's build progress stream (JSON lines,
+// wire-compatible with Docker's build output), writing the readable log to
+// writer and returning the built image's ID.
+func parseBuildOutput(reader io.Reader, writer io.Writer) (string, error) {
+	var imageID string
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var buildOutput struct {
+			Stream string `json:"stream,omitempty"`
+			Error  string `json:"error,omitempty"`
+			Aux    *struct {
+				ID string `json:"ID"`
+			} `json:"aux,omitempty"`
+		}
+
+		if err := decoder.Decode(&buildOutput); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", fmt.Errorf("failed to decode build output: %w", err)
+		}
+
+		if buildOutput.Error != "" {
+			return "", fmt.Errorf("build error: %s", buildOutput.Error)
+		}
+		if buildOutput.Stream != "" {
+			fmt.Fprint(writer, buildOutput.Stream)
+		}
+		if buildOutput.Aux != nil && buildOutput.Aux.ID != "" {
+			imageID = buildOutput.Aux.ID
+		}
+	}
+
+	return imageID, nil
+}
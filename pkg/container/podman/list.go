@@ -0,0 +1,141 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+)
+
+// containerListEntry is the subset of libpod's container list response
+// toolhive reads.
+type containerListEntry struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	Image   string            `json:"Image"`
+	State   string            `json:"State"`
+	Created int64             `json:"Created"`
+	Labels  map[string]string `json:"Labels"`
+	Ports   []struct {
+		ContainerPort uint16 `json:"container_port"`
+		HostPort      uint16 `json:"host_port"`
+		Protocol      string `json:"protocol"`
+	} `json:"Ports"`
+}
+
+// ListWorkloads returns every toolhive-managed workload known to libpod,
+// filtered the same way the Docker backend filters its container list: by
+// the toolhive=true and toolhive-main-workload=true labels.
+func (c *Client) ListWorkloads(ctx context.Context) ([]runtime.ContainerInfo, error) {
+	encoded, err := encodeFilters(map[string][]string{
+		"label": {"toolhive=true", "toolhive-main-workload=true"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode filters: %w", err)
+	}
+
+	var entries []containerListEntry
+	path := fmt.Sprintf("/libpod/containers/json?all=true&filters=%s", encoded)
+	if err := c.doJSON(ctx, "GET", path, nil, &entries); err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	result := make([]runtime.ContainerInfo, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.ID
+		if len(entry.Names) > 0 {
+			name = entry.Names[0]
+		}
+
+		ports := make([]runtime.PortMapping, 0, len(entry.Ports))
+		for _, p := range entry.Ports {
+			ports = append(ports, runtime.PortMapping{
+				ContainerPort: int(p.ContainerPort),
+				HostPort:      int(p.HostPort),
+				Protocol:      p.Protocol,
+			})
+		}
+
+		result = append(result, runtime.ContainerInfo{
+			ID:      entry.ID,
+			Name:    name,
+			Image:   entry.Image,
+			State:   entry.State,
+			Created: time.Unix(entry.Created, 0),
+			Labels:  entry.Labels,
+			Ports:   ports,
+		})
+	}
+	return result, nil
+}
+
+// StopWorkload stops a running workload. Libpod stops every container in a
+// pod independently rather than the pod as a whole, so StopWorkload stops
+// the egress/DNS sidecars itself too, mirroring the Docker backend.
+// Stopping an already-stopped workload is a no-op.
+func (c *Client) StopWorkload(ctx context.Context, workloadID string) error {
+	if err := c.stopContainer(ctx, workloadID); err != nil {
+		return fmt.Errorf("failed to stop workload %s: %w", workloadID, err)
+	}
+
+	entries, err := c.ListWorkloads(ctx)
+	if err != nil {
+		// The workload itself already stopped successfully; not being able
+		// to resolve its name for sidecar cleanup shouldn't fail the call.
+		return nil
+	}
+
+	var workloadName string
+	for _, entry := range entries {
+		if entry.ID == workloadID {
+			workloadName = entry.Name
+			break
+		}
+	}
+	if workloadName == "" {
+		return nil
+	}
+
+	for _, sidecar := range []string{fmt.Sprintf("%s-egress", workloadName), fmt.Sprintf("%s-dns", workloadName)} {
+		// Best effort: a sidecar failing to stop shouldn't fail a call that
+		// already stopped the workload it belongs to.
+		_ = c.stopContainer(ctx, sidecar)
+	}
+	return nil
+}
+
+// stopContainer stops containerID. Libpod returns 304 Not Modified for an
+// already-stopped container, which doJSON treats as success since it
+// exempts 304 from its non-2xx error check.
+func (c *Client) stopContainer(ctx context.Context, containerID string) error {
+	return c.doJSON(ctx, "POST", fmt.Sprintf("/libpod/containers/%s/stop", containerID), nil, nil)
+}
+
+// encodeFilters encodes a libpod filters map into the URL-escaped JSON query
+// parameter its list endpoints expect.
+func encodeFilters(filters map[string][]string) (string, error) {
+	encoded, err := json.Marshal(filters)
+	if err != nil {
+		return "", err
+	}
+	return url.QueryEscape(string(encoded)), nil
+}
+
+// parsePort parses a "port" or "port/proto" string into its numeric port.
+func parsePort(port string) (uint16, error) {
+	for i, r := range port {
+		if r == '/' {
+			port = port[:i]
+			break
+		}
+	}
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q: %w", port, err)
+	}
+	return uint16(n), nil
+}
@@ -0,0 +1,51 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+)
+
+// networkCreateSpec is the subset of libpod's network create options
+// toolhive sends to POST /libpod/networks/create.
+type networkCreateSpec struct {
+	Name     string `json:"name"`
+	Internal bool   `json:"internal"`
+}
+
+// networkCreateResponse is libpod's response to a network create request.
+type networkCreateResponse struct {
+	Name string `json:"Name"`
+}
+
+// networkConnectSpec is the body POST /libpod/networks/{name}/connect
+// expects.
+type networkConnectSpec struct {
+	Container string `json:"container"`
+}
+
+// CreateNetwork creates (or reuses, if one by this name already exists) a
+// private network that a set of related but independent workloads can be
+// attached to via ConnectNetwork, without going as far as sharing a single
+// namespace the way CreatePod's members do. internal, when true, gives the
+// network no route out to the host's own network.
+func (c *Client) CreateNetwork(ctx context.Context, name string, internal bool) (string, error) {
+	var created networkCreateResponse
+	err := c.doJSON(ctx, "POST", "/libpod/networks/create", networkCreateSpec{Name: name, Internal: internal}, &created)
+	if err != nil {
+		if isConflict(err) {
+			return name, nil
+		}
+		return "", fmt.Errorf("failed to create network %q: %w", name, err)
+	}
+	return created.Name, nil
+}
+
+// ConnectNetwork attaches an already-created container to a network created
+// by CreateNetwork.
+func (c *Client) ConnectNetwork(ctx context.Context, networkName, containerID string) error {
+	path := fmt.Sprintf("/libpod/networks/%s/connect", networkName)
+	if err := c.doJSON(ctx, "POST", path, networkConnectSpec{Container: containerID}, nil); err != nil {
+		return fmt.Errorf("failed to connect container %s to network %s: %w", containerID, networkName, err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+)
+
+// CreatePod creates a real libpod pod named name over POST
+// /libpod/pods/create and returns its ID. DeployWorkload uses this
+// internally to give a single MCP workload its own pod; callers that want
+// to group several otherwise-independent workloads onto one shared network
+// namespace can call it directly and pass the returned ID as
+// DeployWorkloadOptions.PodID for each member. Only opts.Labels is sent to
+// libpod; opts.PortBindings and opts.ExposedPorts are not applied to the pod.
+func (c *Client) CreatePod(ctx context.Context, name string, opts runtime.PodOptions) (string, error) {
+	var pod podCreateResponse
+	if err := c.doJSON(ctx, "POST", "/libpod/pods/create", podSpec{Name: name, Labels: opts.Labels}, &pod); err != nil {
+		return "", fmt.Errorf("failed to create pod %s: %w", name, err)
+	}
+	return pod.ID, nil
+}
+
+// RemovePod force-removes the pod named name, treating "already gone" as
+// success.
+func (c *Client) RemovePod(ctx context.Context, name string) error {
+	if err := c.doJSON(ctx, http.MethodDelete, fmt.Sprintf("/libpod/pods/%s?force=true", name), nil, nil); err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to remove pod %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/labels"
+	"github.com/stacklok/toolhive/pkg/permissions"
+)
+
+// egressImage and dnsImage mirror the images pkg/container/docker uses for
+// the same sidecars; they're redeclared here rather than imported to avoid
+// an import cycle (pkg/container/docker constructs a Client for this
+// package, so this package can't import it back).
+const (
+	egressImage = "ubuntu/squid:latest"
+	dnsImage    = "dockurr/dnsmasq:latest"
+)
+
+// podSpec is the subset of libpod's pod SpecGenerator toolhive sends to
+// POST /libpod/pods/create.
+type podSpec struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// podCreateResponse is libpod's response to a pod create request.
+type podCreateResponse struct {
+	ID string `json:"Id"`
+}
+
+// containerSpec is the subset of libpod's container SpecGenerator toolhive
+// sends to POST /libpod/containers/create.
+type containerSpec struct {
+	Name          string            `json:"name"`
+	Image         string            `json:"image"`
+	Command       []string          `json:"command,omitempty"`
+	Env           map[string]string `json:"env,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Pod           string            `json:"pod,omitempty"`
+	Mounts        []mountSpec       `json:"mounts,omitempty"`
+	PortMappings  []portMapping     `json:"portmappings,omitempty"`
+	RestartPolicy string            `json:"restart_policy,omitempty"`
+	// SeccompProfilePath is a path to a seccomp profile, or "unconfined" to
+	// disable seccomp entirely; empty uses libpod's own default profile.
+	SeccompProfilePath string `json:"seccomp_profile_path,omitempty"`
+	// ApparmorProfile names an already-loaded AppArmor profile, or
+	// "unconfined" to disable it; empty uses libpod's own default profile.
+	ApparmorProfile string `json:"apparmor_profile,omitempty"`
+}
+
+// mountSpec is a libpod bind mount spec entry.
+type mountSpec struct {
+	Source      string `json:"Source"`
+	Destination string `json:"Destination"`
+	ReadOnly    bool   `json:"ReadOnly"`
+}
+
+// portMapping is a libpod container port mapping entry.
+type portMapping struct {
+	ContainerPort uint16 `json:"container_port"`
+	HostPort      uint16 `json:"host_port,omitempty"`
+	Protocol      string `json:"protocol,omitempty"`
+}
+
+// containerCreateResponse is libpod's response to a container create
+// request.
+type containerCreateResponse struct {
+	ID string `json:"Id"`
+}
+
+// DeployWorkload creates a libpod pod named after the workload and starts
+// the MCP workload inside it alongside its egress and DNS sidecars, all
+// sharing the pod's network namespace -- a real libpod pod rather than the
+// Docker backend's label-convention grouping. If options.PodID is set, the
+// workload joins that already-created pod (see Client.CreatePod) instead,
+// and skips creating its own egress/DNS sidecars since those belong to
+// whichever caller created the shared pod.
+func (c *Client) DeployWorkload(
+	ctx context.Context,
+	image, name string,
+	command []string,
+	envVars, containerLabels map[string]string,
+	permissionProfile *permissions.Profile,
+	_ string,
+	options *runtime.DeployWorkloadOptions,
+) (string, error) {
+	podID := ""
+	if options != nil {
+		podID = options.PodID
+	}
+	deploySidecarsForWorkload := podID == ""
+	if podID == "" {
+		podName := fmt.Sprintf("%s-pod", name)
+
+		podLabels := map[string]string{}
+		labels.AddStandardLabels(podLabels, podName, podName, "stdio", 0)
+
+		created, err := c.CreatePod(ctx, podName, runtime.PodOptions{Labels: podLabels})
+		if err != nil {
+			return "", fmt.Errorf("failed to create pod %s: %w", podName, err)
+		}
+		podID = created
+	}
+
+	if containerLabels == nil {
+		containerLabels = map[string]string{}
+	}
+	containerLabels["toolhive-main-workload"] = "true"
+	// toolhive-runtime records which backend created this workload; see the
+	// matching comment in the Docker backend's DeployWorkload.
+	containerLabels["toolhive-runtime"] = "podman"
+
+	workloadSpec := containerSpec{
+		Name:          name,
+		Image:         image,
+		Command:       command,
+		Env:           envVars,
+		Labels:        containerLabels,
+		Pod:           podID,
+		RestartPolicy: "unless-stopped",
+	}
+	if permissionProfile != nil {
+		workloadSpec.Mounts = convertMounts(permissionProfile.Mounts)
+
+		seccompPath, err := seccompProfilePath(permissionProfile.Seccomp)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve seccomp profile: %w", err)
+		}
+		workloadSpec.SeccompProfilePath = seccompPath
+		workloadSpec.ApparmorProfile = apparmorProfileValue(permissionProfile.AppArmor)
+	}
+	if options != nil {
+		workloadSpec.PortMappings = convertPortMappings(options.PortBindings)
+	}
+
+	var workloadContainer containerCreateResponse
+	if err := c.doJSON(ctx, "POST", "/libpod/containers/create", workloadSpec, &workloadContainer); err != nil {
+		return "", fmt.Errorf("failed to create workload container %s: %w", name, err)
+	}
+
+	if err := c.startContainer(ctx, workloadContainer.ID); err != nil {
+		return "", fmt.Errorf("failed to start workload container %s: %w", name, err)
+	}
+
+	if deploySidecarsForWorkload && permissionProfile != nil && permissionProfile.Network != nil {
+		// A workload joining a pod it didn't create (options.PodID) shares
+		// that pod's sidecars rather than getting its own.
+		if err := c.deploySidecars(ctx, name, podID, permissionProfile); err != nil {
+			return "", fmt.Errorf("failed to deploy sidecars for %s: %w", name, err)
+		}
+	}
+
+	return workloadContainer.ID, nil
+}
+
+// deploySidecars creates and starts the egress and DNS sidecar containers
+// for a workload in the same pod as the workload itself.
+func (c *Client) deploySidecars(ctx context.Context, workloadName, podID string, _ *permissions.Profile) error {
+	for _, sidecar := range []struct {
+		name  string
+		image string
+	}{
+		{fmt.Sprintf("%s-egress", workloadName), egressImage},
+		{fmt.Sprintf("%s-dns", workloadName), dnsImage},
+	} {
+		sidecarLabels := map[string]string{}
+		labels.AddStandardLabels(sidecarLabels, sidecar.name, sidecar.name, "stdio", 0)
+
+		spec := containerSpec{
+			Name:          sidecar.name,
+			Image:         sidecar.image,
+			Labels:        sidecarLabels,
+			Pod:           podID,
+			RestartPolicy: "unless-stopped",
+		}
+
+		var created containerCreateResponse
+		if err := c.doJSON(ctx, "POST", "/libpod/containers/create", spec, &created); err != nil {
+			return fmt.Errorf("failed to create sidecar container %s: %w", sidecar.name, err)
+		}
+		if err := c.startContainer(ctx, created.ID); err != nil {
+			return fmt.Errorf("failed to start sidecar container %s: %w", sidecar.name, err)
+		}
+	}
+	return nil
+}
+
+// seccompProfilePath resolves a permission profile's Seccomp setting into
+// the path (or "unconfined") libpod's seccomp_profile_path field accepts.
+// "localhost/<name>" is resolved the same way the Docker backend resolves
+// it; an inline OCI seccomp JSON document is written to a temp file since
+// libpod's create API takes a path rather than inline JSON.
+func seccompProfilePath(seccomp string) (string, error) {
+	switch {
+	case seccomp == "" || seccomp == "runtime/default":
+		return "", nil
+	case seccomp == "unconfined":
+		return "unconfined", nil
+	case strings.HasPrefix(seccomp, "localhost/"):
+		return runtime.ResolveNamedSeccompProfilePath(strings.TrimPrefix(seccomp, "localhost/")), nil
+	case json.Valid([]byte(seccomp)):
+		tempFile, err := os.CreateTemp("", "toolhive-seccomp-*.json")
+		if err != nil {
+			return "", fmt.Errorf("failed to create temporary seccomp profile file: %w", err)
+		}
+		defer tempFile.Close()
+		if _, err := tempFile.WriteString(seccomp); err != nil {
+			return "", fmt.Errorf("failed to write temporary seccomp profile file: %w", err)
+		}
+		return tempFile.Name(), nil
+	default:
+		return "", fmt.Errorf(
+			"invalid seccomp profile %q: expected runtime/default, unconfined, localhost/<name>, or inline OCI seccomp JSON", seccomp,
+		)
+	}
+}
+
+// apparmorProfileValue resolves a permission profile's AppArmor setting
+// into libpod's apparmor_profile field: "runtime/default" (or empty) omits
+// it so libpod applies its own default, everything else (including
+// "unconfined") is passed through verbatim.
+func apparmorProfileValue(apparmor string) string {
+	if apparmor == "runtime/default" {
+		return ""
+	}
+	return apparmor
+}
+
+// startContainer starts a previously created container.
+func (c *Client) startContainer(ctx context.Context, containerID string) error {
+	return c.doJSON(ctx, "POST", fmt.Sprintf("/libpod/containers/%s/start", containerID), nil, nil)
+}
+
+// convertMounts translates toolhive's internal mount format into libpod bind
+// mount specs.
+func convertMounts(mounts []runtime.Mount) []mountSpec {
+	result := make([]mountSpec, 0, len(mounts))
+	for _, m := range mounts {
+		result = append(result, mountSpec{
+			Source:      m.Source,
+			Destination: m.Target,
+			ReadOnly:    m.ReadOnly,
+		})
+	}
+	return result
+}
+
+// convertPortMappings translates toolhive's internal port binding format
+// into libpod port mapping specs.
+func convertPortMappings(portBindings map[string][]runtime.PortBinding) []portMapping {
+	var result []portMapping
+	for port, bindings := range portBindings {
+		containerPort, err := parsePort(port)
+		if err != nil {
+			continue
+		}
+		for _, binding := range bindings {
+			hostPort, err := parsePort(binding.HostPort)
+			if err != nil {
+				continue
+			}
+			result = append(result, portMapping{
+				ContainerPort: containerPort,
+				HostPort:      hostPort,
+				Protocol:      "tcp",
+			})
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/stacklok/toolhive/pkg/permissions"
+)
+
+// Backend is implemented by each runtime-specific client toolhive can speak
+// to -- the Docker-compat client in pkg/container/docker and the
+// libpod-native client in pkg/container/podman -- so the callers that
+// deploy, list, and stop workloads don't need to know which socket protocol
+// a given workload actually ended up talking to.
+type Backend interface {
+	// DeployWorkload creates and starts a new workload from image, along
+	// with whatever sidecars its permission profile requires.
+	DeployWorkload(
+		ctx context.Context,
+		image, name string,
+		command []string,
+		envVars, labels map[string]string,
+		permissionProfile *permissions.Profile,
+		transportType string,
+		options *DeployWorkloadOptions,
+	) (string, error)
+
+	// ListWorkloads returns every toolhive-managed workload this backend
+	// knows about.
+	ListWorkloads(ctx context.Context) ([]ContainerInfo, error)
+
+	// StopWorkload stops a running workload, along with its sidecars if
+	// any. Stopping an already-stopped workload is a no-op.
+	StopWorkload(ctx context.Context, workloadID string) error
+
+	// RunServices starts workloadName's declared service containers, in
+	// order, on the workload's own network and waits for each one's
+	// HealthCheck (if set) before starting the next. If any service fails
+	// to start or never becomes healthy, every service already started for
+	// this workload is torn down before RunServices returns the error, so a
+	// failed deploy never leaves orphaned sidecars behind.
+	RunServices(ctx context.Context, workloadName string, services []ServiceContainer) ([]ServiceResult, error)
+
+	// StopServices tears down every service container previously started
+	// for workloadName by RunServices. Stopping a workload with no service
+	// containers is a no-op.
+	StopServices(ctx context.Context, workloadName string) error
+}
+
+// ReusePolicy controls whether DeployWorkload reuses an already-existing
+// container with the requested name instead of recreating it, and how
+// strictly it checks that the existing container actually matches what was
+// asked for.
+type ReusePolicy string
+
+const (
+	// ReuseIfMatches reuses the existing container only when its recorded
+	// config hash matches the desired spec; otherwise it's stopped, removed,
+	// and recreated. This is the default.
+	ReuseIfMatches ReusePolicy = "if-matches"
+	// ReuseAlways reuses whatever container already exists with the
+	// requested name, regardless of whether its config matches -- useful
+	// for callers that manage the container's lifecycle themselves and only
+	// want toolhive to adopt it.
+	ReuseAlways ReusePolicy = "always"
+	// ReuseNever always stops, removes, and recreates an existing
+	// container, bypassing config-hash comparison entirely.
+	ReuseNever ReusePolicy = "never"
+)
@@ -0,0 +1,35 @@
+package runtime
+
+import "time"
+
+// LogStream identifies which stream a LogEntry's line came from.
+type LogStream int
+
+const (
+	// Stdout is a line read from the workload's standard output.
+	Stdout LogStream = iota
+	// Stderr is a line read from the workload's standard error.
+	Stderr
+)
+
+// LogEntry is a single demultiplexed log line produced by StreamWorkloadLogs.
+type LogEntry struct {
+	// Stream is which of stdout/stderr Line came from.
+	Stream LogStream
+	// Timestamp is when the runtime recorded the line; zero if the caller
+	// didn't request LogOptions.Timestamps.
+	Timestamp time.Time
+	// Line is the raw line content, with its stream-framing header and
+	// trailing newline already stripped.
+	Line []byte
+}
+
+// LogSink consumes a workload's log entries as they're produced, so a
+// long-running workload's logs can be written out (to a rotating file, to
+// journald, ...) without buffering the whole history in memory.
+type LogSink interface {
+	// Write handles a single log entry.
+	Write(entry LogEntry) error
+	// Close releases any resources the sink holds open.
+	Close() error
+}
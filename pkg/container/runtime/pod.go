@@ -0,0 +1,15 @@
+package runtime
+
+// PodOptions configures the CreatePod method of the Docker and Podman
+// clients. The Podman client applies only Labels.
+type PodOptions struct {
+	// Labels are applied to the pod itself: the infra container on the
+	// Docker backend, the pod resource on the Podman backend.
+	Labels map[string]string
+	// PortBindings are bound on the pod's shared namespace, the same way
+	// DeployWorkloadOptions.PortBindings are bound on a workload that owns
+	// its own pod.
+	PortBindings map[string][]PortBinding
+	// ExposedPorts are exposed on the pod's shared namespace.
+	ExposedPorts map[string]struct{}
+}
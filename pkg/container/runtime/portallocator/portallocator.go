@@ -0,0 +1,136 @@
+// Package portallocator tracks host port reservations across concurrent
+// DeployWorkload calls, the same role libnetwork's portallocator plays for
+// Docker itself: two simultaneous deploys asking for an ephemeral port
+// otherwise race against the kernel (and each other), since nothing records
+// that a port picked by one has been claimed before the other looks.
+package portallocator
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// Default ephemeral range reservations are drawn from when a caller doesn't
+// request a specific port, matching the IANA dynamic/private port range.
+const (
+	defaultRangeStart = 49152
+	defaultRangeEnd   = 65535
+)
+
+// key scopes a reservation to the host IP and protocol it was made for, so
+// the same port number can be reserved independently on different
+// interfaces or for TCP and UDP.
+type key struct {
+	hostIP string
+	proto  string
+}
+
+// Allocator is a process-wide bitmap of host ports reserved over a
+// configured range. It is safe for concurrent use.
+type Allocator struct {
+	mu    sync.Mutex
+	start int
+	end   int
+	taken map[key]map[int]bool
+}
+
+// New creates an Allocator reserving ports from [start, end].
+func New(start, end int) *Allocator {
+	return &Allocator{
+		start: start,
+		end:   end,
+		taken: make(map[key]map[int]bool),
+	}
+}
+
+// Default is the allocator DeployWorkload consults for every workload in
+// this process, so reservations made for one workload are visible to the
+// next regardless of which Client instance handles it.
+var Default = New(defaultRangeStart, defaultRangeEnd)
+
+// RequestPort reserves a host port for (hostIP, proto). If requestedPort is
+// nonzero, that exact port is reserved (failing if it's already taken);
+// otherwise the first free port in the allocator's range is reserved and
+// returned. "Free" means both unreserved by this allocator and currently
+// bindable on hostIP, so ports held by processes outside toolhive's
+// bookkeeping are skipped too.
+func (a *Allocator) RequestPort(hostIP, proto string, requestedPort int) (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := key{hostIP: hostIP, proto: proto}
+	if a.taken[k] == nil {
+		a.taken[k] = make(map[int]bool)
+	}
+
+	if requestedPort != 0 {
+		if a.taken[k][requestedPort] {
+			return 0, fmt.Errorf("port %d/%s on %q is already reserved", requestedPort, proto, hostIP)
+		}
+		a.taken[k][requestedPort] = true
+		return requestedPort, nil
+	}
+
+	for port := a.start; port <= a.end; port++ {
+		if a.taken[k][port] {
+			continue
+		}
+		if !isPortFree(hostIP, proto, port) {
+			continue
+		}
+		a.taken[k][port] = true
+		return port, nil
+	}
+	return 0, fmt.Errorf("no free port available in range %d-%d for %s/%q", a.start, a.end, proto, hostIP)
+}
+
+// ReleasePort releases a previously reserved port so it can be handed out
+// again. Releasing a port that isn't reserved is a no-op.
+func (a *Allocator) ReleasePort(hostIP, proto string, port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.taken[key{hostIP: hostIP, proto: proto}], port)
+}
+
+// ReserveExisting marks a port as already taken without checking whether
+// it's actually bindable, so a process restart can reconcile the allocator
+// with ports already bound by containers that existed before it started.
+func (a *Allocator) ReserveExisting(hostIP, proto string, port int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	k := key{hostIP: hostIP, proto: proto}
+	if a.taken[k] == nil {
+		a.taken[k] = make(map[int]bool)
+	}
+	a.taken[k][port] = true
+}
+
+// isPortFree reports whether port can currently be bound on hostIP, used to
+// skip ports held by something outside toolhive's own bookkeeping (e.g. a
+// leftover process from a previous run, or a container started by another
+// tool entirely).
+func isPortFree(hostIP, proto string, port int) bool {
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+	address := net.JoinHostPort(hostIP, strconv.Itoa(port))
+
+	if proto == "udp" {
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+
+	ln, err := net.Listen("tcp", address)
+	if err != nil {
+		return false
+	}
+	ln.Close()
+	return true
+}
@@ -0,0 +1,67 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SeccompProfileDirEnvVar overrides the directory a "localhost/<name>"
+	// seccomp profile is resolved against.
+	SeccompProfileDirEnvVar = "TOOLHIVE_SECCOMP_PROFILE_DIR"
+	// defaultSeccompProfileDir mirrors Podman's own default search location
+	// for named seccomp profiles.
+	defaultSeccompProfileDir = "/etc/containers/seccomp.d"
+)
+
+// ResolveNamedSeccompProfilePath resolves a "localhost/<name>" seccomp
+// profile reference to its file path, under SeccompProfileDirEnvVar
+// (defaulting to defaultSeccompProfileDir) -- shared so the Docker and
+// Podman backends agree on where a named profile lives.
+func ResolveNamedSeccompProfilePath(name string) string {
+	dir := os.Getenv(SeccompProfileDirEnvVar)
+	if dir == "" {
+		dir = defaultSeccompProfileDir
+	}
+	return filepath.Join(dir, name)
+}
+
+// SecurityOptsFromProfile translates a permission profile's Seccomp and
+// AppArmor settings into Docker-style "--security-opt" values. Seccomp may
+// be "runtime/default" (or empty, its default) to leave the runtime's own
+// default confinement in place, "unconfined" to disable it, a
+// "localhost/<name>" reference resolved via ResolveNamedSeccompProfilePath,
+// or an inline OCI seccomp JSON document. AppArmor may be "runtime/default"
+// (or empty), "unconfined", or the name of an already-loaded profile.
+func SecurityOptsFromProfile(seccomp, apparmor string) ([]string, error) {
+	var opts []string
+
+	switch {
+	case seccomp == "" || seccomp == "runtime/default":
+		// Leave the runtime's own default seccomp confinement in place.
+	case seccomp == "unconfined":
+		opts = append(opts, "seccomp=unconfined")
+	case strings.HasPrefix(seccomp, "localhost/"):
+		opts = append(opts, "seccomp="+ResolveNamedSeccompProfilePath(strings.TrimPrefix(seccomp, "localhost/")))
+	case json.Valid([]byte(seccomp)):
+		opts = append(opts, "seccomp="+seccomp)
+	default:
+		return nil, fmt.Errorf(
+			"invalid seccomp profile %q: expected runtime/default, unconfined, localhost/<name>, or inline OCI seccomp JSON", seccomp,
+		)
+	}
+
+	switch {
+	case apparmor == "" || apparmor == "runtime/default":
+		// Leave the runtime's own default AppArmor confinement in place.
+	case apparmor == "unconfined":
+		opts = append(opts, "apparmor=unconfined")
+	default:
+		opts = append(opts, "apparmor="+apparmor)
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,72 @@
+package runtime
+
+import "time"
+
+// ServiceContainer declares one named sidecar a workload depends on,
+// analogous to GitHub Actions' (and act's) service containers: the backend
+// starts it on the workload's own network before the workload's own
+// container, waits for HealthCheck to pass, and resolves its DNS name and
+// published ports back to the caller so they can be injected into the
+// workload's environment.
+type ServiceContainer struct {
+	// Name identifies the service within the workload (e.g. "postgres").
+	// It becomes the service's DNS alias on the workload's network and is
+	// used to derive its container name, so it must be unique per workload.
+	Name string
+	// Image is the service's container image, e.g. "postgres:16".
+	Image string
+	// Command overrides the image's default entrypoint/command, if set.
+	Command []string
+	// Env is a map of environment variables to set on the service
+	// container. It is never merged into the main workload's own Env.
+	Env map[string]string
+	// Ports are the service's container ports to resolve for the workload,
+	// e.g. "5432/tcp". Each is reachable only from the workload's own
+	// network, never published to the host.
+	Ports []string
+	// Credentials, when set, authenticates the pull of Image from a
+	// private registry. It is scoped to this one service and is never
+	// attached to the main workload's container or logged.
+	Credentials *RegistryCredentials
+	// HealthCheck, when set, gates startup: the service isn't considered
+	// up (and RunServices doesn't return) until it passes.
+	HealthCheck *ServiceHealthCheck
+}
+
+// RegistryCredentials authenticates a single image pull.
+type RegistryCredentials struct {
+	// Username is the registry account name.
+	Username string
+	// Password is the registry account password or access token.
+	Password string
+	// ServerAddress is the registry host, e.g. "ghcr.io". Empty defaults to
+	// Docker Hub, matching the Docker CLI's own behavior.
+	ServerAddress string
+}
+
+// ServiceHealthCheck polls a service container by running Test inside it
+// until it exits zero or Retries is exhausted.
+type ServiceHealthCheck struct {
+	// Test is the command to run inside the service container to probe
+	// health, e.g. []string{"pg_isready"}.
+	Test []string
+	// Interval is how long to wait between health check attempts.
+	Interval time.Duration
+	// Retries is how many consecutive failed attempts are tolerated before
+	// RunServices gives up, rolls back every service it already started,
+	// and returns an error.
+	Retries int
+}
+
+// ServiceResult is what RunServices reports back for one started service.
+type ServiceResult struct {
+	// Name is the ServiceContainer.Name it was started from.
+	Name string
+	// ContainerID is the started service container's ID.
+	ContainerID string
+	// DNSName is the hostname the main workload reaches this service at.
+	DNSName string
+	// Addrs maps each of ServiceContainer.Ports to its "host:port" address,
+	// reachable from the main workload.
+	Addrs map[string]string
+}
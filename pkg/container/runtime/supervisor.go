@@ -0,0 +1,294 @@
+package runtime
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// EventSource is implemented by backends that can stream container
+// lifecycle events -- currently only the Docker backend's Events method.
+// Supervisor type-asserts a Backend against this interface and falls back
+// to polling ListWorkloads when a backend (e.g. Podman, Kubernetes) doesn't
+// implement it yet.
+type EventSource interface {
+	Events(ctx context.Context, filter EventFilter) (<-chan Event, error)
+}
+
+// RedeployFunc recreates a workload's container -- typically a closure
+// around Backend.DeployWorkload with the workload's original image,
+// command, env, and permission profile -- and returns its new container ID.
+type RedeployFunc func(ctx context.Context) (containerID string, err error)
+
+// Hook is called by a Supervisor after it redeploys a crashed workload, so
+// callers can react -- e.g. re-registering a proxy against the workload's
+// new container ID.
+type Hook func(ctx context.Context, workloadName, oldContainerID, newContainerID string)
+
+// Health reports what a Supervisor has observed about one supervised
+// workload's recent lifecycle, returned by GetContainerHealth.
+type Health struct {
+	// ContainerID is the ID of the workload's current container.
+	ContainerID string
+	// Restarts is how many times the supervisor has redeployed this workload.
+	Restarts int
+	// OOMKills is how many of Restarts were triggered by an "oom" event.
+	OOMKills int
+	// LastExitAction is the Event.Action that ended the workload's most
+	// recent run ("die", "oom", "kill", or "destroy"), or "" if it hasn't
+	// exited yet.
+	LastExitAction string
+	// LastRestart is when the supervisor last redeployed the workload.
+	LastRestart time.Time
+}
+
+const (
+	// supervisorMinBackoff is the delay before a workload's first redeploy attempt.
+	supervisorMinBackoff = 1 * time.Second
+	// supervisorMaxBackoff caps how far consecutive crashes push the delay out to.
+	supervisorMaxBackoff = 2 * time.Minute
+	// supervisorPollInterval is how often waitForExit polls ListWorkloads
+	// when backend doesn't implement EventSource.
+	supervisorPollInterval = 5 * time.Second
+)
+
+// Supervisor watches workloads (via a backend's push-based Events when
+// available, otherwise by polling ListWorkloads) and redeploys ones that
+// die, backing off exponentially between consecutive redeploys of the same
+// workload so a crash-looping MCP server doesn't spin the host.
+type Supervisor struct {
+	backend Backend
+
+	mutex   sync.Mutex
+	workers map[string]*supervisedWorkload
+	hooks   []Hook
+}
+
+type supervisedWorkload struct {
+	cancel   context.CancelFunc
+	redeploy RedeployFunc
+	health   Health
+}
+
+// NewSupervisor creates a Supervisor that redeploys crashed workloads over backend.
+func NewSupervisor(backend Backend) *Supervisor {
+	return &Supervisor{
+		backend: backend,
+		workers: make(map[string]*supervisedWorkload),
+	}
+}
+
+// RegisterHook adds hook to the set called after every redeploy the
+// Supervisor performs.
+func (s *Supervisor) RegisterHook(hook Hook) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+// Supervise starts watching workloadName's containerID and redeploying it
+// via redeploy, with exponential backoff, whenever it exits. It runs until
+// ctx is canceled or Unsupervise is called for workloadName.
+func (s *Supervisor) Supervise(ctx context.Context, workloadName, containerID string, redeploy RedeployFunc) {
+	workerCtx, cancel := context.WithCancel(ctx)
+
+	s.mutex.Lock()
+	s.workers[workloadName] = &supervisedWorkload{
+		cancel:   cancel,
+		redeploy: redeploy,
+		health:   Health{ContainerID: containerID},
+	}
+	s.mutex.Unlock()
+
+	go s.run(workerCtx, workloadName, containerID)
+}
+
+// Unsupervise stops redeploying workloadName, leaving its current run (if
+// any) untouched.
+func (s *Supervisor) Unsupervise(workloadName string) {
+	s.mutex.Lock()
+	worker, ok := s.workers[workloadName]
+	delete(s.workers, workloadName)
+	s.mutex.Unlock()
+
+	if ok {
+		worker.cancel()
+	}
+}
+
+// GetContainerHealth returns the health report accumulated for workloadName,
+// or false if workloadName isn't supervised.
+func (s *Supervisor) GetContainerHealth(workloadName string) (Health, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	worker, ok := s.workers[workloadName]
+	if !ok {
+		return Health{}, false
+	}
+	return worker.health, true
+}
+
+// run watches containerID until it exits, then redeploys it and repeats,
+// doubling the delay between consecutive crashes, until ctx is canceled or
+// workloadName is no longer supervised. A run that lasts longer than
+// supervisorMaxBackoff resets the delay.
+func (s *Supervisor) run(ctx context.Context, workloadName, containerID string) {
+	backoff := supervisorMinBackoff
+
+	for {
+		started := time.Now()
+		action, ok := s.waitForExit(ctx, containerID)
+		if !ok {
+			return
+		}
+		s.recordExit(workloadName, action)
+		if time.Since(started) > supervisorMaxBackoff {
+			backoff = supervisorMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		worker, supervised := s.workerFor(workloadName)
+		if !supervised {
+			return
+		}
+
+		newID, err := worker.redeploy(ctx)
+		if err != nil {
+			logger.Warnf("supervisor: failed to redeploy workload %s after it %s: %v", workloadName, action, err)
+			backoff = nextSupervisorBackoff(backoff)
+			continue
+		}
+
+		oldID := containerID
+		containerID = newID
+		backoff = nextSupervisorBackoff(backoff)
+		s.recordRestart(workloadName, newID)
+
+		for _, hook := range s.hookSnapshot() {
+			hook(ctx, workloadName, oldID, newID)
+		}
+	}
+}
+
+// waitForExit blocks until containerID's next die/oom/kill/destroy event (or
+// ctx is canceled), returning the event's Action. The second return value is
+// false only when ctx was canceled.
+func (s *Supervisor) waitForExit(ctx context.Context, containerID string) (string, bool) {
+	source, ok := s.backend.(EventSource)
+	if !ok {
+		return s.pollForExit(ctx, containerID)
+	}
+
+	events, err := source.Events(ctx, EventFilter{ContainerID: containerID})
+	if err != nil {
+		return s.pollForExit(ctx, containerID)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", false
+		case event, ok := <-events:
+			if !ok {
+				return s.pollForExit(ctx, containerID)
+			}
+			if event.ContainerID != containerID {
+				continue
+			}
+			switch event.Action {
+			case "die", "oom", "kill", "destroy":
+				return event.Action, true
+			}
+		}
+	}
+}
+
+// pollForExit is waitForExit's fallback for backends with no EventSource,
+// polling ListWorkloads for containerID's continued presence.
+func (s *Supervisor) pollForExit(ctx context.Context, containerID string) (string, bool) {
+	ticker := time.NewTicker(supervisorPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", false
+		case <-ticker.C:
+			workloads, err := s.backend.ListWorkloads(ctx)
+			if err != nil {
+				continue
+			}
+			if !containsWorkload(workloads, containerID) {
+				return "die", true
+			}
+		}
+	}
+}
+
+func containsWorkload(workloads []ContainerInfo, containerID string) bool {
+	for _, w := range workloads {
+		if w.ID == containerID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Supervisor) workerFor(workloadName string) (*supervisedWorkload, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	worker, ok := s.workers[workloadName]
+	return worker, ok
+}
+
+func (s *Supervisor) hookSnapshot() []Hook {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	hooks := make([]Hook, len(s.hooks))
+	copy(hooks, s.hooks)
+	return hooks
+}
+
+func (s *Supervisor) recordExit(workloadName, action string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	worker, ok := s.workers[workloadName]
+	if !ok {
+		return
+	}
+	worker.health.LastExitAction = action
+	if action == "oom" {
+		worker.health.OOMKills++
+	}
+}
+
+func (s *Supervisor) recordRestart(workloadName, newContainerID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	worker, ok := s.workers[workloadName]
+	if !ok {
+		return
+	}
+	worker.health.ContainerID = newContainerID
+	worker.health.Restarts++
+	worker.health.LastRestart = time.Now()
+}
+
+// nextSupervisorBackoff doubles backoff, capped at supervisorMaxBackoff.
+func nextSupervisorBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > supervisorMaxBackoff {
+		return supervisorMaxBackoff
+	}
+	return backoff
+}
@@ -68,6 +68,50 @@ type Runtime interface {
 
 	// AttachContainer attaches to a container
 	AttachContainer(ctx context.Context, containerID string) (io.WriteCloser, io.ReadCloser, error)
+
+	// ExecInContainer runs cmd inside containerID and waits for it to
+	// finish, returning its combined result. Use this for short-lived
+	// commands (health probes, cache warmup, injecting rotated secrets)
+	// where the caller just wants the output and exit code.
+	ExecInContainer(ctx context.Context, containerID string, cmd []string, opts ExecOptions) (ExecResult, error)
+
+	// ExecInContainerStream runs cmd inside containerID and returns live
+	// pipes for its stdin/stdout/stderr instead of waiting for it to
+	// finish. resize adjusts the exec's TTY size and is a no-op when
+	// opts.Tty is false; wait blocks until the command exits and returns
+	// its exit code.
+	ExecInContainerStream(ctx context.Context, containerID string, cmd []string, opts ExecOptions) (
+		stdin io.WriteCloser, stdout, stderr io.ReadCloser, resize func(height, width uint), wait func() (int, error), err error)
+
+	// PullImage pulls imageName from a registry. opts selects a platform
+	// variant from a multi-arch index and/or authenticates the pull; a nil
+	// opts pulls the daemon's own platform anonymously.
+	PullImage(ctx context.Context, imageName string, opts *PullImageOptions) error
+
+	// Events subscribes to the runtime's lifecycle event stream (Docker's
+	// /events, Podman's /libpod/events with stream=true), scoped by filter.
+	// The returned channel is closed when ctx is canceled or the
+	// underlying event stream ends.
+	Events(ctx context.Context, filter EventFilter) (<-chan Event, error)
+
+	// ContainerStats reports containerID's resource usage over Docker's
+	// /containers/{id}/stats endpoint (Podman's /libpod/containers/{id}/stats
+	// is wire-compatible). When stream is true, a new ContainerStats is sent
+	// on the returned channel as each update arrives until ctx is canceled;
+	// when false, the channel receives exactly one sample and is then closed.
+	ContainerStats(ctx context.Context, containerID string, stream bool) (<-chan ContainerStats, error)
+
+	// BuildImage builds an image from a Dockerfile in opts.ContextDir over
+	// Docker's /build endpoint (Podman's /libpod/build is wire-compatible),
+	// blocking until the build completes. It returns the built image's ID and
+	// its full build log.
+	BuildImage(ctx context.Context, opts BuildOptions) (imageID string, logs io.ReadCloser, err error)
+
+	// StreamContainerLogs streams containerID's combined stdout/stderr per
+	// opts. If opts.Follow is true the returned reader keeps delivering new
+	// lines until ctx is canceled; otherwise it reads to EOF once all
+	// currently available output has been copied.
+	StreamContainerLogs(ctx context.Context, containerID string, opts LogOptions) (io.ReadCloser, error)
 }
 
 // RuntimeType represents the type of container runtime
@@ -80,6 +124,20 @@ const (
 	RuntimeTypeDocker RuntimeType = "docker"
 )
 
+// PullImageOptions configures PullImage. A nil *PullImageOptions pulls the
+// daemon's own platform anonymously, matching PullImage's previous
+// no-options behavior.
+type PullImageOptions struct {
+	// Platform selects a single os/arch variant from a multi-arch image's
+	// manifest index, e.g. "linux/arm64". Empty selects the daemon's own
+	// platform, the same default `docker pull` itself uses.
+	Platform string
+	// Username and Password authenticate this pull against a private
+	// registry. Both empty means an anonymous pull.
+	Username string
+	Password string
+}
+
 // PermissionConfig represents container permission configuration
 type PermissionConfig struct {
 	// Mounts is the list of volume mounts
@@ -100,12 +158,12 @@ type CreateContainerOptions struct {
 	// The key is in the format "port/protocol" (e.g., "8080/tcp")
 	// The value is an empty struct (not used)
 	ExposedPorts map[string]struct{}
-	
+
 	// PortBindings is a map of container ports to host ports
 	// The key is in the format "port/protocol" (e.g., "8080/tcp")
 	// The value is a slice of host port bindings
 	PortBindings map[string][]PortBinding
-	
+
 	// AttachStdio indicates whether to attach stdin/stdout/stderr
 	// This is typically set to true for stdio transport
 	AttachStdio bool
@@ -124,10 +182,155 @@ func NewCreateContainerOptions() *CreateContainerOptions {
 	return &CreateContainerOptions{
 		ExposedPorts: make(map[string]struct{}),
 		PortBindings: make(map[string][]PortBinding),
-		AttachStdio: false,
+		AttachStdio:  false,
 	}
 }
 
+// ExecOptions configures ExecInContainer and ExecInContainerStream, mirroring
+// the fields exposed by both the Docker and Podman (libpod-compatible) exec
+// APIs.
+type ExecOptions struct {
+	// User runs the command as this user (and optionally group), e.g. "1000:1000"
+	User string
+	// WorkingDir is the working directory for the command, defaulting to the
+	// container's own working directory if empty
+	WorkingDir string
+	// Env is a list of "KEY=VALUE" environment variables to set for the command
+	Env []string
+	// Tty allocates a pseudo-TTY for the command
+	Tty bool
+	// AttachStdin attaches the command's stdin
+	AttachStdin bool
+	// AttachStdout attaches the command's stdout
+	AttachStdout bool
+	// AttachStderr attaches the command's stderr
+	AttachStderr bool
+	// DetachKeys overrides the default key sequence for detaching from the exec session
+	DetachKeys string
+}
+
+// ExecResult is the outcome of ExecInContainer.
+type ExecResult struct {
+	// ExitCode is the command's exit code
+	ExitCode int
+	// Stdout is the command's collected standard output
+	Stdout []byte
+	// Stderr is the command's collected standard error
+	Stderr []byte
+}
+
+// EventFilter scopes a Runtime.Events subscription.
+type EventFilter struct {
+	// ContainerID restricts the subscription to events for this container
+	// only. Empty means all containers.
+	ContainerID string
+}
+
+// Event is a single container lifecycle event from Runtime.Events, using the
+// same vocabulary Docker and Podman report on their event streams (die, oom,
+// kill, destroy, etc. for Type "container").
+type Event struct {
+	// Type is the event's top-level category, e.g. "container"
+	Type string
+	// Action is the specific lifecycle action, e.g. "die", "oom", "kill", "destroy"
+	Action string
+	// ContainerID is the ID of the container the event is about
+	ContainerID string
+	// ExitCode is the container's exit code, populated for "die" events
+	ExitCode *int
+	// Timestamp is when the event occurred
+	Timestamp time.Time
+	// Attributes carries the event's raw key/value labels (e.g. "exitCode", "signal")
+	Attributes map[string]string
+}
+
+// ContainerStats is a single resource-usage sample for a container, matching
+// the fields reported by `docker stats`.
+type ContainerStats struct {
+	// ContainerID is the ID of the container the sample is for
+	ContainerID string
+	// CPUPercent is the container's CPU usage as a percentage of one CPU's
+	// worth of time (0-100 per core; multi-core containers can exceed 100).
+	// Computed from the delta of cpu_stats.cpu_usage.total_usage over
+	// system_cpu_usage, times online_cpus, matching Docker's documented
+	// formula so results are comparable to `docker stats`.
+	CPUPercent float64
+	// MemoryUsageBytes is the container's current memory usage
+	MemoryUsageBytes uint64
+	// MemoryLimitBytes is the container's memory limit
+	MemoryLimitBytes uint64
+	// NetworkRxBytes is the total bytes received across all network interfaces
+	NetworkRxBytes uint64
+	// NetworkTxBytes is the total bytes transmitted across all network interfaces
+	NetworkTxBytes uint64
+	// BlockReadBytes is the total bytes read from block devices
+	BlockReadBytes uint64
+	// BlockWriteBytes is the total bytes written to block devices
+	BlockWriteBytes uint64
+	// Timestamp is when the sample was taken
+	Timestamp time.Time
+}
+
+// BuildOptions configures BuildImage.
+type BuildOptions struct {
+	// ContextDir is the local directory sent as the build context
+	ContextDir string
+	// Dockerfile is the path to the Dockerfile within ContextDir, defaulting
+	// to "Dockerfile" if empty
+	Dockerfile string
+	// Tags are the image names (optionally including a tag) to apply to the
+	// built image, e.g. "my-mcp-server:latest"
+	Tags []string
+	// BuildArgs is a map of "ARG" values to pass to the build
+	BuildArgs map[string]string
+	// Target is the build stage to build, for multi-stage Dockerfiles
+	Target string
+	// Platform is the target platform to build for, e.g. "linux/amd64"
+	Platform string
+	// Pull always attempts to pull a newer version of the base image
+	Pull bool
+	// NoCache disables the build cache
+	NoCache bool
+	// Labels is a map of labels to apply to the built image
+	Labels map[string]string
+	// CacheFrom is a list of external cache sources, e.g. "my-image:cache" or
+	// a Buildx cache reference
+	CacheFrom []string
+	// CacheTo is a list of Buildx-style cache export destinations. Runtimes
+	// that build through the classic (non-Buildx) Docker API may not support
+	// exporting a cache and can ignore this field.
+	CacheTo []string
+	// RemoteContext, when set, is a remote build context the daemon resolves
+	// itself instead of ContextDir: a git URL
+	// ("https://github.com/org/repo.git#branch:subdir"), an HTTPS tarball
+	// URL, or an OCI artifact reference. Mutually exclusive with ContextDir.
+	RemoteContext string
+	// Platforms builds for multiple target platforms at once (e.g.
+	// "linux/amd64", "linux/arm64") in a single invocation, producing a
+	// multi-arch image index. Requires BuildKit and the daemon's containerd
+	// image store; a single entry is equivalent to Platform.
+	Platforms []string
+	// Secrets exposes build secrets to RUN --mount=type=secret instructions
+	// as "id=mysecret,src=/local/path" references, requires BuildKit.
+	Secrets []string
+}
+
+// LogOptions configures StreamContainerLogs, matching docker logs/kubectl
+// logs ergonomics.
+type LogOptions struct {
+	// Follow keeps the stream open and delivers new log lines as they're
+	// written, until the context is canceled.
+	Follow bool
+	// Tail limits the stream to the last N lines of existing output. 0
+	// means all available output.
+	Tail int
+	// Since only returns log lines written after this time. The zero Time
+	// returns all available output.
+	Since time.Time
+	// Timestamps prefixes each line with its RFC3339Nano timestamp.
+	Timestamps bool
+}
+
 // Mount represents a volume mount
 type Mount struct {
 	// Source is the source path on the host
@@ -136,4 +339,4 @@ type Mount struct {
 	Target string
 	// ReadOnly indicates if the mount is read-only
 	ReadOnly bool
-}
\ No newline at end of file
+}
@@ -36,7 +36,7 @@ func SetTransportEnvironmentVariables(envVars map[string]string, transportType s
 	
 	// Set transport-specific environment variables
 	switch transportType {
-	case "sse":
+	case "sse", "streamable-http":
 		envVars["MCP_PORT"] = fmt.Sprintf("%d", port)
 	case "stdio":
 		// No additional environment variables needed for stdio transport
@@ -50,4 +50,14 @@ func AddStandardLabels(labels map[string]string, containerName, transportType st
 	labels["vibetool-name"] = containerName
 	labels["vibetool-transport"] = transportType
 	labels["vibetool-port"] = fmt.Sprintf("%d", port)
-}
\ No newline at end of file
+}
+
+// AddDependsOnLabel records the names of the other MCP servers this
+// container depends on, so the dependency graph survives process restarts
+// (e.g. for `rm` to refuse removal while dependents still exist).
+func AddDependsOnLabel(labels map[string]string, dependsOn []string) {
+	if len(dependsOn) == 0 {
+		return
+	}
+	labels["toolhive-depends-on"] = strings.Join(dependsOn, ",")
+}
@@ -0,0 +1,178 @@
+// Package manifest parses the multi-server YAML manifest the `deploy`
+// command reads, letting an operator stand up a whole toolchain of MCP
+// servers in one invocation instead of scripting repeated `run`s.
+package manifest
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/stacklok/vibetool/pkg/permissions"
+)
+
+// Manifest is the top-level shape of a `vt deploy -f` file: a named group
+// of MCP servers, plus the shared definitions its server entries can refer
+// to by name instead of repeating inline.
+type Manifest struct {
+	// Group names this manifest's deployment, so `vt ls`/`vt stop` can
+	// operate on every server it started as one unit. Defaults to the
+	// manifest file's base name (without extension) if empty.
+	Group string `yaml:"group,omitempty"`
+	// PermissionProfiles maps a name to a permission profile definition,
+	// shared across server entries that reference it by name in
+	// Server.PermissionProfile instead of duplicating the profile inline.
+	PermissionProfiles map[string]*permissions.Profile `yaml:"permissionProfiles,omitempty"`
+	// ConfigMaps maps a name to a set of plain (non-secret) key/value env
+	// vars, referenced by name from Server.ConfigMaps and merged into that
+	// server's environment.
+	ConfigMaps map[string]map[string]string `yaml:"configMaps,omitempty"`
+	// Servers are the MCP server instances this manifest deploys, in
+	// declaration order.
+	Servers []Server `yaml:"servers"`
+}
+
+// Server is one MCP server entry in a Manifest.
+type Server struct {
+	// Name identifies the server within the group; it's also the workload
+	// name it's deployed under.
+	Name string `yaml:"name"`
+	// Image is the server's container image.
+	Image string `yaml:"image"`
+	// Transport is the communication protocol for the server (stdio or
+	// sse), defaulting to "stdio" if empty.
+	Transport string `yaml:"transport,omitempty"`
+	// TargetPort is the port the container exposes, only applicable to the
+	// sse transport.
+	TargetPort int `yaml:"targetPort,omitempty"`
+	// PermissionProfile is either the name of an entry in
+	// Manifest.PermissionProfiles, or a path to a permission profile JSON
+	// file, the same two forms `run --permission-profile` accepts.
+	PermissionProfile string `yaml:"permissionProfile,omitempty"`
+	// Env is a literal set of environment variables for this server,
+	// applied on top of any ConfigMaps it references.
+	Env map[string]string `yaml:"env,omitempty"`
+	// ConfigMaps lists names from Manifest.ConfigMaps whose key/value pairs
+	// are merged into this server's environment, earlier entries losing to
+	// later ones on a key collision, and Env losing to neither (it's
+	// applied last).
+	ConfigMaps []string `yaml:"configMaps,omitempty"`
+	// Secrets lists secrets manager references in the same
+	// "NAME,target=TARGET" format `run --secret` accepts.
+	Secrets []string `yaml:"secrets,omitempty"`
+	// AuthzConfigPath is the path to this server's authorization
+	// configuration file, if any.
+	AuthzConfigPath string `yaml:"authzConfig,omitempty"`
+	// OIDC configures this server's OIDC token validation, if any.
+	OIDC *OIDCConfig `yaml:"oidc,omitempty"`
+}
+
+// OIDCConfig mirrors the run command's --oidc-* flags for a manifest's
+// server entry.
+type OIDCConfig struct {
+	Issuer   string `yaml:"issuer,omitempty"`
+	Audience string `yaml:"audience,omitempty"`
+	JwksURL  string `yaml:"jwksUrl,omitempty"`
+	ClientID string `yaml:"clientId,omitempty"`
+}
+
+// Load reads and parses the manifest at path, defaulting Group to path's
+// base name (without extension) if the file didn't set one.
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+
+	if m.Group == "" {
+		m.Group = groupNameFromPath(path)
+	}
+
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+
+	return &m, nil
+}
+
+// validate checks that every server's PermissionProfile/ConfigMaps
+// references actually resolve within the manifest, so a typo is caught
+// before any container is deployed rather than partway through the group.
+func (m *Manifest) validate() error {
+	seenNames := map[string]bool{}
+	for _, server := range m.Servers {
+		if server.Name == "" {
+			return fmt.Errorf("server entry missing required \"name\"")
+		}
+		if seenNames[server.Name] {
+			return fmt.Errorf("duplicate server name %q", server.Name)
+		}
+		seenNames[server.Name] = true
+
+		if server.Image == "" {
+			return fmt.Errorf("server %q missing required \"image\"", server.Name)
+		}
+
+		if name := server.PermissionProfile; name != "" {
+			if _, isInlineName := m.PermissionProfiles[name]; !isInlineName {
+				if _, err := os.Stat(name); err != nil {
+					return fmt.Errorf(
+						"server %q: permissionProfile %q is neither a permissionProfiles entry nor a file", server.Name, name,
+					)
+				}
+			}
+		}
+
+		for _, cmName := range server.ConfigMaps {
+			if _, ok := m.ConfigMaps[cmName]; !ok {
+				return fmt.Errorf("server %q: configMap %q not defined in manifest", server.Name, cmName)
+			}
+		}
+	}
+	return nil
+}
+
+// groupNameFromPath derives a group name from a manifest file path, the
+// same way a registry server name is derived from an image when none is
+// given.
+func groupNameFromPath(path string) string {
+	base := path
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' || path[i] == '\\' {
+			base = path[i+1:]
+			break
+		}
+	}
+	for i := len(base) - 1; i >= 0; i-- {
+		if base[i] == '.' {
+			return base[:i]
+		}
+	}
+	return base
+}
+
+// ResolveEnv merges s's ConfigMaps (in order) and then s.Env on top, so a
+// literal Env entry always wins over a configMap-sourced one.
+func (m *Manifest) ResolveEnv(s *Server) []string {
+	merged := map[string]string{}
+	for _, cmName := range s.ConfigMaps {
+		for k, v := range m.ConfigMaps[cmName] {
+			merged[k] = v
+		}
+	}
+	for k, v := range s.Env {
+		merged[k] = v
+	}
+
+	env := make([]string, 0, len(merged))
+	for k, v := range merged {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	return env
+}
@@ -22,8 +22,12 @@ type Server struct {
 	// Name is the identifier for the MCP server, used when referencing the server in commands
 	// If not provided, it will be auto-generated from the image name
 	Name string `json:"name,omitempty"`
-	// Image is the Docker image reference for the MCP server
-	Image string `json:"image"`
+	// Image is the Docker image reference for the MCP server. Required
+	// unless Build is set, in which case the image is built locally instead.
+	Image string `json:"image,omitempty"`
+	// Build, if set, builds the server's image locally from source (a local
+	// path or a git repository) instead of pulling a pre-baked Image.
+	Build *BuildSpec `json:"build,omitempty"`
 	// Description is a human-readable description of the server's purpose and functionality
 	Description string `json:"description"`
 	// Transport defines the communication protocol for the server (stdio or sse)
@@ -47,6 +51,39 @@ type Server struct {
 	Tags []string `json:"tags,omitempty"`
 	// DockerTags lists the available Docker tags for this server image
 	DockerTags []string `json:"docker_tags,omitempty"`
+	// Signer pins the identity Image's signature must come from before
+	// toolhive will run it: a sigstore Fulcio identity (e.g. a GitHub
+	// Actions workflow ref) or a GPG key fingerprint, depending on which
+	// trust policy scope covers Image. Empty means the scope's own policy
+	// decides, with no additional pin from the registry entry.
+	Signer string `json:"signer,omitempty"`
+	// Identity refines Signer for signer types that need a second
+	// coordinate to pin an expected identity, such as a sigstore identity's
+	// OIDC issuer URL alongside its Fulcio subject.
+	Identity string `json:"identity,omitempty"`
+	// RecommendedSeccompProfile is a seccomp setting (in the same format as
+	// permissions.Profile.Seccomp) the server's maintainer recommends
+	// running it under. It only takes effect when Permissions.Seccomp isn't
+	// already set and the caller didn't pass --seccomp-profile.
+	RecommendedSeccompProfile string `json:"recommended_seccomp_profile,omitempty"`
+}
+
+// BuildSpec describes how to build a server's image from source instead of
+// pulling a pre-baked one, so contributors can iterate on an MCP server
+// without pushing to a registry.
+type BuildSpec struct {
+	// ContextPath is a local directory to use as the build context. Mutually
+	// exclusive with GitURL.
+	ContextPath string `json:"context_path,omitempty"`
+	// GitURL is a git repository to clone and use as the build context.
+	// Mutually exclusive with ContextPath.
+	GitURL string `json:"git_url,omitempty"`
+	// GitRef is the branch, tag, or commit to check out when GitURL is set,
+	// defaulting to the repository's default branch if empty
+	GitRef string `json:"git_ref,omitempty"`
+	// Dockerfile is the path to the Dockerfile within the build context,
+	// defaulting to "Dockerfile" if empty
+	Dockerfile string `json:"dockerfile,omitempty"`
 }
 
 // EnvVar represents an environment variable for an MCP server
@@ -0,0 +1,74 @@
+package runlabel
+
+import "fmt"
+
+// FlagsSet reports, for each RunOptions field runlabel can fill in, whether
+// the caller explicitly set it on the command line -- mirroring
+// applyRegistrySettings' cmd.Flags().Changed checks, since labels use the
+// exact same "CLI flags win, everything else fills gaps" precedence a
+// registry entry does.
+type FlagsSet struct {
+	Transport         bool
+	TargetPort        bool
+	PermissionProfile bool
+}
+
+// Target is the subset of RunOptions runlabel fills in; defined locally so
+// this package doesn't import the cmd/vt package that owns RunOptions
+// (which would be a cycle -- cmd/vt calls into this package, not the other
+// way around).
+type Target struct {
+	Transport         string
+	TargetPort        int
+	PermissionProfile string
+	EnvVars           []string
+}
+
+// MissingEnvVar names a Required io.toolhive.env.<NAME> label Apply
+// couldn't satisfy from providedEnv or a Default.
+type MissingEnvVar struct {
+	Name        string
+	Description string
+}
+
+// Apply merges an image's io.toolhive.* labels into target, only
+// overwriting fields the caller didn't already set via a CLI flag (set).
+// Required env vars whose names aren't in providedEnv are reported in
+// missing so the caller can prompt for them or fail, the same way a registry
+// entry's required env vars are handled.
+func Apply(labels map[string]string, target *Target, set FlagsSet, providedEnv map[string]bool) (missing []MissingEnvVar, err error) {
+	if transport, ok := labels[Transport]; ok && !set.Transport {
+		target.Transport = transport
+	}
+
+	if portLabel, ok := labels[TargetPort]; ok && !set.TargetPort {
+		var port int
+		if _, err := fmt.Sscanf(portLabel, "%d", &port); err != nil {
+			return nil, fmt.Errorf("invalid %s label %q: %w", TargetPort, portLabel, err)
+		}
+		target.TargetPort = port
+	}
+
+	if profile, ok := labels[PermissionProfile]; ok && !set.PermissionProfile {
+		target.PermissionProfile = profile
+	}
+
+	envVars, err := EnvVars(labels)
+	if err != nil {
+		return nil, err
+	}
+	for name, env := range envVars {
+		if providedEnv[name] {
+			continue
+		}
+		if env.Required {
+			missing = append(missing, MissingEnvVar{Name: name, Description: env.Description})
+			continue
+		}
+		if env.Default != "" {
+			target.EnvVars = append(target.EnvVars, name+"="+env.Default)
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,78 @@
+// Package runlabel reads toolhive's io.toolhive.* image labels, letting an
+// image author ship a runnable MCP server without a PR to the curated
+// registry -- the same role `podman container runlabel`'s LABEL RUN plays
+// for a single container.
+package runlabel
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Label names toolhive looks for on an image's config.
+const (
+	// Transport names the transport the image speaks (stdio or sse).
+	Transport = "io.toolhive.transport"
+	// TargetPort is the port the container exposes, for the sse transport.
+	TargetPort = "io.toolhive.target-port"
+	// PermissionProfile is either a well-known profile name (stdio,
+	// network) or an inline JSON permission profile document.
+	PermissionProfile = "io.toolhive.permission-profile"
+	// EnvPrefix prefixes one label per declared environment variable, e.g.
+	// "io.toolhive.env.API_KEY".
+	EnvPrefix = "io.toolhive.env."
+	// Run is a template string toolhive runs verbatim instead of its own
+	// default invocation, with $IMAGE/$NAME/$OPTS substituted in -- the
+	// same role LABEL RUN plays for `podman container runlabel`.
+	Run = "io.toolhive.run"
+)
+
+// EnvVar describes one io.toolhive.env.<NAME> label's value.
+type EnvVar struct {
+	// Required marks this variable as one the MCP server can't start
+	// without; `run` fails with an error naming it if it's not already
+	// provided.
+	Required bool `json:"required,omitempty"`
+	// Default is used when the variable isn't otherwise provided and
+	// Required is false.
+	Default string `json:"default,omitempty"`
+	// Description is included in `run`'s error for a missing Required
+	// variable (the first missing one's only).
+	Description string `json:"description,omitempty"`
+}
+
+// ParseEnvVar decodes an io.toolhive.env.<NAME> label's JSON value.
+func ParseEnvVar(value string) (EnvVar, error) {
+	var env EnvVar
+	if err := json.Unmarshal([]byte(value), &env); err != nil {
+		return EnvVar{}, fmt.Errorf("failed to parse env label value %q: %w", value, err)
+	}
+	return env, nil
+}
+
+// EnvVars extracts every io.toolhive.env.<NAME> label from labels, keyed by
+// NAME.
+func EnvVars(labels map[string]string) (map[string]EnvVar, error) {
+	envVars := map[string]EnvVar{}
+	for key, value := range labels {
+		name, ok := strings.CutPrefix(key, EnvPrefix)
+		if !ok || name == "" {
+			continue
+		}
+		env, err := ParseEnvVar(value)
+		if err != nil {
+			return nil, err
+		}
+		envVars[name] = env
+	}
+	return envVars, nil
+}
+
+// ExpandRunTemplate substitutes $IMAGE, $NAME, and $OPTS into an
+// io.toolhive.run template, matching the substitutions `podman container
+// runlabel` makes into LABEL RUN.
+func ExpandRunTemplate(template, image, name, opts string) string {
+	replacer := strings.NewReplacer("$IMAGE", image, "$NAME", name, "$OPTS", opts)
+	return replacer.Replace(template)
+}
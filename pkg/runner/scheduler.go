@@ -0,0 +1,211 @@
+// Package runner implements dependency-ordered scheduling of MCP servers,
+// resolving the DependsOn graph declared on each server so that a group of
+// servers can be started and stopped in an order that respects their
+// dependencies.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ErrCycle is returned by NewGraph when the DependsOn relationships between
+// servers form a cycle and therefore cannot be scheduled.
+var ErrCycle = fmt.Errorf("dependency graph contains a cycle")
+
+// ErrUnknownDependency is returned by NewGraph when a server declares a
+// DependsOn entry that does not match any server in the set being scheduled.
+var ErrUnknownDependency = fmt.Errorf("unknown dependency")
+
+// Node describes one schedulable MCP server and the names of the other
+// servers (in the same invocation) it depends on.
+type Node struct {
+	// Name is the server's unique name within the graph.
+	Name string
+	// DependsOn lists the names of servers that must be ready before this
+	// one is started.
+	DependsOn []string
+}
+
+// Graph is a resolved, acyclic dependency graph over a set of Nodes.
+type Graph struct {
+	nodes map[string]Node
+}
+
+// NewGraph validates nodes and returns a Graph that can be scheduled.
+// It rejects unknown dependencies and cycles with ErrUnknownDependency /
+// ErrCycle respectively.
+func NewGraph(nodes []Node) (*Graph, error) {
+	byName := make(map[string]Node, len(nodes))
+	for _, n := range nodes {
+		byName[n.Name] = n
+	}
+
+	for _, n := range nodes {
+		for _, dep := range n.DependsOn {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("%w: %q depends on %q", ErrUnknownDependency, n.Name, dep)
+			}
+		}
+	}
+
+	g := &Graph{nodes: byName}
+	if _, err := g.Levels(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Levels groups the graph's nodes into start order: level 0 has no
+// dependencies, level 1 depends only on nodes in level 0, and so on. Nodes
+// within a level have no dependency relationship to one another and may be
+// started concurrently. Levels returns ErrCycle if the graph is not a DAG.
+func (g *Graph) Levels() ([][]string, error) {
+	remaining := make(map[string][]string, len(g.nodes))
+	for name, n := range g.nodes {
+		deps := make([]string, len(n.DependsOn))
+		copy(deps, n.DependsOn)
+		remaining[name] = deps
+	}
+
+	var levels [][]string
+	for len(remaining) > 0 {
+		var level []string
+		for name, deps := range remaining {
+			if len(deps) == 0 {
+				level = append(level, name)
+			}
+		}
+		if len(level) == 0 {
+			return nil, fmt.Errorf("%w: involving %v", ErrCycle, remainingNames(remaining))
+		}
+		sort.Strings(level)
+
+		ready := make(map[string]bool, len(level))
+		for _, name := range level {
+			ready[name] = true
+			delete(remaining, name)
+		}
+		for name, deps := range remaining {
+			filtered := deps[:0]
+			for _, d := range deps {
+				if !ready[d] {
+					filtered = append(filtered, d)
+				}
+			}
+			remaining[name] = filtered
+		}
+
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// ReverseLevels returns Levels() in reverse order, with the order of nodes
+// reversed within each level too, suitable for a stop/remove pass that must
+// tear down dependents before their dependencies.
+func (g *Graph) ReverseLevels() ([][]string, error) {
+	levels, err := g.Levels()
+	if err != nil {
+		return nil, err
+	}
+	reversed := make([][]string, len(levels))
+	for i, level := range levels {
+		rl := make([]string, len(level))
+		for j, name := range level {
+			rl[len(level)-1-j] = name
+		}
+		reversed[len(levels)-1-i] = rl
+	}
+	return reversed, nil
+}
+
+// Dependents returns the names of nodes that directly or transitively
+// declare name in their DependsOn list.
+func (g *Graph) Dependents(name string) []string {
+	var dependents []string
+	visited := make(map[string]bool)
+	var visit func(target string)
+	visit = func(target string) {
+		for n, node := range g.nodes {
+			if visited[n] {
+				continue
+			}
+			for _, dep := range node.DependsOn {
+				if dep == target {
+					visited[n] = true
+					dependents = append(dependents, n)
+					visit(n)
+					break
+				}
+			}
+		}
+	}
+	visit(name)
+	sort.Strings(dependents)
+	return dependents
+}
+
+func remainingNames(remaining map[string][]string) []string {
+	names := make([]string, 0, len(remaining))
+	for name := range remaining {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadinessFunc checks whether the named server is ready to accept
+// dependents, e.g. via an HTTP GET on its SSE endpoint or a stdio JSON-RPC
+// "initialize" round-trip.
+type ReadinessFunc func(ctx context.Context, name string) error
+
+// StartFunc starts the named server and returns once the start request has
+// been issued; it does not need to block until the server is ready.
+type StartFunc func(ctx context.Context, name string) error
+
+// StartTopological starts every node in g, level by level, waiting for every
+// server in a level to report ready (via isReady) before starting the next
+// level. If any server in a level fails to start or become ready, the whole
+// operation is aborted and the error is returned.
+func StartTopological(ctx context.Context, g *Graph, start StartFunc, isReady ReadinessFunc) error {
+	levels, err := g.Levels()
+	if err != nil {
+		return err
+	}
+
+	for _, level := range levels {
+		for _, name := range level {
+			if err := start(ctx, name); err != nil {
+				return fmt.Errorf("failed to start %q: %w", name, err)
+			}
+		}
+		for _, name := range level {
+			if err := isReady(ctx, name); err != nil {
+				return fmt.Errorf("%q did not become ready: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// StopFunc stops the named server.
+type StopFunc func(ctx context.Context, name string) error
+
+// StopTopological stops every node in g in reverse dependency order, so that
+// dependents are always stopped before the servers they depend on.
+func StopTopological(ctx context.Context, g *Graph, stop StopFunc) error {
+	levels, err := g.ReverseLevels()
+	if err != nil {
+		return err
+	}
+	for _, level := range levels {
+		for _, name := range level {
+			if err := stop(ctx, name); err != nil {
+				return fmt.Errorf("failed to stop %q: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGraphLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		nodes     []Node
+		expectErr error
+		expected  [][]string
+	}{
+		{
+			name: "no dependencies",
+			nodes: []Node{
+				{Name: "a"},
+				{Name: "b"},
+			},
+			expected: [][]string{{"a", "b"}},
+		},
+		{
+			name: "linear chain",
+			nodes: []Node{
+				{Name: "a"},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			expected: [][]string{{"a"}, {"b"}, {"c"}},
+		},
+		{
+			name: "diamond",
+			nodes: []Node{
+				{Name: "a"},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"a"}},
+				{Name: "d", DependsOn: []string{"b", "c"}},
+			},
+			expected: [][]string{{"a"}, {"b", "c"}, {"d"}},
+		},
+		{
+			name: "cycle",
+			nodes: []Node{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+			},
+			expectErr: ErrCycle,
+		},
+		{
+			name: "unknown dependency",
+			nodes: []Node{
+				{Name: "a", DependsOn: []string{"missing"}},
+			},
+			expectErr: ErrUnknownDependency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g, err := NewGraph(tt.nodes)
+			if tt.expectErr != nil {
+				require.ErrorIs(t, err, tt.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			levels, err := g.Levels()
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, levels)
+		})
+	}
+}
+
+func TestGraphDependents(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGraph([]Node{
+		{Name: "a"},
+		{Name: "b", DependsOn: []string{"a"}},
+		{Name: "c", DependsOn: []string{"b"}},
+		{Name: "d"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"b", "c"}, g.Dependents("a"))
+	assert.Equal(t, []string{"c"}, g.Dependents("b"))
+	assert.Empty(t, g.Dependents("d"))
+}
+
+func TestStartTopologicalOrder(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGraph([]Node{
+		{Name: "a"},
+		{Name: "b", DependsOn: []string{"a"}},
+		{Name: "c", DependsOn: []string{"b"}},
+	})
+	require.NoError(t, err)
+
+	var started []string
+	err = StartTopological(context.Background(), g,
+		func(_ context.Context, name string) error {
+			started = append(started, name)
+			return nil
+		},
+		func(context.Context, string) error { return nil },
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, started)
+}
+
+func TestStopTopologicalOrder(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewGraph([]Node{
+		{Name: "a"},
+		{Name: "b", DependsOn: []string{"a"}},
+		{Name: "c", DependsOn: []string{"b"}},
+	})
+	require.NoError(t, err)
+
+	var stopped []string
+	err = StopTopological(context.Background(), g, func(_ context.Context, name string) error {
+		stopped = append(stopped, name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b", "a"}, stopped)
+}
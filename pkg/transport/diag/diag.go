@@ -0,0 +1,192 @@
+// Package diag exposes a diagnostic HTTP listener for a transport, separate
+// from the MCP-facing proxy port: Kubernetes-style /healthz and /readyz
+// probes, a Prometheus /metrics endpoint, and /debug/pprof/* profiling, so
+// operators can monitor and probe a running transport without that traffic
+// competing with MCP clients for the same port.
+package diag
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// Direction labels a forwarded JSON-RPC message's flow for
+// Metrics.MessagesForwarded.
+type Direction string
+
+const (
+	// DirectionToContainer labels a message sent from a client to the container.
+	DirectionToContainer Direction = "to_container"
+	// DirectionToClient labels a message sent from the container to clients.
+	DirectionToClient Direction = "to_client"
+)
+
+// RestartOutcome labels a container restart/reattach attempt for
+// Metrics.ContainerRestarts.
+type RestartOutcome string
+
+const (
+	// RestartOutcomeSucceeded labels a reattach attempt that succeeded.
+	RestartOutcomeSucceeded RestartOutcome = "succeeded"
+	// RestartOutcomeFailed labels a reattach attempt that failed.
+	RestartOutcomeFailed RestartOutcome = "failed"
+)
+
+// Metrics holds the Prometheus collectors a transport publishes: messages
+// forwarded in each direction, JSON parse failures, bytes read from the
+// container's stdout, sanitization fallbacks, connected client count, and
+// container restart/reattach events.
+type Metrics struct {
+	MessagesForwarded     *prometheus.CounterVec
+	JSONParseFailures     prometheus.Counter
+	StdoutBytesRead       prometheus.Counter
+	SanitizationFallbacks prometheus.Counter
+	ConnectedClients      prometheus.Gauge
+	ContainerRestarts     *prometheus.CounterVec
+}
+
+// NewMetrics creates the transport metrics and registers them against reg.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		MessagesForwarded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "toolhive",
+			Subsystem: "transport",
+			Name:      "messages_forwarded_total",
+			Help:      "JSON-RPC messages forwarded between client and container, by direction.",
+		}, []string{"direction"}),
+		JSONParseFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "toolhive",
+			Subsystem: "transport",
+			Name:      "json_parse_failures_total",
+			Help:      "JSON-RPC frames read from container stdout that failed to decode.",
+		}),
+		StdoutBytesRead: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "toolhive",
+			Subsystem: "transport",
+			Name:      "stdout_bytes_read_total",
+			Help:      "Bytes read from the container's stdout.",
+		}),
+		SanitizationFallbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "toolhive",
+			Subsystem: "transport",
+			Name:      "sanitization_fallbacks_total",
+			Help:      "Non-JSON-RPC lines on container stdout that were skipped instead of forwarded.",
+		}),
+		ConnectedClients: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "toolhive",
+			Subsystem: "transport",
+			Name:      "connected_clients",
+			Help:      "Number of SSE/Streamable HTTP clients currently connected.",
+		}),
+		ContainerRestarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "toolhive",
+			Subsystem: "transport",
+			Name:      "container_restarts_total",
+			Help:      "Container restart/reattach attempts, by outcome.",
+		}, []string{"outcome"}),
+	}
+
+	reg.MustRegister(
+		m.MessagesForwarded,
+		m.JSONParseFailures,
+		m.StdoutBytesRead,
+		m.SanitizationFallbacks,
+		m.ConnectedClients,
+		m.ContainerRestarts,
+	)
+
+	return m
+}
+
+// Server is a diagnostic HTTP listener, separate from the MCP-facing proxy,
+// exposing health/readiness probes, pprof, and a Prometheus metrics
+// endpoint. It starts healthy and not-ready; call SetReady(true) once the
+// transport has finished starting up, and SetHealthy(false) once it begins
+// shutting down.
+type Server struct {
+	addr   string
+	server *http.Server
+
+	ready   atomic.Bool
+	healthy atomic.Bool
+}
+
+// NewServer creates a diagnostic server that will listen on addr (e.g.
+// ":8081") once Start is called, reporting metrics registered against reg.
+func NewServer(addr string, reg *prometheus.Registry) *Server {
+	s := &Server{addr: addr}
+	s.healthy.Store(true)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	s.server = &http.Server{Addr: addr, Handler: mux}
+	return s
+}
+
+// Start binds the diagnostic listener and begins serving in the background.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to bind diagnostic listener on %s: %w", s.addr, err)
+	}
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil && err != http.ErrServerClosed {
+			logger.Log.Error(fmt.Sprintf("Diagnostic server error: %v", err))
+		}
+	}()
+
+	logger.Log.Info(fmt.Sprintf("Diagnostic server listening on %s", s.addr))
+	return nil
+}
+
+// Stop gracefully shuts down the diagnostic server.
+func (s *Server) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// SetReady marks the transport ready (or not) for traffic; /readyz reflects
+// this.
+func (s *Server) SetReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// SetHealthy marks the transport healthy (or not); /healthz reflects this.
+func (s *Server) SetHealthy(healthy bool) {
+	s.healthy.Store(healthy)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	if !s.healthy.Load() {
+		http.Error(w, "unhealthy", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if !s.ready.Load() {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
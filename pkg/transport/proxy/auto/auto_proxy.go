@@ -0,0 +1,160 @@
+// Package auto provides a proxy that serves legacy HTTP+SSE clients and
+// modern Streamable HTTP clients from a single listener, so a server doesn't
+// have to pick one MCP HTTP transport over the other.
+package auto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+	"github.com/StacklokLabs/toolhive/pkg/transport/proxy/httpsse"
+	"github.com/StacklokLabs/toolhive/pkg/transport/proxy/streamablehttp"
+	"github.com/StacklokLabs/toolhive/pkg/transport/types"
+)
+
+// Proxy composes an HTTPSSEProxy and a StreamableHTTPProxy behind one HTTP
+// server: legacy clients keep using the SSE and messages endpoints, modern
+// clients use the single streamable endpoint, and both are reachable on the
+// same port at the same time. Within the streamable endpoint itself,
+// StreamableHTTPProxy already negotiates plain-JSON vs. SSE-upgrade
+// responses from the client's Accept header.
+//
+//nolint:revive // Intentionally named Proxy despite package name, mirroring httpsse.Proxy and streamablehttp.
+type Proxy struct {
+	port          int
+	containerName string
+	middlewares   []types.Middleware
+
+	sse        *httpsse.HTTPSSEProxy
+	streamable *streamablehttp.StreamableHTTPProxy
+
+	server     *http.Server
+	shutdownCh chan struct{}
+
+	messageCh  chan jsonrpc2.Message
+	responseCh chan jsonrpc2.Message
+}
+
+// NewProxy creates a new auto-negotiating proxy for transports.
+func NewProxy(port int, containerName string, middlewares ...types.Middleware) *Proxy {
+	return &Proxy{
+		port:          port,
+		containerName: containerName,
+		middlewares:   middlewares,
+		sse:           httpsse.NewHTTPSSEProxy(port, containerName, middlewares...),
+		streamable:    streamablehttp.NewStreamableHTTPProxy(port, containerName, middlewares...),
+		shutdownCh:    make(chan struct{}),
+		messageCh:     make(chan jsonrpc2.Message, 100),
+		responseCh:    make(chan jsonrpc2.Message, 100),
+	}
+}
+
+// Start starts the combined proxy.
+func (p *Proxy) Start(_ context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", p.sse.Handler())
+	mux.Handle(streamablehttp.HTTPStreamableEndpoint, p.streamable.Handler())
+
+	p.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", p.port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second, // Prevent Slowloris attacks
+	}
+
+	go func() {
+		logger.Log.Info(fmt.Sprintf("Auto HTTP proxy started for container %s on port %d", p.containerName, p.port))
+		logger.Log.Info(fmt.Sprintf("SSE endpoint: http://localhost:%d%s", p.port, "/sse"))
+		logger.Log.Info(fmt.Sprintf("Streamable endpoint: http://localhost:%d%s", p.port, streamablehttp.HTTPStreamableEndpoint))
+
+		if err := p.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Log.Error(fmt.Sprintf("HTTP server error: %v", err))
+		}
+	}()
+
+	// Merge client->destination messages from both inner proxies into one
+	// channel, since StdioTransport only reads from a single message channel.
+	go p.pumpMessages(p.sse.GetMessageChannel())
+	go p.pumpMessages(p.streamable.GetMessageChannel())
+
+	return nil
+}
+
+// Stop stops the combined proxy.
+func (p *Proxy) Stop(ctx context.Context) error {
+	close(p.shutdownCh)
+
+	if p.server != nil {
+		return p.server.Shutdown(ctx)
+	}
+
+	return nil
+}
+
+// GetMessageChannel returns the merged channel for messages to/from the destination.
+func (p *Proxy) GetMessageChannel() chan jsonrpc2.Message {
+	return p.messageCh
+}
+
+// GetResponseChannel returns the channel for receiving messages from the destination.
+func (p *Proxy) GetResponseChannel() <-chan jsonrpc2.Message {
+	return p.responseCh
+}
+
+// ClientCount returns the number of clients currently connected across both
+// inner proxies.
+func (p *Proxy) ClientCount() int {
+	return p.sse.ClientCount() + p.streamable.ClientCount()
+}
+
+// SendMessageToDestination sends a message to the destination.
+func (p *Proxy) SendMessageToDestination(msg jsonrpc2.Message) error {
+	select {
+	case p.messageCh <- msg:
+		return nil
+	default:
+		return fmt.Errorf("message channel full, dropping message")
+	}
+}
+
+// ForwardResponseToClients forwards a response from the destination to every
+// client connected through either inner proxy, whichever they picked.
+func (p *Proxy) ForwardResponseToClients(ctx context.Context, msg jsonrpc2.Message) error {
+	return errors.Join(
+		p.sse.ForwardResponseToClients(ctx, msg),
+		p.streamable.ForwardResponseToClients(ctx, msg),
+	)
+}
+
+// SendResponseMessage sends a message to the response channel of both inner proxies.
+func (p *Proxy) SendResponseMessage(msg jsonrpc2.Message) error {
+	return errors.Join(
+		p.sse.SendResponseMessage(msg),
+		p.streamable.SendResponseMessage(msg),
+	)
+}
+
+// pumpMessages forwards every message from an inner proxy's message channel
+// into the combined channel until the proxy is stopped or src is closed.
+func (p *Proxy) pumpMessages(src <-chan jsonrpc2.Message) {
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case msg, ok := <-src:
+			if !ok {
+				return
+			}
+			select {
+			case p.messageCh <- msg:
+			default:
+				logger.Log.Warn("Auto proxy message channel full, dropping message")
+			}
+		}
+	}
+}
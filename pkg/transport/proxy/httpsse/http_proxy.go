@@ -6,7 +6,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +22,19 @@ import (
 	"github.com/StacklokLabs/toolhive/pkg/transport/types"
 )
 
+// clientIPContextKey is the context.Value key under which handleSSEConnection
+// stores a request's resolved client IP, so middlewares and handlers further
+// down the chain that derive their context from the request can read it
+// without re-deriving it themselves.
+type clientIPContextKey struct{}
+
+// ClientIPFromContext returns the client IP resolveClientIP attached to ctx,
+// if any.
+func ClientIPFromContext(ctx context.Context) (netip.Addr, bool) {
+	ip, ok := ctx.Value(clientIPContextKey{}).(netip.Addr)
+	return ip, ok
+}
+
 // Proxy defines the interface for proxying messages between clients and destinations.
 type Proxy interface {
 	// Start starts the proxy.
@@ -60,13 +77,167 @@ type HTTPSSEProxy struct {
 	sseClients      map[string]*ssecommon.SSEClient
 	sseClientsMutex sync.Mutex
 
-	// Pending messages for SSE clients
-	pendingMessages []*ssecommon.PendingSSEMessage
+	// pendingMessages holds messages queued because their destination client
+	// wasn't connected to receive them immediately, keyed by the session/
+	// client ID they belong to so a message meant for one session is never
+	// handed to a different one that happens to connect first. Broadcast
+	// notifications, which name no owning client, are queued under
+	// pendingBroadcastKey instead and delivered to every new connection.
+	pendingMessages map[string][]*ssecommon.PendingSSEMessage
 	pendingMutex    sync.Mutex
 
+	// requestOwner maps an in-flight JSON-RPC request ID to the client that
+	// sent it, so its eventual response is routed back to that one client
+	// instead of broadcast to every connected client. Entries are removed
+	// once the matching response is forwarded.
+	requestOwner   map[string]string
+	requestOwnerMu sync.Mutex
+
+	// backlogs holds each session's bounded replay buffer, keyed by the same
+	// session_id a client reconnects with, so a dropped SSE connection can
+	// resume from its Last-Event-ID instead of silently missing whatever
+	// was sent while it was gone.
+	backlogs   map[string]*sseBacklog
+	backlogsMu sync.Mutex
+
+	// trustedProxies lists the peers allowed to set forwarding headers
+	// (X-Forwarded-For/Proto/Host, Forwarded) on an incoming request. A
+	// request from any other peer has those headers ignored entirely, since
+	// an untrusted client could otherwise spoof its own scheme or IP.
+	trustedProxies []netip.Prefix
+
+	// trustedProxyHeaders restricts which forwarding headers a trusted proxy
+	// is honored for. An empty slice (the default) honors all of them.
+	trustedProxyHeaders []string
+
 	// Message channels
 	messageCh  chan jsonrpc2.Message
 	responseCh chan jsonrpc2.Message
+
+	// messageChMu and responseChMu guard closing messageCh/responseCh in
+	// Stop against a concurrent send in SendMessageToDestination/
+	// SendResponseMessage, which would otherwise panic.
+	messageChMu      sync.Mutex
+	messageChClosed  bool
+	responseChMu     sync.Mutex
+	responseChClosed bool
+
+	// wg tracks every in-flight SSE client goroutine and POST handler, so
+	// Stop can wait for them to notice shutdownCh and return before closing
+	// messageCh/responseCh out from under them.
+	wg sync.WaitGroup
+
+	// writeTimeout bounds how long sendSSEEvent waits for a slow client
+	// before evicting it; heartbeatInterval is how often handleSSEConnection
+	// emits a keep-alive comment. Both default to the sseDefault* constants
+	// and can be overridden via SetSSETuning before Start.
+	writeTimeout      time.Duration
+	heartbeatInterval time.Duration
+
+	// metricsMu guards the counters handleMetrics reports.
+	metricsMu       sync.Mutex
+	eventsSentTotal uint64
+	evictionsTotal  map[string]uint64
+}
+
+// sseShutdownRetryMillis is the retry: hint sent with the final "shutdown"
+// SSE event, telling a well-behaved client how long to wait before
+// reconnecting to (presumably) a fresh instance.
+const sseShutdownRetryMillis = 1000
+
+// pendingBroadcastKey is the pendingMessages key for server-initiated
+// notifications, which (unlike a call's response) belong to no single
+// client, so they're delivered to whichever client connects next rather
+// than held for one session.
+const pendingBroadcastKey = ""
+
+// sseDefaultWriteTimeout and sseDefaultHeartbeatInterval are HTTPSSEProxy's
+// defaults for, respectively, how long a slow client is given to drain a
+// broadcast event before eviction, and how often an idle connection gets a
+// keep-alive comment to stop NAT/proxy hops from timing it out.
+const (
+	sseDefaultWriteTimeout      = 5 * time.Second
+	sseDefaultHeartbeatInterval = 15 * time.Second
+)
+
+// sseResumeBufferSize bounds how many past events a session remembers for
+// Last-Event-ID replay on reconnect.
+const sseResumeBufferSize = 100
+
+// sseEvent is a single buffered/sent SSE payload tagged with the
+// monotonically increasing event ID used for Last-Event-ID resumption.
+type sseEvent struct {
+	id   uint64
+	data string
+}
+
+// sseBacklog is one session's bounded history of recently sent SSE events.
+type sseBacklog struct {
+	mu     sync.Mutex
+	nextID uint64
+	events []sseEvent
+}
+
+// record appends data as a new event and returns it tagged with its
+// assigned ID, trimming the backlog down to sseResumeBufferSize entries.
+func (b *sseBacklog) record(data string) sseEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	evt := sseEvent{id: b.nextID, data: data}
+
+	b.events = append(b.events, evt)
+	if len(b.events) > sseResumeBufferSize {
+		b.events = b.events[len(b.events)-sseResumeBufferSize:]
+	}
+	return evt
+}
+
+// eventsAfter returns every buffered event with an ID greater than
+// lastEventID, in order, for replay on reconnect.
+func (b *sseBacklog) eventsAfter(lastEventID uint64) []sseEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []sseEvent
+	for _, evt := range b.events {
+		if evt.id > lastEventID {
+			replay = append(replay, evt)
+		}
+	}
+	return replay
+}
+
+// backlogFor returns the replay buffer for sessionID, creating one if this
+// is the session's first event.
+func (p *HTTPSSEProxy) backlogFor(sessionID string) *sseBacklog {
+	p.backlogsMu.Lock()
+	defer p.backlogsMu.Unlock()
+
+	b, ok := p.backlogs[sessionID]
+	if !ok {
+		b = &sseBacklog{}
+		p.backlogs[sessionID] = b
+	}
+	return b
+}
+
+// formatEvent renders evt as a wire-ready SSE frame, with its id: line
+// prepended so a client's Last-Event-ID tracks it on the next reconnect.
+func formatEvent(evt sseEvent) string {
+	return fmt.Sprintf("id: %d\n%s", evt.id, evt.data)
+}
+
+// parseLastEventID reads the Last-Event-ID header used by SSE clients to
+// resume a dropped connection, returning 0 (replay nothing) if absent or
+// unparseable.
+func parseLastEventID(r *http.Request) uint64 {
+	id, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
 }
 
 // NewHTTPSSEProxy creates a new HTTP SSE proxy for transports.
@@ -79,7 +250,170 @@ func NewHTTPSSEProxy(port int, containerName string, middlewares ...types.Middle
 		messageCh:       make(chan jsonrpc2.Message, 100),
 		responseCh:      make(chan jsonrpc2.Message, 100),
 		sseClients:      make(map[string]*ssecommon.SSEClient),
-		pendingMessages: []*ssecommon.PendingSSEMessage{},
+		pendingMessages: make(map[string][]*ssecommon.PendingSSEMessage),
+		requestOwner:    make(map[string]string),
+		backlogs:        make(map[string]*sseBacklog),
+
+		writeTimeout:      sseDefaultWriteTimeout,
+		heartbeatInterval: sseDefaultHeartbeatInterval,
+		evictionsTotal:    make(map[string]uint64),
+	}
+}
+
+// SetSSETuning overrides the per-client write timeout and heartbeat
+// interval; a non-positive value for either leaves its default in place.
+// Call this before Start.
+func (p *HTTPSSEProxy) SetSSETuning(writeTimeout, heartbeatInterval time.Duration) {
+	if writeTimeout > 0 {
+		p.writeTimeout = writeTimeout
+	}
+	if heartbeatInterval > 0 {
+		p.heartbeatInterval = heartbeatInterval
+	}
+}
+
+// recordEventSent increments the sse_events_sent_total counter handleMetrics
+// reports.
+func (p *HTTPSSEProxy) recordEventSent() {
+	p.metricsMu.Lock()
+	p.eventsSentTotal++
+	p.metricsMu.Unlock()
+}
+
+// recordEviction increments the sse_client_evictions_total{reason=...}
+// counter handleMetrics reports.
+func (p *HTTPSSEProxy) recordEviction(reason string) {
+	p.metricsMu.Lock()
+	p.evictionsTotal[reason]++
+	p.metricsMu.Unlock()
+}
+
+// handleMetrics renders a minimal Prometheus text-exposition snapshot of
+// this proxy's SSE counters: current client count, total events sent, and
+// client evictions by reason.
+func (p *HTTPSSEProxy) handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	p.metricsMu.Lock()
+	eventsSent := p.eventsSentTotal
+	evictions := make(map[string]uint64, len(p.evictionsTotal))
+	for reason, count := range p.evictionsTotal {
+		evictions[reason] = count
+	}
+	p.metricsMu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	fmt.Fprint(w, "# HELP sse_clients Number of currently connected SSE clients.\n")
+	fmt.Fprint(w, "# TYPE sse_clients gauge\n")
+	fmt.Fprintf(w, "sse_clients %d\n", p.ClientCount())
+
+	fmt.Fprint(w, "# HELP sse_events_sent_total Total number of SSE events sent to clients.\n")
+	fmt.Fprint(w, "# TYPE sse_events_sent_total counter\n")
+	fmt.Fprintf(w, "sse_events_sent_total %d\n", eventsSent)
+
+	fmt.Fprint(w, "# HELP sse_client_evictions_total Total number of SSE clients evicted, by reason.\n")
+	fmt.Fprint(w, "# TYPE sse_client_evictions_total counter\n")
+	for reason, count := range evictions {
+		fmt.Fprintf(w, "sse_client_evictions_total{reason=%q} %d\n", reason, count)
+	}
+}
+
+// SetTrustedProxies configures which peers are allowed to set forwarding
+// headers on a request, and (optionally) which of those headers are honored
+// from them; an empty headers slice honors all of X-Forwarded-For,
+// X-Forwarded-Proto, X-Forwarded-Host and Forwarded. Call this before Start.
+// Requests from any peer not in proxies have forwarding headers ignored.
+func (p *HTTPSSEProxy) SetTrustedProxies(proxies []netip.Prefix, headers []string) {
+	p.trustedProxies = proxies
+	p.trustedProxyHeaders = headers
+}
+
+// isTrustedProxy reports whether addr falls within a configured trusted
+// proxy prefix.
+func (p *HTTPSSEProxy) isTrustedProxy(addr netip.Addr) bool {
+	for _, prefix := range p.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// trustsHeader reports whether header should be honored from a trusted
+// peer, per trustedProxyHeaders.
+func (p *HTTPSSEProxy) trustsHeader(header string) bool {
+	if len(p.trustedProxyHeaders) == 0 {
+		return true
+	}
+	for _, h := range p.trustedProxyHeaders {
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerAddr extracts the IP from an http.Request's RemoteAddr, which is
+// always a host:port pair for a real connection.
+func peerAddr(remoteAddr string) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr, true
+}
+
+// resolveClientIP determines r's true client address. If the immediate peer
+// isn't a trusted proxy, it's the client, full stop -- X-Forwarded-For from
+// an untrusted peer is trivially spoofable and must be ignored. Otherwise it
+// walks X-Forwarded-For right-to-left, the order proxies prepend entries in,
+// skipping over any entry that is itself a trusted proxy, and returns the
+// first one that isn't.
+func (p *HTTPSSEProxy) resolveClientIP(r *http.Request) (netip.Addr, bool) {
+	peer, ok := peerAddr(r.RemoteAddr)
+	if !ok || !p.isTrustedProxy(peer) || !p.trustsHeader("X-Forwarded-For") {
+		return peer, ok
+	}
+
+	xff := r.Header.Get("X-Forwarded-For")
+	if xff == "" {
+		return peer, true
+	}
+
+	entries := strings.Split(xff, ",")
+	client := peer
+	for i := len(entries) - 1; i >= 0; i-- {
+		candidate, err := netip.ParseAddr(strings.TrimSpace(entries[i]))
+		if err != nil {
+			break
+		}
+		client = candidate
+		if !p.isTrustedProxy(candidate) {
+			break
+		}
+	}
+	return client, true
+}
+
+// applyForwarded applies the leftmost (client-facing) entry of an RFC 7239
+// Forwarded header's proto and host parameters to scheme and host.
+func applyForwarded(value string, scheme, host *string) {
+	first := strings.Split(value, ",")[0]
+	for _, part := range strings.Split(first, ";") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		val := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "proto":
+			*scheme = val
+		case "host":
+			*host = val
+		}
 	}
 }
 
@@ -93,8 +427,11 @@ func applyMiddlewares(handler http.Handler, middlewares ...types.Middleware) htt
 }
 
 // Start starts the HTTP SSE proxy.
-func (p *HTTPSSEProxy) Start(_ context.Context) error {
-	// Create a new HTTP server
+// Handler returns the proxy's HTTP handler (its endpoints with middlewares
+// applied, plus a health check) without starting a server, so a caller that
+// wants to serve several proxies behind one listener — see
+// pkg/transport/proxy/auto — can mount it alongside others on its own mux.
+func (p *HTTPSSEProxy) Handler() http.Handler {
 	mux := http.NewServeMux()
 
 	// Add handlers for SSE and JSON-RPC with middlewares
@@ -109,10 +446,17 @@ func (p *HTTPSSEProxy) Start(_ context.Context) error {
 		}
 	})
 
+	// Add a Prometheus-style metrics endpoint (no middlewares)
+	mux.HandleFunc("/metrics", p.handleMetrics)
+
+	return mux
+}
+
+func (p *HTTPSSEProxy) Start(_ context.Context) error {
 	// Create the server
 	p.server = &http.Server{
 		Addr:              fmt.Sprintf(":%d", p.port),
-		Handler:           mux,
+		Handler:           p.Handler(),
 		ReadHeaderTimeout: 10 * time.Second, // Prevent Slowloris attacks
 	}
 
@@ -130,16 +474,46 @@ func (p *HTTPSSEProxy) Start(_ context.Context) error {
 	return nil
 }
 
-// Stop stops the HTTP SSE proxy.
+// Stop stops the HTTP SSE proxy. It signals shutdown so new POSTs are
+// rejected and every connected SSE client gets a final "shutdown" frame,
+// then waits (bounded by ctx) for those in-flight handlers to return before
+// closing messageCh/responseCh.
 func (p *HTTPSSEProxy) Stop(ctx context.Context) error {
-	// Signal shutdown
+	// Signal shutdown: handlePostRequest starts rejecting new work, and each
+	// SSE client's message loop notices on its next iteration.
 	close(p.shutdownCh)
 
-	// Stop the HTTP server
+	// Stop the HTTP server from accepting new connections.
 	if p.server != nil {
-		return p.server.Shutdown(ctx)
+		if err := p.server.Shutdown(ctx); err != nil {
+			return err
+		}
 	}
 
+	// Wait for every in-flight client goroutine and POST handler to notice
+	// shutdownCh and return, bounded by ctx's deadline so one stuck handler
+	// can't hang Stop forever.
+	wgDone := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(wgDone)
+	}()
+	select {
+	case <-wgDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.messageChMu.Lock()
+	p.messageChClosed = true
+	close(p.messageCh)
+	p.messageChMu.Unlock()
+
+	p.responseChMu.Lock()
+	p.responseChClosed = true
+	close(p.responseCh)
+	p.responseChMu.Unlock()
+
 	return nil
 }
 
@@ -153,31 +527,56 @@ func (p *HTTPSSEProxy) GetResponseChannel() <-chan jsonrpc2.Message {
 	return p.responseCh
 }
 
+// ClientCount returns the number of SSE clients currently connected.
+func (p *HTTPSSEProxy) ClientCount() int {
+	p.sseClientsMutex.Lock()
+	defer p.sseClientsMutex.Unlock()
+	return len(p.sseClients)
+}
+
 // SendMessageToDestination sends a message to the destination via the message channel.
 func (p *HTTPSSEProxy) SendMessageToDestination(msg jsonrpc2.Message) error {
+	p.messageChMu.Lock()
+	defer p.messageChMu.Unlock()
+
+	if p.messageChClosed {
+		return fmt.Errorf("proxy is shutting down")
+	}
+
 	select {
 	case p.messageCh <- msg:
 		// Message sent successfully
 		return nil
 	default:
-		// Channel is full or closed
+		// Channel is full
 		return fmt.Errorf("failed to send message to destination")
 	}
 }
 
 // SendResponseMessage sends a message to the response channel.
 func (p *HTTPSSEProxy) SendResponseMessage(msg jsonrpc2.Message) error {
+	p.responseChMu.Lock()
+	defer p.responseChMu.Unlock()
+
+	if p.responseChClosed {
+		return fmt.Errorf("proxy is shutting down")
+	}
+
 	select {
 	case p.responseCh <- msg:
 		// Message sent successfully
 		return nil
 	default:
-		// Channel is full or closed
+		// Channel is full
 		return fmt.Errorf("failed to send message to response channel")
 	}
 }
 
-// ForwardResponseToClients forwards a response from the destination to all connected SSE clients.
+// ForwardResponseToClients forwards a message from the destination to the
+// client it belongs to: a response to a JSON-RPC call is routed only to the
+// client that sent that call, identified via requestOwner, while a
+// server-initiated notification (which names no client) is broadcast to
+// every connected client as before.
 func (p *HTTPSSEProxy) ForwardResponseToClients(_ context.Context, msg jsonrpc2.Message) error {
 	// Serialize the message to JSON
 	data, err := jsonrpc2.EncodeMessage(msg)
@@ -188,6 +587,10 @@ func (p *HTTPSSEProxy) ForwardResponseToClients(_ context.Context, msg jsonrpc2.
 	// Create an SSE message
 	sseMsg := ssecommon.NewSSEMessage("message", string(data))
 
+	if resp, ok := msg.(*jsonrpc2.Response); ok {
+		return p.forwardToOwner(resp.ID().String(), sseMsg)
+	}
+
 	// Check if there are any connected clients
 	p.sseClientsMutex.Lock()
 	hasClients := len(p.sseClients) > 0
@@ -198,28 +601,112 @@ func (p *HTTPSSEProxy) ForwardResponseToClients(_ context.Context, msg jsonrpc2.
 		return p.sendSSEEvent(sseMsg)
 	}
 
-	// Queue the message for later delivery
-	p.pendingMutex.Lock()
-	p.pendingMessages = append(p.pendingMessages, ssecommon.NewPendingSSEMessage(sseMsg))
-	p.pendingMutex.Unlock()
+	// Queue the message for later delivery. It names no owning client, so
+	// any client that connects next should receive it.
+	p.queuePending(pendingBroadcastKey, sseMsg)
 
 	return nil
 }
 
+// forwardToOwner delivers sseMsg to the single client that owns requestID,
+// falling back to queuing it as a pending message (the same fallback
+// ForwardResponseToClients used before per-client routing existed) if that
+// client has since disconnected or was never recorded. The queued message is
+// tagged with clientID so a later-connecting, unrelated client never
+// receives a response meant for a different session; if requestID's owner
+// was never recorded at all, there's no session to tag it with, so it falls
+// back to the broadcast queue like a notification would.
+func (p *HTTPSSEProxy) forwardToOwner(requestID string, sseMsg *ssecommon.SSEMessage) error {
+	p.requestOwnerMu.Lock()
+	clientID, ok := p.requestOwner[requestID]
+	if ok {
+		delete(p.requestOwner, requestID)
+	}
+	p.requestOwnerMu.Unlock()
+
+	if ok {
+		p.sseClientsMutex.Lock()
+		client, connected := p.sseClients[clientID]
+		p.sseClientsMutex.Unlock()
+
+		if connected {
+			evt := p.backlogFor(clientID).record(sseMsg.ToSSEString())
+			select {
+			case client.MessageCh <- formatEvent(evt):
+				return nil
+			default:
+				logger.Log.Warn(fmt.Sprintf("Warning: client %s channel full, dropping response", clientID))
+				return nil
+			}
+		}
+	}
+
+	key := clientID
+	if !ok {
+		key = pendingBroadcastKey
+	}
+	p.queuePending(key, sseMsg)
+
+	return nil
+}
+
+// queuePending appends sseMsg to the pending queue for key, a session/client
+// ID or pendingBroadcastKey.
+func (p *HTTPSSEProxy) queuePending(key string, sseMsg *ssecommon.SSEMessage) {
+	p.pendingMutex.Lock()
+	defer p.pendingMutex.Unlock()
+	p.pendingMessages[key] = append(p.pendingMessages[key], ssecommon.NewPendingSSEMessage(sseMsg))
+}
+
+// forgetRequestsFor drops any recorded request ownership for a client that
+// has disconnected, so a response to a call it'll never read falls back to
+// the pending-message queue instead of being silently discarded by a lookup
+// that resolves to a client no longer in sseClients.
+func (p *HTTPSSEProxy) forgetRequestsFor(clientID string) {
+	p.requestOwnerMu.Lock()
+	defer p.requestOwnerMu.Unlock()
+	for reqID, owner := range p.requestOwner {
+		if owner == clientID {
+			delete(p.requestOwner, reqID)
+		}
+	}
+}
+
 // handleSSEConnection handles an SSE connection.
 func (p *HTTPSSEProxy) handleSSEConnection(w http.ResponseWriter, r *http.Request) {
+	p.wg.Add(1)
+	defer p.wg.Done()
+
+	select {
+	case <-p.shutdownCh:
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
 	// Set headers for SSE
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 
-	// Create a unique client ID
-	clientID := uuid.New().String()
+	// Reconnecting clients pass back the session_id they were given on their
+	// endpoint event, so they resume the same session's backlog instead of
+	// starting a fresh one with no history to replay from.
+	clientID := r.URL.Query().Get("session_id")
+	if clientID == "" {
+		clientID = uuid.New().String()
+	}
 
 	// Create a channel for sending messages to this client
 	messageCh := make(chan string, 100)
 
+	// Resolve the true client IP (honoring X-Forwarded-For only from a
+	// trusted peer) and expose it to anything deriving its context from this
+	// request, such as auth or rate-limiting middlewares.
+	clientIP, _ := p.resolveClientIP(r)
+	r = r.WithContext(context.WithValue(r.Context(), clientIPContextKey{}, clientIP))
+
 	// Register the client
 	p.sseClientsMutex.Lock()
 	p.sseClients[clientID] = &ssecommon.SSEClient{
@@ -227,10 +714,17 @@ func (p *HTTPSSEProxy) handleSSEConnection(w http.ResponseWriter, r *http.Reques
 		CreatedAt: time.Now(),
 	}
 	p.sseClientsMutex.Unlock()
+	logger.Log.Info(fmt.Sprintf("Client %s connected from %s", clientID, clientIP))
 
 	// Process any pending messages for this client
 	p.processPendingMessages(clientID, messageCh)
 
+	// Replay whatever this session's backlog buffered after the client's
+	// Last-Event-ID, so a brief disconnect doesn't lose messages sent while
+	// it was gone.
+	lastEventID := parseLastEventID(r)
+	replay := p.backlogFor(clientID).eventsAfter(lastEventID)
+
 	// Create a flusher for SSE
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -244,8 +738,25 @@ func (p *HTTPSSEProxy) handleSSEConnection(w http.ResponseWriter, r *http.Reques
 	if r.TLS != nil {
 		scheme = "https"
 	}
-	if forwardedProto := r.Header.Get("X-Forwarded-Proto"); forwardedProto != "" {
-		scheme = forwardedProto
+
+	// Only let a trusted proxy override scheme/host: from anyone else these
+	// headers are just attacker-controlled input.
+	if peer, ok := peerAddr(r.RemoteAddr); ok && p.isTrustedProxy(peer) {
+		if p.trustsHeader("X-Forwarded-Proto") {
+			if forwardedProto := r.Header.Get("X-Forwarded-Proto"); forwardedProto != "" {
+				scheme = forwardedProto
+			}
+		}
+		if p.trustsHeader("X-Forwarded-Host") {
+			if forwardedHost := r.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
+				host = forwardedHost
+			}
+		}
+		if p.trustsHeader("Forwarded") {
+			if forwarded := r.Header.Get("Forwarded"); forwarded != "" {
+				applyForwarded(forwarded, &scheme, &host)
+			}
+		}
 	}
 
 	baseURL := fmt.Sprintf("%s://%s", scheme, host)
@@ -258,6 +769,11 @@ func (p *HTTPSSEProxy) handleSSEConnection(w http.ResponseWriter, r *http.Reques
 	fmt.Fprint(w, endpointMsg.ToSSEString())
 	flusher.Flush()
 
+	for _, evt := range replay {
+		fmt.Fprint(w, formatEvent(evt))
+		flusher.Flush()
+	}
+
 	// Create a context that is canceled when the client disconnects
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
@@ -268,15 +784,28 @@ func (p *HTTPSSEProxy) handleSSEConnection(w http.ResponseWriter, r *http.Reques
 		p.sseClientsMutex.Lock()
 		delete(p.sseClients, clientID)
 		p.sseClientsMutex.Unlock()
+		p.forgetRequestsFor(clientID)
 		close(messageCh)
-		logger.Log.Info(fmt.Sprintf("Client %s disconnected", clientID))
+		logger.Log.Info(fmt.Sprintf("Client %s (%s) disconnected", clientID, clientIP))
 	}()
 
+	// A periodic comment line keeps idle NAT/proxy hops from timing out the
+	// connection between real events.
+	heartbeat := time.NewTicker(p.heartbeatInterval)
+	defer heartbeat.Stop()
+
 	// Send messages to the client
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-p.shutdownCh:
+			fmt.Fprintf(w, "event: shutdown\nretry: %d\n\n", sseShutdownRetryMillis)
+			flusher.Flush()
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
 		case msg, ok := <-messageCh:
 			if !ok {
 				return
@@ -289,6 +818,16 @@ func (p *HTTPSSEProxy) handleSSEConnection(w http.ResponseWriter, r *http.Reques
 
 // handlePostRequest handles a POST request with a JSON-RPC message.
 func (p *HTTPSSEProxy) handlePostRequest(w http.ResponseWriter, r *http.Request) {
+	p.wg.Add(1)
+	defer p.wg.Done()
+
+	select {
+	case <-p.shutdownCh:
+		http.Error(w, "Server is shutting down", http.StatusServiceUnavailable)
+		return
+	default:
+	}
+
 	// Only accept POST requests
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -330,6 +869,14 @@ func (p *HTTPSSEProxy) handlePostRequest(w http.ResponseWriter, r *http.Request)
 	// Log the message
 	logger.Log.Info(fmt.Sprintf("Received JSON-RPC message: %T", msg))
 
+	// Record which client this call belongs to so its response can be
+	// routed back here instead of broadcast to every connected client.
+	if call, ok := msg.(*jsonrpc2.Call); ok {
+		p.requestOwnerMu.Lock()
+		p.requestOwner[call.ID().String()] = sessionID
+		p.requestOwnerMu.Unlock()
+	}
+
 	// Send the message to the destination
 	if err := p.SendMessageToDestination(msg); err != nil {
 		http.Error(w, "Failed to send message to destination", http.StatusInternalServerError)
@@ -343,55 +890,88 @@ func (p *HTTPSSEProxy) handlePostRequest(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// sendSSEEvent sends an SSE event to all connected clients.
+// sendSSEEvent sends an SSE event to all connected clients, giving each one
+// up to writeTimeout to drain its channel before it's evicted as stalled --
+// a momentary burst shouldn't cost a client its session.
 func (p *HTTPSSEProxy) sendSSEEvent(msg *ssecommon.SSEMessage) error {
 	// Convert the message to an SSE-formatted string
 	sseString := msg.ToSSEString()
 
-	// Send to all clients
+	// Snapshot the client map instead of holding sseClientsMutex across the
+	// sends below: the same mutex guards handleSSEConnection's register/
+	// deregister, handlePostRequest, and ClientCount, so blocking in here for
+	// up to writeTimeout per slow client would stall all of those too,
+	// turning one stalled client into a stall for everyone.
 	p.sseClientsMutex.Lock()
-	defer p.sseClientsMutex.Unlock()
-
+	clients := make(map[string]*ssecommon.SSEClient, len(p.sseClients))
 	for clientID, client := range p.sseClients {
+		clients[clientID] = client
+	}
+	p.sseClientsMutex.Unlock()
+
+	for clientID, client := range clients {
+		evt := p.backlogFor(clientID).record(sseString)
 		select {
-		case client.MessageCh <- sseString:
-			// Message sent successfully
-		default:
-			// Channel is full or closed, remove the client
-			delete(p.sseClients, clientID)
-			close(client.MessageCh)
-			logger.Log.Info(fmt.Sprintf("Client %s removed (channel full or closed)", clientID))
+		case client.MessageCh <- formatEvent(evt):
+			p.recordEventSent()
+		case <-time.After(p.writeTimeout):
+			// Client hasn't drained its channel within writeTimeout, remove it.
+			// Re-check it's still the same client before deleting/closing, in
+			// case it disconnected and a new one reused the same clientID
+			// while we were waiting.
+			p.sseClientsMutex.Lock()
+			if current, ok := p.sseClients[clientID]; ok && current == client {
+				delete(p.sseClients, clientID)
+				close(client.MessageCh)
+			}
+			p.sseClientsMutex.Unlock()
+			p.recordEviction("write_timeout")
+			logger.Log.Info(fmt.Sprintf("Client %s removed (did not read within %s)", clientID, p.writeTimeout))
 		}
 	}
 
 	return nil
 }
 
-// processPendingMessages processes any pending messages for a new client.
+// processPendingMessages delivers clientID's own queued messages (responses
+// to calls it made before disconnecting) plus any queued broadcast
+// notifications to the newly (re)connected client. A different client's
+// queued messages are left untouched -- they're only ever delivered to the
+// session that owns them.
 func (p *HTTPSSEProxy) processPendingMessages(clientID string, messageCh chan<- string) {
 	p.pendingMutex.Lock()
 	defer p.pendingMutex.Unlock()
 
-	if len(p.pendingMessages) == 0 {
+	owned := p.pendingMessages[clientID]
+	var broadcast []*ssecommon.PendingSSEMessage
+	if clientID != pendingBroadcastKey {
+		broadcast = p.pendingMessages[pendingBroadcastKey]
+	}
+	if len(owned) == 0 && len(broadcast) == 0 {
 		return
 	}
 
-	// Find messages for this client (all messages for now)
-	for _, pendingMsg := range p.pendingMessages {
-		// Convert to SSE string
-		sseString := pendingMsg.Message.ToSSEString()
-
-		// Send to the client
-		select {
-		case messageCh <- sseString:
-			// Message sent successfully
-		default:
-			// Channel is full, stop sending
-			logger.Log.Error(fmt.Sprintf("Failed to send pending message to client %s (channel full)", clientID))
-			return
+	deliver := func(pendingMsgs []*ssecommon.PendingSSEMessage) bool {
+		for _, pendingMsg := range pendingMsgs {
+			sseString := pendingMsg.Message.ToSSEString()
+			evt := p.backlogFor(clientID).record(sseString)
+
+			select {
+			case messageCh <- formatEvent(evt):
+				// Message sent successfully
+			default:
+				// Channel is full, stop sending
+				logger.Log.Error(fmt.Sprintf("Failed to send pending message to client %s (channel full)", clientID))
+				return false
+			}
 		}
+		return true
 	}
 
-	// Clear the pending messages
-	p.pendingMessages = nil
+	if deliver(owned) {
+		delete(p.pendingMessages, clientID)
+	}
+	if len(broadcast) > 0 && deliver(broadcast) {
+		delete(p.pendingMessages, pendingBroadcastKey)
+	}
 }
@@ -0,0 +1,182 @@
+package httpsse
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/StacklokLabs/toolhive/pkg/transport/ssecommon"
+)
+
+// TestProcessPendingMessagesSessionIsolation exercises the interleaved-session
+// scenario the pending-message queue exists to guard against: client A posts
+// a call and disconnects before its response arrives, client B connects in
+// the meantime, and B must not receive A's response -- only A should, on its
+// own reconnect.
+func TestProcessPendingMessagesSessionIsolation(t *testing.T) {
+	t.Parallel()
+
+	p := NewHTTPSSEProxy(0, "test")
+
+	const reqA, reqB = "request-a", "request-b"
+	const clientA, clientB = "client-a", "client-b"
+	p.requestOwner[reqA] = clientA
+	p.requestOwner[reqB] = clientB
+
+	require.NoError(t, p.forwardToOwner(reqA, ssecommon.NewSSEMessage("message", "response-for-a")))
+	require.NoError(t, p.forwardToOwner(reqB, ssecommon.NewSSEMessage("message", "response-for-b")))
+
+	// B connects first. It must only see its own response, never A's.
+	chB := make(chan string, 10)
+	p.processPendingMessages(clientB, chB)
+	close(chB)
+	var gotB []string
+	for msg := range chB {
+		gotB = append(gotB, msg)
+	}
+	require.Len(t, gotB, 1)
+	assert.Contains(t, gotB[0], "response-for-b")
+
+	// A connects afterwards and gets exactly its own queued response.
+	chA := make(chan string, 10)
+	p.processPendingMessages(clientA, chA)
+	close(chA)
+	var gotA []string
+	for msg := range chA {
+		gotA = append(gotA, msg)
+	}
+	require.Len(t, gotA, 1)
+	assert.Contains(t, gotA[0], "response-for-a")
+
+	// Both queues are drained, so a third connection as either client sees
+	// nothing further.
+	chAAgain := make(chan string, 10)
+	p.processPendingMessages(clientA, chAAgain)
+	close(chAAgain)
+	assert.Empty(t, chAAgain)
+}
+
+// TestProcessPendingMessagesBroadcastReachesAnyClient verifies that a
+// pending message with no recorded owner (e.g. a server-initiated
+// notification queued while no client was connected) still reaches whichever
+// client connects next, unlike a per-session response.
+func TestProcessPendingMessagesBroadcastReachesAnyClient(t *testing.T) {
+	t.Parallel()
+
+	p := NewHTTPSSEProxy(0, "test")
+	p.queuePending(pendingBroadcastKey, ssecommon.NewSSEMessage("message", "broadcast-notification"))
+
+	ch := make(chan string, 10)
+	p.processPendingMessages("whichever-client-connects-first", ch)
+	close(ch)
+
+	var got []string
+	for msg := range ch {
+		got = append(got, msg)
+	}
+	require.Len(t, got, 1)
+	assert.Contains(t, got[0], "broadcast-notification")
+}
+
+// TestForwardToOwnerUnrecordedRequestFallsBackToBroadcast confirms that a
+// response to a request ID with no recorded owner (the owning client
+// disconnected and forgetRequestsFor already cleared it, or it was never
+// recorded) doesn't vanish: it falls back to the broadcast queue rather than
+// being silently dropped.
+func TestForwardToOwnerUnrecordedRequestFallsBackToBroadcast(t *testing.T) {
+	t.Parallel()
+
+	p := NewHTTPSSEProxy(0, "test")
+	require.NoError(t, p.forwardToOwner("unknown-request", ssecommon.NewSSEMessage("message", "orphaned-response")))
+
+	ch := make(chan string, 10)
+	p.processPendingMessages("any-client", ch)
+	close(ch)
+
+	var got []string
+	for msg := range ch {
+		got = append(got, msg)
+	}
+	require.Len(t, got, 1)
+	assert.Contains(t, got[0], "orphaned-response")
+}
+
+// TestSSEBacklogEventsAfterReplaysOnlyWhatsMissing verifies the core
+// Last-Event-ID resume contract: eventsAfter returns exactly the events
+// recorded after lastEventID, in order, regardless of how many were
+// recorded before it.
+func TestSSEBacklogEventsAfterReplaysOnlyWhatsMissing(t *testing.T) {
+	t.Parallel()
+
+	b := &sseBacklog{}
+	var recorded []sseEvent
+	for i := 0; i < 5; i++ {
+		recorded = append(recorded, b.record(string(rune('a'+i))))
+	}
+
+	replay := b.eventsAfter(recorded[1].id)
+	require.Len(t, replay, 3)
+	assert.Equal(t, recorded[2:], replay)
+
+	// A lastEventID of 0 (no prior connection) replays everything buffered.
+	assert.Equal(t, recorded, b.eventsAfter(0))
+
+	// A lastEventID at or past the newest event replays nothing.
+	assert.Empty(t, b.eventsAfter(recorded[len(recorded)-1].id))
+}
+
+// TestSSEBacklogResumeAfterSimulatedDisconnect exercises the scenario the
+// resume buffer exists for: a client dies mid-stream after having seen some
+// events but not others still in flight, then reconnects with the
+// Last-Event-ID of the last event it actually saw. No message sent while it
+// was gone -- drained into its (now-abandoned) channel or not -- should be
+// lost on reconnect.
+func TestSSEBacklogResumeAfterSimulatedDisconnect(t *testing.T) {
+	t.Parallel()
+
+	p := NewHTTPSSEProxy(0, "test")
+	const clientID = "client-under-test"
+
+	messageCh := make(chan string, 100)
+	p.sseClientsMutex.Lock()
+	p.sseClients[clientID] = &ssecommon.SSEClient{MessageCh: messageCh, CreatedAt: time.Now()}
+	p.sseClientsMutex.Unlock()
+
+	// The client receives and acknowledges (reads) the first event...
+	require.NoError(t, p.sendSSEEvent(ssecommon.NewSSEMessage("message", "event-1")))
+	lastSeen := <-messageCh
+
+	// ...then dies without reading the next two events, which still land in
+	// its channel and the session's backlog.
+	require.NoError(t, p.sendSSEEvent(ssecommon.NewSSEMessage("message", "event-2")))
+	require.NoError(t, p.sendSSEEvent(ssecommon.NewSSEMessage("message", "event-3")))
+
+	p.sseClientsMutex.Lock()
+	delete(p.sseClients, clientID)
+	p.sseClientsMutex.Unlock()
+	close(messageCh)
+
+	// Reconnect: the client replays from the backlog starting after the ID
+	// embedded in the last event it actually saw.
+	seenID := eventIDFromFormatted(t, lastSeen)
+	replay := p.backlogFor(clientID).eventsAfter(seenID)
+
+	require.Len(t, replay, 2)
+	assert.Contains(t, replay[0].data, "event-2")
+	assert.Contains(t, replay[1].data, "event-3")
+}
+
+// eventIDFromFormatted extracts the numeric id a formatEvent-rendered SSE
+// frame was tagged with, the same id: line a real client tracks and sends
+// back as Last-Event-ID on reconnect.
+func eventIDFromFormatted(t *testing.T, formatted string) uint64 {
+	t.Helper()
+
+	var id uint64
+	_, err := fmt.Sscanf(formatted, "id: %d\n", &id)
+	require.NoError(t, err)
+	return id
+}
@@ -0,0 +1,438 @@
+// Package streamablehttp provides an HTTP proxy implementation for the
+// streamable HTTP transport used in communication between the client and MCP
+// server.
+package streamablehttp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/exp/jsonrpc2"
+
+	"github.com/StacklokLabs/toolhive/pkg/logger"
+	"github.com/StacklokLabs/toolhive/pkg/transport/types"
+)
+
+// HTTPStreamableEndpoint is the single endpoint the streamable HTTP
+// transport listens on for JSON-RPC requests.
+const HTTPStreamableEndpoint = "/mcp"
+
+// SessionIDHeader is the header used to carry session affinity between the
+// client and the proxy, minted on the first request and echoed back on
+// every subsequent one so a reconnect resumes the same in-flight stream.
+const SessionIDHeader = "Mcp-Session-Id"
+
+// resumeBufferSize bounds how many past events a session remembers for
+// Last-Event-ID replay on reconnect.
+const resumeBufferSize = 100
+
+// streamEvent is a single buffered/broadcast message tagged with the
+// monotonically increasing event ID used for Last-Event-ID resumption.
+type streamEvent struct {
+	id  uint64
+	msg jsonrpc2.Message
+}
+
+// session tracks the live connections (if any) subscribed to notifications
+// and responses for one Mcp-Session-Id, plus a short backlog of recent
+// events so a reconnecting client with a Last-Event-ID can catch up on
+// whatever it missed before transitioning to live streaming.
+type session struct {
+	mu      sync.Mutex
+	streams map[string]chan streamEvent
+	nextID  uint64
+	backlog []streamEvent
+}
+
+func newSession() *session {
+	return &session{streams: make(map[string]chan streamEvent)}
+}
+
+// subscribeFrom registers a new live stream and, under the same lock,
+// snapshots every buffered event after lastEventID so the caller can replay
+// it before switching to the live channel without racing a concurrent
+// broadcast into either missing or duplicating an event.
+func (s *session) subscribeFrom(lastEventID uint64) (string, chan streamEvent, []streamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var replay []streamEvent
+	for _, evt := range s.backlog {
+		if evt.id > lastEventID {
+			replay = append(replay, evt)
+		}
+	}
+
+	id := uuid.New().String()
+	ch := make(chan streamEvent, 100)
+	s.streams[id] = ch
+	return id, ch, replay
+}
+
+func (s *session) unsubscribe(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch, ok := s.streams[id]; ok {
+		delete(s.streams, id)
+		close(ch)
+	}
+}
+
+func (s *session) broadcast(msg jsonrpc2.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	evt := streamEvent{id: s.nextID, msg: msg}
+
+	s.backlog = append(s.backlog, evt)
+	if len(s.backlog) > resumeBufferSize {
+		s.backlog = s.backlog[len(s.backlog)-resumeBufferSize:]
+	}
+
+	for id, ch := range s.streams {
+		select {
+		case ch <- evt:
+		default:
+			logger.Log.Warn(fmt.Sprintf("Warning: stream %s backlogged, dropping message", id))
+		}
+	}
+}
+
+// StreamableHTTPProxy implements the streamable HTTP transport: a single HTTP
+// endpoint that accepts POSTed JSON-RPC requests and either returns a plain
+// JSON response or, when the client sends Accept: text/event-stream, upgrades
+// the response to a stream of responses and server-initiated notifications.
+//
+//nolint:revive // Intentionally named StreamableHTTPProxy despite package name
+type StreamableHTTPProxy struct {
+	port          int
+	containerName string
+	middlewares   []types.Middleware
+
+	server     *http.Server
+	shutdownCh chan struct{}
+
+	sessionsMu sync.Mutex
+	sessions   map[string]*session
+
+	messageCh  chan jsonrpc2.Message
+	responseCh chan jsonrpc2.Message
+}
+
+// NewStreamableHTTPProxy creates a new streamable HTTP proxy for transports.
+func NewStreamableHTTPProxy(port int, containerName string, middlewares ...types.Middleware) *StreamableHTTPProxy {
+	return &StreamableHTTPProxy{
+		port:          port,
+		containerName: containerName,
+		middlewares:   middlewares,
+		shutdownCh:    make(chan struct{}),
+		sessions:      make(map[string]*session),
+		messageCh:     make(chan jsonrpc2.Message, 100),
+		responseCh:    make(chan jsonrpc2.Message, 100),
+	}
+}
+
+// applyMiddlewares applies a chain of middlewares to a handler
+func applyMiddlewares(handler http.Handler, middlewares ...types.Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Handler returns the proxy's HTTP handler (its endpoint with middlewares
+// applied, plus a health check) without starting a server, so a caller that
+// wants to serve several proxies behind one listener — see
+// pkg/transport/proxy/auto — can mount it alongside others on its own mux.
+func (p *StreamableHTTPProxy) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(HTTPStreamableEndpoint, applyMiddlewares(http.HandlerFunc(p.handleRequest), p.middlewares...))
+
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte("OK")); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: Failed to write health check response: %v", err))
+		}
+	})
+
+	return mux
+}
+
+// Start starts the streamable HTTP proxy.
+func (p *StreamableHTTPProxy) Start(_ context.Context) error {
+	p.server = &http.Server{
+		Addr:              fmt.Sprintf(":%d", p.port),
+		Handler:           p.Handler(),
+		ReadHeaderTimeout: 10 * time.Second, // Prevent Slowloris attacks
+	}
+
+	go func() {
+		logger.Log.Info(fmt.Sprintf("Streamable HTTP proxy started for container %s on port %d", p.containerName, p.port))
+		logger.Log.Info(fmt.Sprintf("MCP endpoint: http://localhost:%d%s", p.port, HTTPStreamableEndpoint))
+
+		if err := p.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Log.Error(fmt.Sprintf("HTTP server error: %v", err))
+		}
+	}()
+
+	// Fan out every response/notification coming back from the destination to
+	// every live session stream.
+	go p.pumpResponses()
+
+	return nil
+}
+
+// Stop stops the streamable HTTP proxy.
+func (p *StreamableHTTPProxy) Stop(ctx context.Context) error {
+	close(p.shutdownCh)
+
+	if p.server != nil {
+		return p.server.Shutdown(ctx)
+	}
+
+	return nil
+}
+
+// GetMessageChannel returns the channel for messages to/from the destination.
+func (p *StreamableHTTPProxy) GetMessageChannel() chan jsonrpc2.Message {
+	return p.messageCh
+}
+
+// GetResponseChannel returns the channel for receiving messages from the destination.
+func (p *StreamableHTTPProxy) GetResponseChannel() <-chan jsonrpc2.Message {
+	return p.responseCh
+}
+
+// ClientCount returns the number of sessions currently connected.
+func (p *StreamableHTTPProxy) ClientCount() int {
+	p.sessionsMu.Lock()
+	defer p.sessionsMu.Unlock()
+	return len(p.sessions)
+}
+
+// SendMessageToDestination sends a message to the destination via the message channel.
+func (p *StreamableHTTPProxy) SendMessageToDestination(msg jsonrpc2.Message) error {
+	select {
+	case p.messageCh <- msg:
+		return nil
+	default:
+		return fmt.Errorf("failed to send message to destination")
+	}
+}
+
+// SendResponseMessage sends a message to the response channel.
+func (p *StreamableHTTPProxy) SendResponseMessage(msg jsonrpc2.Message) error {
+	select {
+	case p.responseCh <- msg:
+		return nil
+	default:
+		return fmt.Errorf("failed to send message to response channel")
+	}
+}
+
+// ForwardResponseToClients forwards a response from the destination to every
+// session subscribed to a stream.
+func (p *StreamableHTTPProxy) ForwardResponseToClients(_ context.Context, msg jsonrpc2.Message) error {
+	p.sessionsMu.Lock()
+	sessions := make([]*session, 0, len(p.sessions))
+	for _, s := range p.sessions {
+		sessions = append(sessions, s)
+	}
+	p.sessionsMu.Unlock()
+
+	for _, s := range sessions {
+		s.broadcast(msg)
+	}
+
+	return nil
+}
+
+// pumpResponses forwards every message read off the response channel to all
+// live session streams, mirroring ForwardResponseToClients.
+func (p *StreamableHTTPProxy) pumpResponses() {
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case msg, ok := <-p.responseCh:
+			if !ok {
+				return
+			}
+			if err := p.ForwardResponseToClients(context.Background(), msg); err != nil {
+				logger.Log.Error(fmt.Sprintf("Error forwarding response to sessions: %v", err))
+			}
+		}
+	}
+}
+
+// sessionFor returns the session for id, creating one if it doesn't exist yet.
+func (p *StreamableHTTPProxy) sessionFor(id string) *session {
+	p.sessionsMu.Lock()
+	defer p.sessionsMu.Unlock()
+
+	s, ok := p.sessions[id]
+	if !ok {
+		s = newSession()
+		p.sessions[id] = s
+	}
+	return s
+}
+
+// handleRequest handles a POST request carrying a JSON-RPC message, either
+// replying with a plain JSON response or upgrading to an SSE stream when the
+// client sends Accept: text/event-stream.
+func (p *StreamableHTTPProxy) handleRequest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionID := r.Header.Get(SessionIDHeader)
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+	w.Header().Set(SessionIDHeader, sessionID)
+	sess := p.sessionFor(sessionID)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error reading request body: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	msg, err := jsonrpc2.DecodeMessage(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error parsing JSON-RPC message: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	logger.Log.Info(fmt.Sprintf("Received JSON-RPC message: %T", msg))
+
+	if wantsEventStream(r) {
+		p.streamResponses(w, r, sess, msg)
+		return
+	}
+
+	p.respondOnce(w, sess, msg)
+}
+
+// wantsEventStream reports whether the client asked for an SSE upgrade.
+func wantsEventStream(r *http.Request) bool {
+	return r.Header.Get("Accept") == "text/event-stream" ||
+		r.Header.Get("Accept") == "application/json, text/event-stream"
+}
+
+// streamResponses upgrades the response to a server-sent event stream of
+// responses and notifications for this session, for the lifetime of the
+// request context. If the client reconnected with a Last-Event-ID header,
+// it first replays whatever events the session buffered after that ID
+// before joining the live stream, so a brief disconnect doesn't lose
+// messages.
+func (p *StreamableHTTPProxy) streamResponses(w http.ResponseWriter, r *http.Request, sess *session, msg jsonrpc2.Message) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	lastEventID := parseLastEventID(r)
+	streamID, streamCh, replay := sess.subscribeFrom(lastEventID)
+	defer sess.unsubscribe(streamID)
+
+	if err := p.SendMessageToDestination(msg); err != nil {
+		http.Error(w, "Failed to send message to destination", http.StatusInternalServerError)
+		return
+	}
+
+	for _, evt := range replay {
+		if !writeEvent(w, flusher, evt) {
+			return
+		}
+	}
+
+	ctx := r.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.shutdownCh:
+			return
+		case evt, ok := <-streamCh:
+			if !ok {
+				return
+			}
+			if !writeEvent(w, flusher, evt) {
+				return
+			}
+		}
+	}
+}
+
+// parseLastEventID reads the Last-Event-ID header used by SSE clients to
+// resume a dropped connection, returning 0 (replay nothing) if absent or
+// unparseable.
+func parseLastEventID(r *http.Request) uint64 {
+	id, err := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// writeEvent encodes and flushes a single SSE event, reporting whether the
+// write succeeded so the caller can stop streaming on failure.
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, evt streamEvent) bool {
+	data, err := jsonrpc2.EncodeMessage(evt.msg)
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Error encoding JSON-RPC message: %v", err))
+		return true
+	}
+	fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", evt.id, data)
+	flusher.Flush()
+	return true
+}
+
+// respondOnce sends msg to the destination and replies with the next message
+// delivered back for this session as a single plain JSON response. This
+// mirrors the HTTP SSE proxy, which likewise doesn't correlate responses to
+// requests by JSON-RPC id.
+func (p *StreamableHTTPProxy) respondOnce(w http.ResponseWriter, sess *session, msg jsonrpc2.Message) {
+	streamID, streamCh, _ := sess.subscribeFrom(0)
+	defer sess.unsubscribe(streamID)
+
+	if err := p.SendMessageToDestination(msg); err != nil {
+		http.Error(w, "Failed to send message to destination", http.StatusInternalServerError)
+		return
+	}
+
+	select {
+	case evt, ok := <-streamCh:
+		if !ok {
+			http.Error(w, "Session closed before a response arrived", http.StatusGatewayTimeout)
+			return
+		}
+		data, err := jsonrpc2.EncodeMessage(evt.msg)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error encoding JSON-RPC message: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(data); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: Failed to write response: %v", err))
+		}
+	case <-time.After(30 * time.Second):
+		http.Error(w, "Timed out waiting for a response", http.StatusGatewayTimeout)
+	}
+}
@@ -1,26 +1,37 @@
 package transport
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
-	"unicode"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/exp/jsonrpc2"
 
 	"github.com/stacklok/toolhive/pkg/container"
 	rt "github.com/stacklok/toolhive/pkg/container/runtime"
 	"github.com/stacklok/toolhive/pkg/logger"
 	"github.com/stacklok/toolhive/pkg/permissions"
+	"github.com/stacklok/toolhive/pkg/transport/diag"
 	"github.com/stacklok/toolhive/pkg/transport/errors"
+	"github.com/stacklok/toolhive/pkg/transport/proxy/auto"
 	"github.com/stacklok/toolhive/pkg/transport/proxy/httpsse"
+	"github.com/stacklok/toolhive/pkg/transport/proxy/streamablehttp"
 	"github.com/stacklok/toolhive/pkg/transport/types"
 )
 
+// clientCountSamplePeriod is how often Start's diagnostic-sampling goroutine
+// refreshes the connected-clients gauge from t.httpProxy.
+const clientCountSamplePeriod = 5 * time.Second
+
 // StdioTransport implements the Transport interface using standard input/output.
 // It acts as a proxy between the MCP client and the container's stdin/stdout.
 type StdioTransport struct {
@@ -38,15 +49,111 @@ type StdioTransport struct {
 	shutdownCh chan struct{}
 	errorCh    <-chan error
 
-	// HTTP SSE proxy
+	// HTTP proxy exposed to MCP clients over the network
 	httpProxy types.Proxy
 
+	// proxyMode selects which HTTP proxy front end Start creates. Defaults
+	// to types.HTTPProxyModeAuto so one server can serve both legacy
+	// HTTP+SSE clients and modern Streamable HTTP clients.
+	proxyMode types.HTTPProxyMode
+
+	// logSink optionally receives a copy of the container's raw stdout, e.g.
+	// a rotating file sink (see pkg/container/logsink). Nil disables it.
+	logSink io.Writer
+
+	// diagAddr, if set, is the address (e.g. ":8081") Start binds a
+	// diagnostic HTTP server to, exposing /healthz, /readyz, /metrics, and
+	// /debug/pprof/*. Empty disables it.
+	diagAddr string
+	// metricsRegistry and metrics are always created (registering them is
+	// cheap and in-memory), so message/byte/restart counters stay up to
+	// date even if no diagnostic server is ever started.
+	metricsRegistry *prometheus.Registry
+	metrics         *diag.Metrics
+	diagServer      *diag.Server
+
 	// Container I/O
 	stdin  io.WriteCloser
 	stdout io.ReadCloser
 
 	// Container monitor
 	monitor rt.Monitor
+
+	// restartPolicy controls whether/how handleContainerExit reattaches to
+	// the container instead of tearing the transport down. The zero value
+	// (MaxRetries 0) disables automatic reattach.
+	restartPolicy RestartPolicy
+	// retryCount is the number of reattach attempts made for the exit
+	// currently being handled, surfaced via RetryCount.
+	retryCount int
+
+	// Container creation parameters, stashed by Setup so attemptReattach can
+	// recreate the container if it was removed rather than merely stopped.
+	image             string
+	cmdArgs           []string
+	envVars           map[string]string
+	labels            map[string]string
+	permissionProfile *permissions.Profile
+}
+
+// RestartPolicy configures whether and how StdioTransport reattaches to its
+// container after the monitor reports it exited, instead of immediately
+// tearing the transport down. This lets the proxy ride out a crash or a
+// restart performed by an external orchestrator without disconnecting
+// clients. The zero value disables automatic reattach.
+type RestartPolicy struct {
+	// MaxRetries is the maximum number of reattach attempts after an exit
+	// before giving up and stopping the transport. 0 disables reattach.
+	MaxRetries int
+	// InitialBackoff is the delay before the first reattach attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between reattach attempts.
+	MaxBackoff time.Duration
+	// Multiplier scales the backoff delay after each failed attempt.
+	Multiplier float64
+}
+
+// SetRestartPolicy configures how handleContainerExit reattaches to the
+// container after it exits. Call this before Start.
+func (t *StdioTransport) SetRestartPolicy(policy RestartPolicy) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.restartPolicy = policy
+}
+
+// RetryCount returns the number of reattach attempts made for the exit
+// currently being handled, or 0 if the container hasn't exited or reattach
+// isn't in progress.
+func (t *StdioTransport) RetryCount() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.retryCount
+}
+
+// SetHTTPProxyMode selects which HTTP proxy front end Start creates. Call
+// this before Start. The zero value behaves like types.HTTPProxyModeAuto.
+func (t *StdioTransport) SetHTTPProxyMode(mode types.HTTPProxyMode) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.proxyMode = mode
+}
+
+// SetLogSink configures a writer that receives a copy of the container's
+// raw stdout, e.g. a rotating file sink from pkg/container/logsink. Call
+// this before Start. Pass nil to disable (the default).
+func (t *StdioTransport) SetLogSink(sink io.Writer) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.logSink = sink
+}
+
+// SetDiagAddr configures the address (e.g. ":8081") Start binds a
+// diagnostic HTTP server to, exposing /healthz, /readyz, /metrics, and
+// /debug/pprof/*. Call this before Start. Pass "" to disable (the default).
+func (t *StdioTransport) SetDiagAddr(addr string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.diagAddr = addr
 }
 
 // NewStdioTransport creates a new stdio transport.
@@ -56,12 +163,16 @@ func NewStdioTransport(
 	debug bool,
 	middlewares ...types.Middleware,
 ) *StdioTransport {
+	registry := prometheus.NewRegistry()
 	return &StdioTransport{
-		port:        port,
-		runtime:     runtime,
-		debug:       debug,
-		middlewares: middlewares,
-		shutdownCh:  make(chan struct{}),
+		port:            port,
+		runtime:         runtime,
+		debug:           debug,
+		middlewares:     middlewares,
+		shutdownCh:      make(chan struct{}),
+		proxyMode:       types.HTTPProxyModeAuto,
+		metricsRegistry: registry,
+		metrics:         diag.NewMetrics(registry),
 	}
 }
 
@@ -94,6 +205,14 @@ func (t *StdioTransport) Setup(
 	// Add transport-specific environment variables
 	envVars["MCP_TRANSPORT"] = "stdio"
 
+	// Stash the creation parameters so attemptReattach can recreate the
+	// container later if it's gone rather than merely stopped.
+	t.image = image
+	t.cmdArgs = cmdArgs
+	t.envVars = envVars
+	t.labels = labels
+	t.permissionProfile = permissionProfile
+
 	// Create container options
 	containerOptions := rt.NewCreateContainerOptions()
 	containerOptions.AttachStdio = true
@@ -138,6 +257,16 @@ func (t *StdioTransport) Start(ctx context.Context) error {
 		return fmt.Errorf("container runtime not set")
 	}
 
+	// Start the diagnostic server first, if configured, so /healthz and
+	// /readyz are reachable (reporting not-ready) for the whole startup
+	// sequence rather than only once everything else is already up.
+	if t.diagAddr != "" {
+		t.diagServer = diag.NewServer(t.diagAddr, t.metricsRegistry)
+		if err := t.diagServer.Start(); err != nil {
+			return fmt.Errorf("failed to start diagnostic server: %w", err)
+		}
+	}
+
 	// Attach to the container
 	var err error
 	t.stdin, t.stdout, err = t.runtime.AttachContainer(ctx, t.containerID)
@@ -145,12 +274,22 @@ func (t *StdioTransport) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to attach to container: %w", err)
 	}
 
-	// Create and start the HTTP SSE proxy with middlewares
-	t.httpProxy = httpsse.NewHTTPSSEProxy(t.port, t.containerName, t.middlewares...)
+	// Create and start the HTTP proxy with middlewares, per the configured
+	// proxy mode (legacy HTTP+SSE, Streamable HTTP, or both at once).
+	switch t.proxyMode {
+	case types.HTTPProxyModeSSE:
+		t.httpProxy = httpsse.NewHTTPSSEProxy(t.port, t.containerName, t.middlewares...)
+	case types.HTTPProxyModeStreamableHTTP:
+		t.httpProxy = streamablehttp.NewStreamableHTTPProxy(t.port, t.containerName, t.middlewares...)
+	case types.HTTPProxyModeAuto:
+		fallthrough
+	default:
+		t.httpProxy = auto.NewProxy(t.port, t.containerName, t.middlewares...)
+	}
 	if err := t.httpProxy.Start(ctx); err != nil {
 		return err
 	}
-	logger.Log.Info("HTTP SSE proxy started, processing messages...")
+	logger.Log.Info(fmt.Sprintf("HTTP proxy started in %s mode, processing messages...", t.proxyMode))
 
 	// Start processing messages in a goroutine
 	go t.processMessages(ctx, t.stdin, t.stdout)
@@ -171,9 +310,42 @@ func (t *StdioTransport) Start(ctx context.Context) error {
 	// Start a goroutine to handle container exit
 	go t.handleContainerExit(ctx)
 
+	// The container monitor is up and the HTTP proxy accepted its listener,
+	// so /readyz can start reporting ready.
+	if t.diagServer != nil {
+		t.diagServer.SetReady(true)
+		go t.sampleClientCount(ctx)
+	}
+
 	return nil
 }
 
+// sampleClientCount periodically refreshes the connected-clients gauge from
+// t.httpProxy until ctx is done or the transport is shut down. Proxies
+// report their own client count on demand rather than pushing changes, so
+// polling is simpler than threading a callback through every connect/
+// disconnect path.
+func (t *StdioTransport) sampleClientCount(ctx context.Context) {
+	counter, ok := t.httpProxy.(types.ClientCounter)
+	if !ok {
+		return
+	}
+
+	ticker := time.NewTicker(clientCountSamplePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.shutdownCh:
+			return
+		case <-ticker.C:
+			t.metrics.ConnectedClients.Set(float64(counter.ClientCount()))
+		}
+	}
+}
+
 // Stop gracefully shuts down the transport and the container.
 func (t *StdioTransport) Stop(ctx context.Context) error {
 	// First check if the transport is already stopped without locking
@@ -201,6 +373,12 @@ func (t *StdioTransport) Stop(ctx context.Context) error {
 		close(t.shutdownCh)
 	}
 
+	// Flip /healthz to unhealthy immediately, before any of the shutdown
+	// steps below that can take time or fail.
+	if t.diagServer != nil {
+		t.diagServer.SetHealthy(false)
+	}
+
 	// Stop the monitor if it's running and we haven't already stopped it
 	if t.monitor != nil {
 		t.monitor.StopMonitoring()
@@ -214,6 +392,14 @@ func (t *StdioTransport) Stop(ctx context.Context) error {
 		}
 	}
 
+	// Stop the diagnostic server
+	if t.diagServer != nil {
+		if err := t.diagServer.Stop(ctx); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: Failed to stop diagnostic server: %v", err))
+		}
+		t.diagServer = nil
+	}
+
 	// Close stdin and stdout if they're open
 	if t.stdin != nil {
 		if err := t.stdin.Close(); err != nil {
@@ -289,153 +475,255 @@ func (t *StdioTransport) processMessages(ctx context.Context, stdin io.WriteClos
 	}
 }
 
-// processStdout reads from the container's stdout and processes JSON-RPC messages.
+// processStdout reads from the container's stdout and forwards decoded
+// JSON-RPC messages to connected clients, using jsonrpcFrameReader to frame
+// messages correctly instead of scanning for the outermost braces.
 func (t *StdioTransport) processStdout(ctx context.Context, stdout io.ReadCloser) {
-	// Create a buffer for accumulating data
-	var buffer bytes.Buffer
+	var r io.Reader = stdout
+	if t.logSink != nil {
+		r = io.TeeReader(stdout, t.logSink)
+	}
+	r = newCountingReader(r, t.metrics.StdoutBytesRead)
 
-	// Create a buffer for reading
-	readBuffer := make([]byte, 4096)
+	frames := newJSONRPCFrameReader(r)
+	frames.onParseFailure = func() { t.metrics.JSONParseFailures.Inc() }
+	frames.onSanitizationFallback = func() { t.metrics.SanitizationFallbacks.Inc() }
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			// Read data from stdout
-			n, err := stdout.Read(readBuffer)
-			if err != nil {
-				if err == io.EOF {
-					logger.Log.Info("Container stdout closed")
-				} else {
-					logger.Log.Error(fmt.Sprintf("Error reading from container stdout: %v", err))
-				}
-				return
-			}
-
-			if n > 0 {
-				// Write the data to the buffer
-				buffer.Write(readBuffer[:n])
+		}
 
-				// Process the buffer
-				t.processBuffer(ctx, &buffer)
+		msg, err := frames.next()
+		if err != nil {
+			if err == io.EOF {
+				logger.Log.Info("Container stdout closed")
+			} else {
+				logger.Log.Error(fmt.Sprintf("Error reading from container stdout: %v", err))
 			}
+			return
 		}
+
+		t.forwardJSONRPCMessage(ctx, msg)
 	}
 }
 
-// processBuffer processes the accumulated data in the buffer.
-func (t *StdioTransport) processBuffer(ctx context.Context, buffer *bytes.Buffer) {
-	// Process complete lines
-	for {
-		line, err := buffer.ReadString('\n')
-		if err == io.EOF {
-			// No complete line found, put the data back in the buffer
-			buffer.WriteString(line)
-			break
-		}
+// forwardJSONRPCMessage forwards a decoded JSON-RPC message to connected
+// clients via the HTTP proxy.
+func (t *StdioTransport) forwardJSONRPCMessage(ctx context.Context, msg jsonrpc2.Message) {
+	logger.Log.Info(fmt.Sprintf("Received JSON-RPC message: %T", msg))
 
-		// Verify if new line character is present as last character
-		// If so, remove it
-		if len(line) > 0 && line[len(line)-1] == '\n' {
-			// Remove the trailing newline
-			line = line[:len(line)-1]
-		}
+	t.metrics.MessagesForwarded.WithLabelValues(string(diag.DirectionToClient)).Inc()
 
-		// Try to parse as JSON-RPC
-		if line != "" {
-			t.parseAndForwardJSONRPC(ctx, line)
-		}
+	if err := t.httpProxy.ForwardResponseToClients(ctx, msg); err != nil {
+		logger.Log.Error(fmt.Sprintf("Error forwarding to clients: %v", err))
+	}
+
+	if err := t.httpProxy.SendResponseMessage(msg); err != nil {
+		logger.Log.Error(fmt.Sprintf("Error sending to response channel: %v", err))
 	}
 }
 
-// sanitizeJSONString extracts the first valid JSON object from a string
-func sanitizeJSONString(input string) string {
-	return sanitizeBinaryString(input)
+// countingReader wraps r, adding the byte count of every successful Read to
+// counter.
+type countingReader struct {
+	r       io.Reader
+	counter prometheus.Counter
 }
 
-// sanitizeBinaryString removes all non-JSON characters and whitespace from a string
-func sanitizeBinaryString(input string) string {
-	// Find the first opening brace
-	startIdx := strings.Index(input, "{")
-	if startIdx == -1 {
-		return "" // No JSON object found
-	}
+// newCountingReader wraps r so every byte read through it is added to counter.
+func newCountingReader(r io.Reader, counter prometheus.Counter) io.Reader {
+	return &countingReader{r: r, counter: counter}
+}
 
-	// Find the last closing brace
-	endIdx := strings.LastIndex(input, "}")
-	if endIdx == -1 || endIdx < startIdx {
-		return "" // No valid JSON object found
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	if n > 0 {
+		c.counter.Add(float64(n))
 	}
+	return n, err
+}
 
-	// Extract just the JSON object, discarding everything else
-	jsonObj := input[startIdx : endIdx+1]
+// jsonrpcFrameReader incrementally decodes JSON-RPC 2.0 messages from a
+// container's stdout, replacing the old scan-for-the-outermost-braces
+// sanitizer that silently corrupted batched messages, embedded braces in
+// strings, and multi-line JSON. It supports both LSP-style
+// Content-Length-framed messages (the convention used by
+// golang.org/x/exp/jsonrpc2's header framer) and newline-delimited JSON,
+// auto-detected from the first non-whitespace bytes of the stream. Batch
+// arrays are split via json.Decoder so each element is forwarded in order,
+// per JSON-RPC 2.0.
+type jsonrpcFrameReader struct {
+	br           *bufio.Reader
+	detected     bool
+	headerFramed bool
+	queue        []jsonrpc2.Message
+
+	// onParseFailure, if set, is called whenever a Content-Length-framed
+	// message fails to decode.
+	onParseFailure func()
+	// onSanitizationFallback, if set, is called whenever a line of
+	// newline-delimited output is skipped for not being valid JSON-RPC.
+	onSanitizationFallback func()
+}
 
-	// Remove all whitespace and control characters
-	var buffer bytes.Buffer
+// newJSONRPCFrameReader wraps r for incremental JSON-RPC decoding.
+func newJSONRPCFrameReader(r io.Reader) *jsonrpcFrameReader {
+	return &jsonrpcFrameReader{br: bufio.NewReader(r)}
+}
 
-	for _, r := range jsonObj {
-		if unicode.IsPrint(r) || isSpace(r) {
-			buffer.WriteRune(r)
+// next returns the next decoded JSON-RPC message, blocking until one is
+// available. It returns the underlying read error (io.EOF once the stream
+// closes) when there's nothing left to decode.
+func (f *jsonrpcFrameReader) next() (jsonrpc2.Message, error) {
+	for len(f.queue) == 0 {
+		if err := f.fill(); err != nil {
+			return nil, err
 		}
 	}
+	msg := f.queue[0]
+	f.queue = f.queue[1:]
+	return msg, nil
+}
 
-	return buffer.String()
+// fill reads and decodes the next frame from the stream, appending any
+// resulting message(s) to the queue.
+func (f *jsonrpcFrameReader) fill() error {
+	if !f.detected {
+		if err := f.detectFraming(); err != nil {
+			return err
+		}
+	}
+	if f.headerFramed {
+		return f.fillHeaderFramed()
+	}
+	return f.fillLineDelimited()
 }
 
-// isSpace reports whether r is a space character as defined by JSON.
-// These are the valid space characters in this implementation:
-//   - ' ' (U+0020, SPACE)
-//   - '\n' (U+000A, LINE FEED)
-func isSpace(r rune) bool {
-	return r == ' ' || r == '\n'
+// detectFraming peeks past any leading whitespace to decide whether the
+// stream uses Content-Length-framed messages or newline-delimited JSON.
+func (f *jsonrpcFrameReader) detectFraming() error {
+	for {
+		b, err := f.br.Peek(1)
+		if err != nil {
+			return err
+		}
+		if b[0] == ' ' || b[0] == '\t' || b[0] == '\r' || b[0] == '\n' {
+			if _, err := f.br.ReadByte(); err != nil {
+				return err
+			}
+			continue
+		}
+		break
+	}
+
+	const probeLen = len("content-length:")
+	probe, _ := f.br.Peek(probeLen)
+	f.headerFramed = strings.EqualFold(string(probe), "content-length:")
+	f.detected = true
+	return nil
 }
 
-// parseAndForwardJSONRPC parses a JSON-RPC message and forwards it.
-func (t *StdioTransport) parseAndForwardJSONRPC(ctx context.Context, line string) {
-	// Log the raw line for debugging
-	logger.Log.Info(fmt.Sprintf("JSON-RPC raw: %s", line))
+// fillHeaderFramed reads one LSP-style Content-Length-framed message.
+func (f *jsonrpcFrameReader) fillHeaderFramed() error {
+	contentLength := -1
+	for {
+		line, err := f.br.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid Content-Length header %q: %w", value, err)
+			}
+			contentLength = n
+		}
+	}
+	if contentLength < 0 {
+		return fmt.Errorf("frame missing Content-Length header")
+	}
 
-	// Check if the line contains binary data
-	hasBinaryData := false
-	for _, c := range line {
-		if !unicode.IsPrint(c) && !isSpace(c) {
-			hasBinaryData = true
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(f.br, body); err != nil {
+		return err
+	}
+	if err := f.decodeFrame(body); err != nil {
+		if f.onParseFailure != nil {
+			f.onParseFailure()
 		}
+		return err
 	}
+	return nil
+}
 
-	// If the line contains binary data, try to sanitize it
-	var jsonData string
-	if hasBinaryData {
-		jsonData = sanitizeJSONString(line)
-		logger.Log.Info(fmt.Sprintf("Sanitized JSON: %s", jsonData))
-	} else {
-		jsonData = line
+// fillLineDelimited reads newline-delimited JSON, logging and skipping any
+// line that isn't a valid JSON-RPC message instead of mangling it into fake
+// JSON.
+func (f *jsonrpcFrameReader) fillLineDelimited() error {
+	for {
+		line, err := f.br.ReadString('\n')
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			if decErr := f.decodeFrame([]byte(trimmed)); decErr == nil {
+				return nil
+			}
+			if f.onSanitizationFallback != nil {
+				f.onSanitizationFallback()
+			}
+			logger.Log.Warn(fmt.Sprintf("Ignoring non-JSON-RPC output on container stdout: %s", trimmed))
+		}
+		if err != nil {
+			return err
+		}
 	}
+}
 
-	// Try to parse the JSON
-	msg, err := jsonrpc2.DecodeMessage([]byte(jsonData))
+// decodeFrame decodes data as either a single JSON-RPC message or a batch
+// array, appending each resulting message to the queue in order.
+func (f *jsonrpcFrameReader) decodeFrame(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
 	if err != nil {
-		logger.Log.Error(fmt.Sprintf("Error parsing JSON-RPC message: %v", err))
-		return
+		return err
 	}
 
-	// Log the message
-	logger.Log.Info(fmt.Sprintf("Received JSON-RPC message: %T", msg))
-
-	// Forward to SSE clients via the HTTP proxy
-	if err := t.httpProxy.ForwardResponseToClients(ctx, msg); err != nil {
-		logger.Log.Error(fmt.Sprintf("Error forwarding to SSE clients: %v", err))
+	delim, isArray := tok.(json.Delim)
+	if !isArray || delim != '[' {
+		msg, err := jsonrpc2.DecodeMessage(data)
+		if err != nil {
+			return err
+		}
+		f.queue = append(f.queue, msg)
+		return nil
 	}
 
-	// Send to the response channel
-	if err := t.httpProxy.SendResponseMessage(msg); err != nil {
-		logger.Log.Error(fmt.Sprintf("Error sending to response channel: %v", err))
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		msg, err := jsonrpc2.DecodeMessage(raw)
+		if err != nil {
+			return err
+		}
+		f.queue = append(f.queue, msg)
 	}
+	_, err = dec.Token() // consume the closing ']'
+	return err
 }
 
 // sendMessageToContainer sends a JSON-RPC message to the container.
-func (*StdioTransport) sendMessageToContainer(_ context.Context, stdin io.Writer, msg jsonrpc2.Message) error {
+func (t *StdioTransport) sendMessageToContainer(_ context.Context, stdin io.Writer, msg jsonrpc2.Message) error {
 	// Serialize the message
 	data, err := jsonrpc2.EncodeMessage(msg)
 	if err != nil {
@@ -452,38 +740,213 @@ func (*StdioTransport) sendMessageToContainer(_ context.Context, stdin io.Writer
 	}
 	logger.Log.Info("Wrote to container stdin")
 
+	t.metrics.MessagesForwarded.WithLabelValues(string(diag.DirectionToContainer)).Inc()
+
 	return nil
 }
 
-// handleContainerExit handles container exit events.
+// handleContainerExit handles container exit events, retrying reattachment
+// with backoff per t.restartPolicy before falling back to tearing the
+// transport down entirely.
 func (t *StdioTransport) handleContainerExit(ctx context.Context) {
-	select {
-	case <-ctx.Done():
-		return
-	case err, ok := <-t.errorCh:
-		// Check if the channel is closed
-		if !ok {
-			logger.Log.Info(fmt.Sprintf("Container monitor channel closed for %s", t.containerName))
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err, ok := <-t.errorCh:
+			// Check if the channel is closed
+			if !ok {
+				logger.Log.Info(fmt.Sprintf("Container monitor channel closed for %s", t.containerName))
+				return
+			}
+
+			logger.Log.Info(fmt.Sprintf("Container %s exited: %v", t.containerName, err))
+
+			// Check if the transport is already stopped before trying to reattach/stop it
+			select {
+			case <-t.shutdownCh:
+				// Transport is already stopping or stopped
+				logger.Log.Info(fmt.Sprintf("Transport for %s is already stopping or stopped", t.containerName))
+				return
+			default:
+			}
+
+			if t.reattachWithBackoff(ctx) {
+				// Reattached successfully; go back to watching for the next exit.
+				continue
+			}
+
+			// Reattach is disabled or every attempt failed: fall back to the
+			// original hard-disconnect behavior.
+			stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if stopErr := t.Stop(stopCtx); stopErr != nil {
+				logger.Log.Error(fmt.Sprintf("Error stopping transport after container exit: %v", stopErr))
+			}
+			cancel()
 			return
 		}
+	}
+}
 
-		logger.Log.Info(fmt.Sprintf("Container %s exited: %v", t.containerName, err))
+// reattachWithBackoff retries reattaching to the container with exponential
+// backoff and jitter, up to t.restartPolicy.MaxRetries times, notifying
+// connected SSE clients that a reconnect is happening instead of dropping
+// them. It returns true if reattachment succeeded and monitoring was
+// re-armed, or false if the policy disables reattach or every attempt failed.
+func (t *StdioTransport) reattachWithBackoff(ctx context.Context) bool {
+	t.mutex.Lock()
+	policy := t.restartPolicy
+	t.mutex.Unlock()
+
+	if policy.MaxRetries <= 0 {
+		return false
+	}
+
+	t.notifyClientsOfRestart(ctx)
+
+	backoff := policy.InitialBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for attempt := 1; attempt <= policy.MaxRetries; attempt++ {
+		t.mutex.Lock()
+		t.retryCount = attempt
+		t.mutex.Unlock()
 
-		// Check if the transport is already stopped before trying to stop it
 		select {
+		case <-ctx.Done():
+			return false
 		case <-t.shutdownCh:
-			// Transport is already stopping or stopped
-			logger.Log.Info(fmt.Sprintf("Transport for %s is already stopping or stopped", t.containerName))
-			return
-		default:
-			// Transport is still running, stop it
-			// Create a context with timeout for stopping the transport
-			stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
+			return false
+		case <-time.After(addJitter(backoff)):
+		}
 
-			if stopErr := t.Stop(stopCtx); stopErr != nil {
-				logger.Log.Error(fmt.Sprintf("Error stopping transport after container exit: %v", stopErr))
+		logger.Log.Info(fmt.Sprintf("Attempting to reattach to %s (attempt %d/%d)", t.containerName, attempt, policy.MaxRetries))
+
+		if err := t.attemptReattach(ctx); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Reattach attempt %d/%d for %s failed: %v", attempt, policy.MaxRetries, t.containerName, err))
+
+			if policy.Multiplier > 0 {
+				backoff = time.Duration(float64(backoff) * policy.Multiplier)
 			}
+			if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+				backoff = policy.MaxBackoff
+			}
+			continue
 		}
+
+		t.mutex.Lock()
+		t.retryCount = 0
+		t.mutex.Unlock()
+		logger.Log.Info(fmt.Sprintf("Reattached to %s after %d attempt(s)", t.containerName, attempt))
+		t.metrics.ContainerRestarts.WithLabelValues(string(diag.RestartOutcomeSucceeded)).Inc()
+		return true
+	}
+
+	logger.Log.Error(fmt.Sprintf("Exhausted %d reattach attempts for %s", policy.MaxRetries, t.containerName))
+	t.metrics.ContainerRestarts.WithLabelValues(string(diag.RestartOutcomeFailed)).Inc()
+	return false
+}
+
+// attemptReattach starts the container again (recreating it if it's gone
+// rather than merely stopped), then re-attaches stdio, restarts message
+// processing, and re-arms the monitor.
+func (t *StdioTransport) attemptReattach(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.monitor != nil {
+		t.monitor.StopMonitoring()
+		t.monitor = nil
+	}
+	if t.stdin != nil {
+		_ = t.stdin.Close()
+		t.stdin = nil
+	}
+
+	if err := t.runtime.StartContainer(ctx, t.containerID); err != nil {
+		logger.Log.Info(fmt.Sprintf("Container %s could not be started, attempting to recreate it: %v", t.containerName, err))
+
+		containerOptions := rt.NewCreateContainerOptions()
+		containerOptions.AttachStdio = true
+
+		containerID, createErr := t.runtime.CreateContainer(
+			ctx,
+			t.image,
+			t.containerName,
+			t.cmdArgs,
+			t.envVars,
+			t.labels,
+			t.permissionProfile,
+			"stdio",
+			containerOptions,
+		)
+		if createErr != nil {
+			return fmt.Errorf("failed to recreate container: %w", createErr)
+		}
+		t.containerID = containerID
+
+		if err := t.runtime.StartContainer(ctx, t.containerID); err != nil {
+			return fmt.Errorf("failed to start recreated container: %w", err)
+		}
+	}
+
+	stdin, stdout, err := t.runtime.AttachContainer(ctx, t.containerID)
+	if err != nil {
+		return fmt.Errorf("failed to attach to container: %w", err)
+	}
+	t.stdin = stdin
+	t.stdout = stdout
+
+	go t.processMessages(ctx, t.stdin, t.stdout)
+
+	monitorRuntime, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create container monitor: %w", err)
+	}
+	t.monitor = container.NewMonitor(monitorRuntime, t.containerID, t.containerName)
+
+	errorCh, err := t.monitor.StartMonitoring(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start container monitoring: %w", err)
+	}
+	t.errorCh = errorCh
+
+	return nil
+}
+
+// notifyClientsOfRestart sends a synthetic "notifications/cancelled"
+// JSON-RPC notification to connected SSE clients before attempting to
+// reattach, so they see a server-initiated re-init instead of a hard
+// disconnect.
+func (t *StdioTransport) notifyClientsOfRestart(ctx context.Context) {
+	if t.httpProxy == nil {
+		return
+	}
+
+	notification := fmt.Sprintf(
+		`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"reason":"container %s is restarting"}}`,
+		t.containerName,
+	)
+
+	msg, err := jsonrpc2.DecodeMessage([]byte(notification))
+	if err != nil {
+		logger.Log.Warn(fmt.Sprintf("failed to build restart notification: %v", err))
+		return
+	}
+
+	if err := t.httpProxy.ForwardResponseToClients(ctx, msg); err != nil {
+		logger.Log.Warn(fmt.Sprintf("failed to notify clients of container restart: %v", err))
+	}
+}
+
+// addJitter adds up to 20% random jitter to d, to avoid multiple transports
+// all retrying in lockstep after a correlated failure.
+func addJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return d
 	}
+	jitter := time.Duration(rand.Int63n(int64(d)/5 + 1)) //nolint:gosec
+	return d + jitter
 }
@@ -0,0 +1,360 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/jsonrpc2"
+
+	"github.com/stacklok/toolhive/pkg/container"
+	rt "github.com/stacklok/toolhive/pkg/container/runtime"
+	"github.com/stacklok/toolhive/pkg/logger"
+	"github.com/stacklok/toolhive/pkg/permissions"
+	"github.com/stacklok/toolhive/pkg/transport/errors"
+	"github.com/stacklok/toolhive/pkg/transport/proxy/streamablehttp"
+	"github.com/stacklok/toolhive/pkg/transport/types"
+)
+
+// StreamableHTTPTransport implements the Transport interface using the
+// streamable HTTP transport. Like StdioTransport, it proxies between the MCP
+// client and the container's stdin/stdout; it differs only in the HTTP
+// front end it starts, which exposes a single POST endpoint instead of
+// separate SSE and messages endpoints.
+type StreamableHTTPTransport struct {
+	port          int
+	containerID   string
+	containerName string
+	runtime       rt.Runtime
+	debug         bool
+	middlewares   []types.Middleware
+
+	mutex sync.Mutex
+
+	shutdownCh chan struct{}
+	errorCh    <-chan error
+
+	httpProxy types.Proxy
+
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+
+	monitor rt.Monitor
+}
+
+// NewStreamableHTTPTransport creates a new streamable HTTP transport.
+func NewStreamableHTTPTransport(
+	port int,
+	runtime rt.Runtime,
+	debug bool,
+	middlewares ...types.Middleware,
+) *StreamableHTTPTransport {
+	return &StreamableHTTPTransport{
+		port:        port,
+		runtime:     runtime,
+		debug:       debug,
+		middlewares: middlewares,
+		shutdownCh:  make(chan struct{}),
+	}
+}
+
+// Mode returns the transport mode.
+func (*StreamableHTTPTransport) Mode() types.TransportType {
+	return types.TransportTypeStreamableHTTP
+}
+
+// Port returns the port used by the transport.
+func (t *StreamableHTTPTransport) Port() int {
+	return t.port
+}
+
+// Setup prepares the transport for use.
+func (t *StreamableHTTPTransport) Setup(
+	ctx context.Context,
+	runtime rt.Runtime,
+	containerName string,
+	image string,
+	cmdArgs []string,
+	envVars, labels map[string]string,
+	permissionProfile *permissions.Profile,
+) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.runtime = runtime
+	t.containerName = containerName
+
+	envVars["MCP_TRANSPORT"] = "streamable-http"
+
+	containerOptions := rt.NewCreateContainerOptions()
+	containerOptions.AttachStdio = true
+
+	logger.Log.Info(fmt.Sprintf("Creating container %s from image %s...", containerName, image))
+	containerID, err := t.runtime.CreateContainer(
+		ctx,
+		image,
+		containerName,
+		cmdArgs,
+		envVars,
+		labels,
+		permissionProfile,
+		"streamable-http",
+		containerOptions,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create container: %v", err)
+	}
+	t.containerID = containerID
+	logger.Log.Info(fmt.Sprintf("Container created with ID: %s", containerID))
+
+	return nil
+}
+
+// Start initializes the transport and begins processing messages.
+func (t *StreamableHTTPTransport) Start(ctx context.Context) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.containerID == "" {
+		return errors.ErrContainerIDNotSet
+	}
+
+	if t.containerName == "" {
+		return errors.ErrContainerNameNotSet
+	}
+
+	if t.runtime == nil {
+		return fmt.Errorf("container runtime not set")
+	}
+
+	var err error
+	t.stdin, t.stdout, err = t.runtime.AttachContainer(ctx, t.containerID)
+	if err != nil {
+		return fmt.Errorf("failed to attach to container: %w", err)
+	}
+
+	t.httpProxy = streamablehttp.NewStreamableHTTPProxy(t.port, t.containerName, t.middlewares...)
+	if err := t.httpProxy.Start(ctx); err != nil {
+		return err
+	}
+	logger.Log.Info("Streamable HTTP proxy started, processing messages...")
+
+	go t.processMessages(ctx, t.stdin, t.stdout)
+
+	monitorRuntime, err := container.NewFactory().Create(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create container monitor: %v", err)
+	}
+	t.monitor = container.NewMonitor(monitorRuntime, t.containerID, t.containerName)
+
+	t.errorCh, err = t.monitor.StartMonitoring(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start container monitoring: %v", err)
+	}
+
+	go t.handleContainerExit(ctx)
+
+	return nil
+}
+
+// Stop gracefully shuts down the transport and the container.
+func (t *StreamableHTTPTransport) Stop(ctx context.Context) error {
+	select {
+	case <-t.shutdownCh:
+		return nil
+	default:
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	select {
+	case <-t.shutdownCh:
+		return nil
+	default:
+		close(t.shutdownCh)
+	}
+
+	if t.monitor != nil {
+		t.monitor.StopMonitoring()
+		t.monitor = nil
+	}
+
+	if t.httpProxy != nil {
+		if err := t.httpProxy.Stop(ctx); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: Failed to stop HTTP proxy: %v", err))
+		}
+	}
+
+	if t.stdin != nil {
+		if err := t.stdin.Close(); err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: Failed to close stdin: %v", err))
+		}
+		t.stdin = nil
+	}
+
+	if t.runtime != nil && t.containerID != "" {
+		running, err := t.runtime.IsContainerRunning(ctx, t.containerID)
+		if err != nil {
+			logger.Log.Warn(fmt.Sprintf("Warning: Failed to check container status: %v", err))
+		} else if running {
+			if err := t.runtime.StopContainer(ctx, t.containerID); err != nil {
+				logger.Log.Warn(fmt.Sprintf("Warning: Failed to stop container: %v", err))
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsRunning checks if the transport is currently running.
+func (t *StreamableHTTPTransport) IsRunning(_ context.Context) (bool, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	select {
+	case <-t.shutdownCh:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// processMessages handles the message exchange between the client and container.
+func (t *StreamableHTTPTransport) processMessages(ctx context.Context, stdin io.WriteCloser, stdout io.ReadCloser) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-t.shutdownCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	go t.processStdout(ctx, stdout)
+	messageCh := t.httpProxy.GetMessageChannel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-messageCh:
+			if err := t.sendMessageToContainer(ctx, stdin, msg); err != nil {
+				logger.Log.Error(fmt.Sprintf("Error sending message to container: %v", err))
+			}
+		}
+	}
+}
+
+// processStdout reads from the container's stdout and processes JSON-RPC messages.
+func (t *StreamableHTTPTransport) processStdout(ctx context.Context, stdout io.ReadCloser) {
+	var buffer bytes.Buffer
+	readBuffer := make([]byte, 4096)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			n, err := stdout.Read(readBuffer)
+			if err != nil {
+				if err == io.EOF {
+					logger.Log.Info("Container stdout closed")
+				} else {
+					logger.Log.Error(fmt.Sprintf("Error reading from container stdout: %v", err))
+				}
+				return
+			}
+
+			if n > 0 {
+				buffer.Write(readBuffer[:n])
+				t.processBuffer(ctx, &buffer)
+			}
+		}
+	}
+}
+
+// processBuffer processes the accumulated data in the buffer.
+func (t *StreamableHTTPTransport) processBuffer(ctx context.Context, buffer *bytes.Buffer) {
+	for {
+		line, err := buffer.ReadString('\n')
+		if err == io.EOF {
+			buffer.WriteString(line)
+			break
+		}
+
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			line = line[:len(line)-1]
+		}
+
+		if line != "" {
+			t.parseAndForwardJSONRPC(ctx, line)
+		}
+	}
+}
+
+// parseAndForwardJSONRPC parses a JSON-RPC message and forwards it.
+func (t *StreamableHTTPTransport) parseAndForwardJSONRPC(ctx context.Context, line string) {
+	msg, err := jsonrpc2.DecodeMessage([]byte(line))
+	if err != nil {
+		logger.Log.Error(fmt.Sprintf("Error parsing JSON-RPC message: %v", err))
+		return
+	}
+
+	if err := t.httpProxy.ForwardResponseToClients(ctx, msg); err != nil {
+		logger.Log.Error(fmt.Sprintf("Error forwarding to streamable HTTP clients: %v", err))
+	}
+
+	if err := t.httpProxy.SendResponseMessage(msg); err != nil {
+		logger.Log.Error(fmt.Sprintf("Error sending to response channel: %v", err))
+	}
+}
+
+// sendMessageToContainer sends a JSON-RPC message to the container.
+func (*StreamableHTTPTransport) sendMessageToContainer(_ context.Context, stdin io.Writer, msg jsonrpc2.Message) error {
+	data, err := jsonrpc2.EncodeMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON-RPC message: %w", err)
+	}
+
+	data = append(data, '\n')
+
+	if _, err := stdin.Write(data); err != nil {
+		return fmt.Errorf("failed to write to container stdin: %w", err)
+	}
+
+	return nil
+}
+
+// handleContainerExit handles container exit events.
+func (t *StreamableHTTPTransport) handleContainerExit(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case err, ok := <-t.errorCh:
+		if !ok {
+			logger.Log.Info(fmt.Sprintf("Container monitor channel closed for %s", t.containerName))
+			return
+		}
+
+		logger.Log.Info(fmt.Sprintf("Container %s exited: %v", t.containerName, err))
+
+		select {
+		case <-t.shutdownCh:
+			logger.Log.Info(fmt.Sprintf("Transport for %s is already stopping or stopped", t.containerName))
+			return
+		default:
+			stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			if stopErr := t.Stop(stopCtx); stopErr != nil {
+				logger.Log.Error(fmt.Sprintf("Error stopping transport after container exit: %v", stopErr))
+			}
+		}
+	}
+}
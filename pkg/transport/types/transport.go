@@ -53,6 +53,12 @@ const (
 
 	// TransportTypeSSE represents the SSE transport.
 	TransportTypeSSE TransportType = "sse"
+
+	// TransportTypeStreamableHTTP represents the streamable HTTP transport: a
+	// single HTTP endpoint that accepts POSTed JSON-RPC requests and responds
+	// either with a plain JSON body or, when the client asks for it, an
+	// upgraded text/event-stream of responses and notifications.
+	TransportTypeStreamableHTTP TransportType = "streamable-http"
 )
 
 // String returns the string representation of the transport type.
@@ -60,6 +66,27 @@ func (t TransportType) String() string {
 	return string(t)
 }
 
+// HTTPProxyMode selects which HTTP proxy front end a transport exposes to
+// MCP clients over the network: legacy HTTP+SSE, the modern Streamable
+// HTTP transport, or (the default) both at once, auto-negotiated per
+// client.
+//
+//nolint:revive // Intentionally named HTTPProxyMode despite package name
+type HTTPProxyMode string
+
+const (
+	// HTTPProxyModeSSE serves only the legacy HTTP+SSE endpoints.
+	HTTPProxyModeSSE HTTPProxyMode = "sse"
+
+	// HTTPProxyModeStreamableHTTP serves only the Streamable HTTP endpoint.
+	HTTPProxyModeStreamableHTTP HTTPProxyMode = "streamable-http"
+
+	// HTTPProxyModeAuto serves both the legacy HTTP+SSE endpoints and the
+	// Streamable HTTP endpoint from a single listener, so one server can
+	// support both legacy and modern clients at once.
+	HTTPProxyModeAuto HTTPProxyMode = "auto"
+)
+
 // ParseTransportType parses a string into a transport type.
 func ParseTransportType(s string) (TransportType, error) {
 	switch s {
@@ -67,6 +94,24 @@ func ParseTransportType(s string) (TransportType, error) {
 		return TransportTypeStdio, nil
 	case "sse", "SSE":
 		return TransportTypeSSE, nil
+	case "streamable-http", "STREAMABLE-HTTP":
+		return TransportTypeStreamableHTTP, nil
+	default:
+		return "", errors.ErrUnsupportedTransport
+	}
+}
+
+// ParseHTTPProxyMode parses a string into an HTTPProxyMode. An empty string
+// is treated as HTTPProxyModeAuto, matching the zero value clients get when
+// the flag is left unset.
+func ParseHTTPProxyMode(s string) (HTTPProxyMode, error) {
+	switch s {
+	case "", "auto":
+		return HTTPProxyModeAuto, nil
+	case "sse":
+		return HTTPProxyModeSSE, nil
+	case "streamable-http":
+		return HTTPProxyModeStreamableHTTP, nil
 	default:
 		return "", errors.ErrUnsupportedTransport
 	}
@@ -96,6 +141,15 @@ type Proxy interface {
 	SendResponseMessage(msg jsonrpc2.Message) error
 }
 
+// ClientCounter is implemented by HTTP proxies that can report how many
+// clients are currently connected (SSE subscribers, or Streamable HTTP
+// sessions with an open GET stream), so a transport can publish it as a
+// metrics gauge without depending on a specific proxy's internals.
+type ClientCounter interface {
+	// ClientCount returns the number of clients currently connected.
+	ClientCount() int
+}
+
 // Config contains configuration options for a transport.
 type Config struct {
 	// Type is the type of transport to use.
@@ -0,0 +1,150 @@
+// Package trust evaluates container image signatures against a trust
+// policy before toolhive runs them, the same role containers/image's
+// policy.json plays for podman and skopeo.
+package trust
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ScopeType names how a policy scope decides whether an image is trusted.
+type ScopeType string
+
+const (
+	// ScopeInsecureAcceptAnything accepts any image in the scope without
+	// checking for a signature at all. Only appropriate for scopes toolhive
+	// doesn't otherwise care about (e.g. local/dev registries).
+	ScopeInsecureAcceptAnything ScopeType = "insecureAcceptAnything"
+	// ScopeSignedBy requires a valid GPG signature from one of KeyringPaths.
+	ScopeSignedBy ScopeType = "signedBy"
+	// ScopeSigstoreSigned requires a valid sigstore (cosign) signature
+	// matching Identity/Issuer, verified against Rekor/Fulcio.
+	ScopeSigstoreSigned ScopeType = "sigstoreSigned"
+)
+
+// Scope is one entry of a Policy, keyed by registry/repository in the
+// policy file (e.g. "docker.io/library", "ghcr.io/acme/mcp-servers").
+type Scope struct {
+	// Type selects how images under this scope are verified.
+	Type ScopeType `json:"type"`
+	// KeyringPaths lists GPG keyring files an image's signature must
+	// verify against. Only meaningful for ScopeSignedBy.
+	KeyringPaths []string `json:"keyringPaths,omitempty"`
+	// Identity is the expected Fulcio certificate subject (e.g. a GitHub
+	// Actions workflow ref, or a signer's email for keyless signing). Only
+	// meaningful for ScopeSigstoreSigned.
+	Identity string `json:"identity,omitempty"`
+	// Issuer is the expected Fulcio certificate OIDC issuer URL. Only
+	// meaningful for ScopeSigstoreSigned.
+	Issuer string `json:"issuer,omitempty"`
+	// RekorURL overrides the default public Rekor transparency log, for
+	// sigstore deployments that run their own. Only meaningful for
+	// ScopeSigstoreSigned.
+	RekorURL string `json:"rekorURL,omitempty"`
+}
+
+// Policy is a trust policy file: a set of scopes keyed by registry/repo,
+// evaluated with the longest matching prefix winning, matching
+// containers/image's policy.json semantics.
+type Policy struct {
+	// Scopes maps a registry/repo prefix (or "*" for the default) to the
+	// requirement images under it must satisfy.
+	Scopes map[string]Scope `json:"scopes"`
+}
+
+// Load reads and parses a policy file at path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust policy %s: %w", path, err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse trust policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+// LoadDir reads every *.json file in dir as a policy fragment and merges
+// their scopes into a single Policy, so an operator can ship one file per
+// registry instead of editing a single monolithic policy. Scopes are merged
+// in filename order; a later file's scope overrides an earlier file's scope
+// of the same name.
+func LoadDir(dir string) (*Policy, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust policy directory %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	merged := &Policy{Scopes: map[string]Scope{}}
+	for _, name := range names {
+		fragment, err := Load(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		for scopeName, scope := range fragment.Scopes {
+			merged.Scopes[scopeName] = scope
+		}
+	}
+	return merged, nil
+}
+
+// Merge overlays other's scopes on top of p's, with other taking precedence
+// on conflicts -- used to layer a --signature-policy-dir on top of a base
+// --signature-policy.
+func (p *Policy) Merge(other *Policy) *Policy {
+	merged := &Policy{Scopes: map[string]Scope{}}
+	for name, scope := range p.Scopes {
+		merged.Scopes[name] = scope
+	}
+	for name, scope := range other.Scopes {
+		merged.Scopes[name] = scope
+	}
+	return merged
+}
+
+// Covers reports whether some scope in p applies to imageRef.
+func (p *Policy) Covers(imageRef string) bool {
+	_, ok := p.scopeFor(imageRef)
+	return ok
+}
+
+// scopeFor returns the scope covering imageRef, matching the longest
+// registry/repo prefix in p.Scopes, falling back to the "*" default scope
+// if present. ok is false if no scope covers imageRef at all.
+func (p *Policy) scopeFor(imageRef string) (scope Scope, ok bool) {
+	repo := strings.SplitN(imageRef, "@", 2)[0]
+	repo = strings.SplitN(repo, ":", 2)[0]
+
+	bestLen := -1
+	for name, candidate := range p.Scopes {
+		if name == "*" {
+			continue
+		}
+		if (repo == name || strings.HasPrefix(repo, name+"/")) && len(name) > bestLen {
+			scope, ok, bestLen = candidate, true, len(name)
+		}
+	}
+	if ok {
+		return scope, true
+	}
+
+	if def, hasDefault := p.Scopes["*"]; hasDefault {
+		return def, true
+	}
+	return Scope{}, false
+}
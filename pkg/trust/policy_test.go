@@ -0,0 +1,114 @@
+package trust
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyScopeForLongestPrefixWins(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Scopes: map[string]Scope{
+		"docker.io":               {Type: ScopeInsecureAcceptAnything},
+		"docker.io/library":       {Type: ScopeSignedBy, KeyringPaths: []string{"/keys/library.pem"}},
+		"docker.io/library/redis": {Type: ScopeSigstoreSigned, Identity: "redis-signer"},
+	}}
+
+	scope, ok := policy.scopeFor("docker.io/library/redis:7")
+	require.True(t, ok)
+	assert.Equal(t, ScopeSigstoreSigned, scope.Type)
+
+	scope, ok = policy.scopeFor("docker.io/library/postgres:16")
+	require.True(t, ok)
+	assert.Equal(t, ScopeSignedBy, scope.Type)
+
+	scope, ok = policy.scopeFor("docker.io/other/thing:latest")
+	require.True(t, ok)
+	assert.Equal(t, ScopeInsecureAcceptAnything, scope.Type)
+}
+
+func TestPolicyScopeForFallsBackToDefault(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Scopes: map[string]Scope{
+		"*":                 {Type: ScopeInsecureAcceptAnything},
+		"ghcr.io/acme/mcps": {Type: ScopeSigstoreSigned, Identity: "acme-signer"},
+	}}
+
+	scope, ok := policy.scopeFor("ghcr.io/acme/mcps/server:latest")
+	require.True(t, ok)
+	assert.Equal(t, ScopeSigstoreSigned, scope.Type)
+
+	scope, ok = policy.scopeFor("quay.io/unrelated/image:latest")
+	require.True(t, ok)
+	assert.Equal(t, ScopeInsecureAcceptAnything, scope.Type)
+}
+
+func TestPolicyScopeForNoCoverage(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Scopes: map[string]Scope{
+		"ghcr.io/acme/mcps": {Type: ScopeSigstoreSigned, Identity: "acme-signer"},
+	}}
+
+	_, ok := policy.scopeFor("quay.io/unrelated/image:latest")
+	assert.False(t, ok)
+	assert.False(t, policy.Covers("quay.io/unrelated/image:latest"))
+}
+
+func TestPolicyScopeForDoesNotMatchRepoPrefixPartially(t *testing.T) {
+	t.Parallel()
+
+	// "ghcr.io/acme" must not match "ghcr.io/acme-other/..." -- only a full
+	// path-segment prefix counts.
+	policy := &Policy{Scopes: map[string]Scope{
+		"ghcr.io/acme": {Type: ScopeSigstoreSigned, Identity: "acme-signer"},
+	}}
+
+	_, ok := policy.scopeFor("ghcr.io/acme-other/image:latest")
+	assert.False(t, ok)
+}
+
+func TestPolicyMergeOtherTakesPrecedence(t *testing.T) {
+	t.Parallel()
+
+	base := &Policy{Scopes: map[string]Scope{
+		"docker.io": {Type: ScopeInsecureAcceptAnything},
+		"ghcr.io":   {Type: ScopeSignedBy, KeyringPaths: []string{"/keys/base.pem"}},
+	}}
+	overlay := &Policy{Scopes: map[string]Scope{
+		"ghcr.io": {Type: ScopeSigstoreSigned, Identity: "overlay-signer"},
+	}}
+
+	merged := base.Merge(overlay)
+
+	assert.Equal(t, ScopeInsecureAcceptAnything, merged.Scopes["docker.io"].Type)
+	require.Contains(t, merged.Scopes, "ghcr.io")
+	assert.Equal(t, ScopeSigstoreSigned, merged.Scopes["ghcr.io"].Type)
+	assert.Equal(t, "overlay-signer", merged.Scopes["ghcr.io"].Identity)
+}
+
+func TestLoadDirMergesFragmentsInFilenameOrder(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeJSON(t, filepath.Join(dir, "01-base.json"), `{"scopes":{"docker.io":{"type":"insecureAcceptAnything"},"ghcr.io":{"type":"signedBy","keyringPaths":["/keys/a.pem"]}}}`)
+	writeJSON(t, filepath.Join(dir, "02-override.json"), `{"scopes":{"ghcr.io":{"type":"sigstoreSigned","identity":"later-wins"}}}`)
+
+	policy, err := LoadDir(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, ScopeInsecureAcceptAnything, policy.Scopes["docker.io"].Type)
+	require.Contains(t, policy.Scopes, "ghcr.io")
+	assert.Equal(t, ScopeSigstoreSigned, policy.Scopes["ghcr.io"].Type)
+	assert.Equal(t, "later-wins", policy.Scopes["ghcr.io"].Identity)
+}
+
+func writeJSON(t *testing.T, path, contents string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+}
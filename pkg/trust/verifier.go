@@ -0,0 +1,145 @@
+package trust
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	pkgname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+
+	"github.com/stacklok/toolhive/pkg/logger"
+)
+
+// SignerRequirement pins an additional, per-image expected signer on top
+// of whatever a policy scope already requires, e.g. a curated registry
+// entry's registry.Server.Signer/Identity.
+type SignerRequirement struct {
+	// Identity is the expected sigstore Fulcio subject, or a GPG key
+	// fingerprint for signedBy scopes.
+	Identity string
+	// Issuer is the expected sigstore Fulcio OIDC issuer. Ignored for
+	// signedBy scopes.
+	Issuer string
+}
+
+// Verifier evaluates container image references against a loaded Policy.
+type Verifier struct {
+	policy *Policy
+}
+
+// NewVerifier builds a Verifier from a merged policy, ready for VerifyImage
+// calls.
+func NewVerifier(policy *Policy) *Verifier {
+	return &Verifier{policy: policy}
+}
+
+// Covers reports whether v's policy has a scope covering imageRef, so a
+// caller can fail fast on an uncovered image before doing other work (e.g.
+// materializing a permission profile) that VerifyImage's own error would
+// make moot anyway.
+func (v *Verifier) Covers(imageRef string) bool {
+	return v.policy.Covers(imageRef)
+}
+
+// VerifyImage checks imageRef against whichever scope in v.policy covers
+// it, additionally requiring required (if non-nil) to match the image's
+// signer. It returns an error describing why the image is untrusted,
+// including the case where no scope covers it at all -- trust policies are
+// deny-by-default, the same as containers/image's policy.json.
+func (v *Verifier) VerifyImage(ctx context.Context, imageRef string, required *SignerRequirement) error {
+	scope, ok := v.policy.scopeFor(imageRef)
+	if !ok {
+		return fmt.Errorf("no trust policy scope covers image %q; refusing to run it", imageRef)
+	}
+
+	switch scope.Type {
+	case ScopeInsecureAcceptAnything:
+		logger.Warnf("image %q is trusted via insecureAcceptAnything -- its signature (if any) was not checked", imageRef)
+		return nil
+	case ScopeSignedBy:
+		return v.verifySignedBy(imageRef, scope, required)
+	case ScopeSigstoreSigned:
+		return v.verifySigstoreSigned(ctx, imageRef, scope, required)
+	default:
+		return fmt.Errorf("image %q: unknown trust policy scope type %q", imageRef, scope.Type)
+	}
+}
+
+// verifySignedBy is not yet implemented: it validates that scope's
+// keyrings are at least readable and well-formed, but does not fetch
+// imageRef's actual signature (e.g. via the registry's signature extension
+// or an OCI referrer) or verify it against any keyring with openpgp/gpgme.
+// Returning nil here would silently turn every signedBy scope into a
+// no-op, defeating VerifyImage's deny-by-default guarantee -- so this
+// always errors instead, until real signature fetch/verification lands.
+func (*Verifier) verifySignedBy(imageRef string, scope Scope, _ *SignerRequirement) error {
+	if len(scope.KeyringPaths) == 0 {
+		return fmt.Errorf("image %q: signedBy scope has no keyringPaths configured", imageRef)
+	}
+
+	for _, keyringPath := range scope.KeyringPaths {
+		keyring, err := os.ReadFile(keyringPath)
+		if err != nil {
+			return fmt.Errorf("image %q: failed to read keyring %s: %w", imageRef, keyringPath, err)
+		}
+		block, _ := pem.Decode(keyring)
+		if block == nil {
+			return fmt.Errorf("image %q: keyring %s is not PEM-encoded", imageRef, keyringPath)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("image %q: failed to parse keyring %s: %w", imageRef, keyringPath, err)
+		}
+	}
+
+	return fmt.Errorf(
+		"image %q: signedBy verification is not implemented -- refusing to treat it as trusted rather than silently skip its signature check",
+		imageRef,
+	)
+}
+
+// verifySigstoreSigned checks imageRef against Rekor/Fulcio via cosign,
+// requiring the certificate's subject and issuer to match scope (and
+// required, if it narrows scope further).
+func (*Verifier) verifySigstoreSigned(ctx context.Context, imageRef string, scope Scope, required *SignerRequirement) error {
+	identity := scope.Identity
+	issuer := scope.Issuer
+	if required != nil {
+		if required.Identity != "" {
+			identity = required.Identity
+		}
+		if required.Issuer != "" {
+			issuer = required.Issuer
+		}
+	}
+	if identity == "" || issuer == "" {
+		return fmt.Errorf("image %q: sigstoreSigned scope requires both identity and issuer", imageRef)
+	}
+
+	ref, err := pkgname.ParseReference(imageRef)
+	if err != nil {
+		return fmt.Errorf("image %q: failed to parse reference: %w", imageRef, err)
+	}
+
+	checkOpts := &cosign.CheckOpts{
+		ClaimVerifier: cosign.SimpleClaimVerifier,
+		Identities: []cosign.Identity{
+			{Subject: identity, Issuer: issuer},
+		},
+		RekorPubKeys: nil, // resolved from the public Rekor instance, or scope.RekorURL below
+	}
+	if scope.RekorURL != "" {
+		checkOpts.RekorURL = scope.RekorURL
+	}
+
+	_, bundleVerified, err := cosign.VerifyImageSignatures(ctx, ref, checkOpts)
+	if err != nil {
+		return fmt.Errorf("image %q: sigstore verification failed: %w", imageRef, err)
+	}
+	if !bundleVerified {
+		return fmt.Errorf("image %q: sigstore signature did not verify against a transparency log entry", imageRef)
+	}
+	return nil
+}
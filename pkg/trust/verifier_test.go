@@ -0,0 +1,180 @@
+package trust
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyImageNoCoveringScopeIsDenied(t *testing.T) {
+	t.Parallel()
+
+	v := NewVerifier(&Policy{Scopes: map[string]Scope{
+		"ghcr.io/acme": {Type: ScopeInsecureAcceptAnything},
+	}})
+
+	err := v.VerifyImage(context.Background(), "quay.io/unrelated/image:latest", nil)
+	require.Error(t, err)
+}
+
+func TestVerifyImageInsecureAcceptAnythingAlwaysPasses(t *testing.T) {
+	t.Parallel()
+
+	v := NewVerifier(&Policy{Scopes: map[string]Scope{
+		"*": {Type: ScopeInsecureAcceptAnything},
+	}})
+
+	err := v.VerifyImage(context.Background(), "docker.io/library/anything:latest", nil)
+	assert.NoError(t, err)
+}
+
+func TestVerifyImageUnknownScopeTypeErrors(t *testing.T) {
+	t.Parallel()
+
+	v := NewVerifier(&Policy{Scopes: map[string]Scope{
+		"*": {Type: ScopeType("madeUpScopeType")},
+	}})
+
+	err := v.VerifyImage(context.Background(), "docker.io/library/anything:latest", nil)
+	require.Error(t, err)
+}
+
+func TestVerifySignedByFailsClosed(t *testing.T) {
+	t.Parallel()
+
+	v := &Verifier{}
+	keyringPath := writeSelfSignedCertPEM(t)
+
+	tests := []struct {
+		name    string
+		scope   Scope
+		wantErr string
+	}{
+		{
+			name:    "no keyring paths configured",
+			scope:   Scope{Type: ScopeSignedBy},
+			wantErr: "no keyringPaths configured",
+		},
+		{
+			name:    "keyring file does not exist",
+			scope:   Scope{Type: ScopeSignedBy, KeyringPaths: []string{filepath.Join(t.TempDir(), "missing.pem")}},
+			wantErr: "failed to read keyring",
+		},
+		{
+			name:    "keyring is not PEM-encoded",
+			scope:   Scope{Type: ScopeSignedBy, KeyringPaths: []string{writeNonPEMFile(t)}},
+			wantErr: "is not PEM-encoded",
+		},
+		{
+			name:    "keyring is PEM but not a valid certificate",
+			scope:   Scope{Type: ScopeSignedBy, KeyringPaths: []string{writeGarbagePEMFile(t)}},
+			wantErr: "failed to parse keyring",
+		},
+		{
+			name:    "keyring is a valid, readable, matching certificate",
+			scope:   Scope{Type: ScopeSignedBy, KeyringPaths: []string{keyringPath}},
+			wantErr: "signedBy verification is not implemented",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.verifySignedBy("docker.io/library/example:latest", tt.scope, nil)
+			require.Error(t, err, "verifySignedBy must never return nil -- signedBy is deny-by-default until real signature verification exists")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerifySigstoreSignedRequiresIdentityAndIssuer(t *testing.T) {
+	t.Parallel()
+
+	v := &Verifier{}
+
+	tests := []struct {
+		name     string
+		scope    Scope
+		required *SignerRequirement
+	}{
+		{name: "no identity or issuer anywhere", scope: Scope{Type: ScopeSigstoreSigned}},
+		{name: "identity but no issuer", scope: Scope{Type: ScopeSigstoreSigned, Identity: "signer@example.com"}},
+		{name: "issuer but no identity", scope: Scope{Type: ScopeSigstoreSigned, Issuer: "https://issuer.example.com"}},
+		{
+			name:     "required narrows identity but scope still lacks issuer",
+			scope:    Scope{Type: ScopeSigstoreSigned, Identity: "signer@example.com"},
+			required: &SignerRequirement{Identity: "other-signer@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.verifySigstoreSigned(context.Background(), "docker.io/library/example:latest", tt.scope, tt.required)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "requires both identity and issuer")
+		})
+	}
+}
+
+func TestVerifySigstoreSignedRejectsUnparseableReference(t *testing.T) {
+	t.Parallel()
+
+	v := &Verifier{}
+	scope := Scope{Type: ScopeSigstoreSigned, Identity: "signer@example.com", Issuer: "https://issuer.example.com"}
+
+	err := v.verifySigstoreSigned(context.Background(), "this is not a valid image reference!!", scope, nil)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "failed to parse reference")
+}
+
+// writeSelfSignedCertPEM writes a throwaway self-signed certificate to a
+// temp file and returns its path, for exercising verifySignedBy's keyring
+// parsing without a checked-in fixture.
+func writeSelfSignedCertPEM(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "signer.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return path
+}
+
+func writeNonPEMFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "not-pem.pem")
+	require.NoError(t, os.WriteFile(path, []byte("this is definitely not PEM-encoded data"), 0o600))
+	return path
+}
+
+func writeGarbagePEMFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "garbage.pem")
+	block := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("not a real certificate")})
+	require.NoError(t, os.WriteFile(path, block, 0o600))
+	return path
+}